@@ -0,0 +1,58 @@
+package avro
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// MarshalJSON implements json.Marshaler.
+func (f Field) MarshalJSON() ([]byte, error) {
+	type field Field
+	data, err := json.Marshal(field(f))
+	if err != nil {
+		return nil, err
+	}
+	data, err = appendProps(data, f.Props, "name", "doc", "type", "aliases")
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", f.Name, err)
+	}
+	return data, nil
+}
+
+// appendProps returns the JSON object data with props added as extra attributes, sorted by key.
+// Props with the same key as one of the reserved standard attributes are rejected.
+func appendProps(data []byte, props map[string]interface{}, reserved ...string) ([]byte, error) {
+	if len(props) == 0 {
+		return data, nil
+	}
+	keys := make([]string, 0, len(props))
+	for key := range props {
+		for _, r := range reserved {
+			if key == r {
+				return nil, fmt.Errorf("prop '%s' conflicts with a standard attribute", key)
+			}
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var b bytes.Buffer
+	b.Write(data[:len(data)-1])
+	for _, key := range keys {
+		keyData, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		valueData, err := json.Marshal(props[key])
+		if err != nil {
+			return nil, fmt.Errorf("prop '%s': %w", key, err)
+		}
+		b.WriteByte(',')
+		b.Write(keyData)
+		b.WriteByte(':')
+		b.Write(valueData)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
@@ -0,0 +1,69 @@
+package avro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestField_MarshalJSON(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		field       Field
+		expected    string
+		errContains string
+	}{
+		{
+			name:     "no props",
+			field:    Field{Name: "a", Type: String()},
+			expected: `{"name":"a","type":{"type":"string"}}`,
+		},
+		{
+			name: "props",
+			field: Field{
+				Name:  "a",
+				Doc:   "doc",
+				Type:  String(),
+				Props: map[string]interface{}{"z": 1, "proto.oneof": "kind"},
+			},
+			expected: `{"name":"a","doc":"doc","type":{"type":"string"},"proto.oneof":"kind","z":1}`,
+		},
+		{
+			name:     "aliases",
+			field:    Field{Name: "a_b", Type: String(), Aliases: []string{"aB"}},
+			expected: `{"name":"a_b","type":{"type":"string"},"aliases":["aB"]}`,
+		},
+		{
+			name:        "reserved prop",
+			field:       Field{Name: "a", Type: String(), Props: map[string]interface{}{"type": "int"}},
+			errContains: "field a: prop 'type' conflicts with a standard attribute",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(tt.field)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, string(got))
+		})
+	}
+}
+
+func TestAnnotatedPrimitive_MarshalJSON(t *testing.T) {
+	got, err := json.Marshal(AnnotatedPrimitive{
+		Primitive: TimestampMicros(),
+		Props:     map[string]interface{}{"java-class": "java.time.Instant", "connect.name": "timestamp"},
+	})
+	assert.NilError(t, err)
+	assert.Equal(
+		t,
+		`{"type":"long","logicalType":"timestamp-micros","connect.name":"timestamp","java-class":"java.time.Instant"}`,
+		string(got),
+	)
+	_, err = json.Marshal(AnnotatedPrimitive{Primitive: Date(), Props: map[string]interface{}{"logicalType": "x"}})
+	assert.ErrorContains(t, err, "int: prop 'logicalType' conflicts with a standard attribute")
+}
@@ -0,0 +1,29 @@
+package avro
+
+import "strings"
+
+// FullName returns the full name of a named type according to the Avro specification.
+// A name that contains a dot is already a full name. Otherwise, the name is qualified with
+// namespace, or with the namespace of the enclosing named type when namespace is empty.
+// See: https://avro.apache.org/docs/current/spec.html#names
+func FullName(name, namespace, enclosingNamespace string) string {
+	if strings.Contains(name, ".") {
+		return name
+	}
+	if namespace == "" {
+		namespace = enclosingNamespace
+	}
+	if namespace == "" {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// NamespaceOf returns the namespace of a full name, which is the enclosing namespace
+// of the types defined within the named type.
+func NamespaceOf(fullName string) string {
+	if i := strings.LastIndex(fullName, "."); i >= 0 {
+		return fullName[:i]
+	}
+	return ""
+}
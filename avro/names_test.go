@@ -0,0 +1,64 @@
+package avro
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFullName(t *testing.T) {
+	for _, tt := range []struct {
+		name               string
+		typeName           string
+		namespace          string
+		enclosingNamespace string
+		expected           string
+	}{
+		{
+			name:      "name and namespace",
+			typeName:  "X",
+			namespace: "org.foo",
+			expected:  "org.foo.X",
+		},
+		{
+			name:      "full name ignores namespace",
+			typeName:  "org.foo.X",
+			namespace: "org.bar",
+			expected:  "org.foo.X",
+		},
+		{
+			name:               "inherits enclosing namespace",
+			typeName:           "X",
+			enclosingNamespace: "org.foo",
+			expected:           "org.foo.X",
+		},
+		{
+			name:               "namespace overrides enclosing namespace",
+			typeName:           "X",
+			namespace:          "org.bar",
+			enclosingNamespace: "org.foo",
+			expected:           "org.bar.X",
+		},
+		{
+			name:               "full name ignores enclosing namespace",
+			typeName:           "org.bar.X",
+			enclosingNamespace: "org.foo",
+			expected:           "org.bar.X",
+		},
+		{
+			name:     "null namespace",
+			typeName: "X",
+			expected: "X",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FullName(tt.typeName, tt.namespace, tt.enclosingNamespace))
+		})
+	}
+}
+
+func TestNamespaceOf(t *testing.T) {
+	assert.Equal(t, "org.foo", NamespaceOf("org.foo.X"))
+	assert.Equal(t, "", NamespaceOf("X"))
+}
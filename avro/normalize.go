@@ -0,0 +1,179 @@
+package avro
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// NormalizeMode determines how NormalizeSchema represents named types that are used more than once.
+type NormalizeMode int
+
+const (
+	// NormalizeInline expands references to named types into full definitions.
+	// References within the definition of a recursive type are kept, since they can not be expanded.
+	NormalizeInline NormalizeMode = iota
+	// NormalizeReference keeps the first definition of each named type, and replaces
+	// later definitions of the same type with references.
+	NormalizeReference
+)
+
+// NormalizeSchema returns an equivalent JSON encoded Avro schema, where named types are
+// represented according to mode.
+func NormalizeSchema(schema json.RawMessage, mode NormalizeMode) (json.RawMessage, error) {
+	decoder := json.NewDecoder(bytes.NewReader(schema))
+	decoder.UseNumber()
+	var node interface{}
+	if err := decoder.Decode(&node); err != nil {
+		return nil, fmt.Errorf("normalize schema: %w", err)
+	}
+	n := normalizer{
+		mode:        mode,
+		definitions: make(map[string]map[string]interface{}),
+	}
+	normalized, err := n.normalize(node, "")
+	if err != nil {
+		return nil, fmt.Errorf("normalize schema: %w", err)
+	}
+	return json.Marshal(normalized)
+}
+
+type normalizer struct {
+	mode NormalizeMode
+	// definitions holds named type definitions by full name.
+	definitions map[string]map[string]interface{}
+	// enclosing holds the full names of the named types being normalized.
+	enclosing []string
+}
+
+func (n *normalizer) normalize(node interface{}, namespace string) (interface{}, error) {
+	switch node := node.(type) {
+	case string:
+		return n.normalizeName(node, namespace)
+	case []interface{}:
+		union := make([]interface{}, 0, len(node))
+		for _, member := range node {
+			normalized, err := n.normalize(member, namespace)
+			if err != nil {
+				return nil, err
+			}
+			union = append(union, normalized)
+		}
+		return union, nil
+	case map[string]interface{}:
+		return n.normalizeObject(node, namespace)
+	}
+	return nil, fmt.Errorf("unexpected schema %v", node)
+}
+
+func (n *normalizer) normalizeName(name string, namespace string) (interface{}, error) {
+	if isPrimitiveName(name) {
+		return name, nil
+	}
+	fullName := FullName(name, "", namespace)
+	definition, ok := n.definitions[fullName]
+	if !ok {
+		return nil, fmt.Errorf("unknown named type '%s'", fullName)
+	}
+	if n.mode == NormalizeReference || n.isEnclosing(fullName) {
+		return fullName, nil
+	}
+	// the definition is moved, so its name is made absolute to keep the namespace of nested names.
+	expanded := make(map[string]interface{}, len(definition))
+	for key, value := range definition {
+		expanded[key] = value
+	}
+	expanded["name"] = fullName
+	delete(expanded, "namespace")
+	return n.normalizeObject(expanded, namespace)
+}
+
+func (n *normalizer) normalizeObject(node map[string]interface{}, namespace string) (interface{}, error) {
+	typ, ok := node["type"].(string)
+	if !ok {
+		// a nested schema, such as {"type": {"type": "array", ...}}
+		normalized, err := n.normalize(node["type"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		return withKey(node, "type", normalized), nil
+	}
+	switch Type(typ) {
+	case RecordType, EnumType, "error", "fixed":
+		return n.normalizeNamed(node, namespace)
+	case ArrayType:
+		items, err := n.normalize(node["items"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		return withKey(node, "items", items), nil
+	case MapType:
+		values, err := n.normalize(node["values"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		return withKey(node, "values", values), nil
+	}
+	return node, nil
+}
+
+func (n *normalizer) normalizeNamed(node map[string]interface{}, namespace string) (interface{}, error) {
+	name, ok := node["name"].(string)
+	if !ok {
+		return nil, fmt.Errorf("named type without name: %v", node)
+	}
+	explicitNamespace, _ := node["namespace"].(string)
+	fullName := FullName(name, explicitNamespace, namespace)
+	if _, ok := n.definitions[fullName]; ok && n.mode == NormalizeReference {
+		return fullName, nil
+	}
+	if _, ok := n.definitions[fullName]; !ok {
+		n.definitions[fullName] = node
+	}
+	fields, ok := node["fields"].([]interface{})
+	if !ok {
+		return node, nil
+	}
+	n.enclosing = append(n.enclosing, fullName)
+	defer func() { n.enclosing = n.enclosing[:len(n.enclosing)-1] }()
+	normalizedFields := make([]interface{}, 0, len(fields))
+	for _, field := range fields {
+		fieldObject, ok := field.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("record %s: unexpected field %v", fullName, field)
+		}
+		fieldType, err := n.normalize(fieldObject["type"], NamespaceOf(fullName))
+		if err != nil {
+			return nil, fmt.Errorf("record %s: field %v: %w", fullName, fieldObject["name"], err)
+		}
+		normalizedFields = append(normalizedFields, withKey(fieldObject, "type", fieldType))
+	}
+	return withKey(node, "fields", normalizedFields), nil
+}
+
+func (n *normalizer) isEnclosing(fullName string) bool {
+	for _, enclosing := range n.enclosing {
+		if enclosing == fullName {
+			return true
+		}
+	}
+	return false
+}
+
+// withKey returns a copy of object with key set to value.
+func withKey(object map[string]interface{}, key string, value interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(object))
+	for k, v := range object {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
+
+func isPrimitiveName(name string) bool {
+	switch Type(name) {
+	case NullType, BooleanType, IntType, LongType, FloatType, DoubleType, BytesType, StringType:
+		return true
+	}
+	return false
+}
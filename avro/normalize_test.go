@@ -0,0 +1,146 @@
+package avro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"gotest.tools/v3/assert"
+)
+
+const normalizeTestSchema = `{
+  "type": "record",
+  "name": "Node",
+  "namespace": "example",
+  "fields": [
+    {"name": "kind", "type": {"type": "enum", "name": "Kind", "symbols": ["A", "B"]}},
+    {"name": "previous_kind", "type": ["null", "Kind"]},
+    {"name": "children", "type": {"type": "array", "items": "Node"}},
+    {
+      "name": "location",
+      "type": {
+        "type": "record",
+        "name": "Location",
+        "namespace": "other",
+        "fields": [{"name": "kind", "type": "example.Kind"}]
+      }
+    }
+  ]
+}`
+
+func TestNormalizeSchema(t *testing.T) {
+	kind := `{"type": "enum", "name": "example.Kind", "symbols": ["A", "B"]}`
+	for _, tt := range []struct {
+		name     string
+		mode     NormalizeMode
+		expected string
+	}{
+		{
+			name: "inline",
+			mode: NormalizeInline,
+			expected: `{
+  "type": "record",
+  "name": "Node",
+  "namespace": "example",
+  "fields": [
+    {"name": "kind", "type": {"type": "enum", "name": "Kind", "symbols": ["A", "B"]}},
+    {"name": "previous_kind", "type": ["null", ` + kind + `]},
+    {"name": "children", "type": {"type": "array", "items": "example.Node"}},
+    {
+      "name": "location",
+      "type": {
+        "type": "record",
+        "name": "Location",
+        "namespace": "other",
+        "fields": [{"name": "kind", "type": ` + kind + `}]
+      }
+    }
+  ]
+}`,
+		},
+		{
+			name: "reference",
+			mode: NormalizeReference,
+			expected: `{
+  "type": "record",
+  "name": "Node",
+  "namespace": "example",
+  "fields": [
+    {"name": "kind", "type": {"type": "enum", "name": "Kind", "symbols": ["A", "B"]}},
+    {"name": "previous_kind", "type": ["null", "example.Kind"]},
+    {"name": "children", "type": {"type": "array", "items": "example.Node"}},
+    {
+      "name": "location",
+      "type": {
+        "type": "record",
+        "name": "Location",
+        "namespace": "other",
+        "fields": [{"name": "kind", "type": "example.Kind"}]
+      }
+    }
+  ]
+}`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeSchema(json.RawMessage(normalizeTestSchema), tt.mode)
+			assert.NilError(t, err)
+			assertEqualJSON(t, tt.expected, got)
+			assertEquivalentSchemas(t, normalizeTestSchema, string(got))
+		})
+	}
+}
+
+func TestNormalizeSchema_RoundTrip(t *testing.T) {
+	inline, err := NormalizeSchema(json.RawMessage(normalizeTestSchema), NormalizeInline)
+	assert.NilError(t, err)
+	reference, err := NormalizeSchema(inline, NormalizeReference)
+	assert.NilError(t, err)
+	expected, err := NormalizeSchema(json.RawMessage(normalizeTestSchema), NormalizeReference)
+	assert.NilError(t, err)
+	assertEqualJSON(t, string(expected), reference)
+	inlineAgain, err := NormalizeSchema(reference, NormalizeInline)
+	assert.NilError(t, err)
+	assertEqualJSON(t, string(inline), inlineAgain)
+}
+
+func TestNormalizeSchema_UnknownReference(t *testing.T) {
+	_, err := NormalizeSchema(json.RawMessage(`{"type": "array", "items": "example.Unknown"}`), NormalizeInline)
+	assert.ErrorContains(t, err, "unknown named type 'example.Unknown'")
+}
+
+func assertEqualJSON(t *testing.T, expected string, got json.RawMessage) {
+	t.Helper()
+	var expectedValue, gotValue interface{}
+	assert.NilError(t, json.Unmarshal([]byte(expected), &expectedValue))
+	assert.NilError(t, json.Unmarshal(got, &gotValue))
+	assert.DeepEqual(t, expectedValue, gotValue)
+}
+
+// assertEquivalentSchemas asserts that a datum has the same binary encoding with both schemas.
+func assertEquivalentSchemas(t *testing.T, a, b string) {
+	t.Helper()
+	datum := map[string]interface{}{
+		"kind":          "B",
+		"previous_kind": map[string]interface{}{"example.Kind": "A"},
+		"children": []interface{}{
+			map[string]interface{}{
+				"kind":          "A",
+				"previous_kind": nil,
+				"children":      []interface{}{},
+				"location":      map[string]interface{}{"kind": "A"},
+			},
+		},
+		"location": map[string]interface{}{"kind": "B"},
+	}
+	var encoded [][]byte
+	for _, schema := range []string{a, b} {
+		codec, err := goavro.NewCodec(schema)
+		assert.NilError(t, err)
+		binary, err := codec.BinaryFromNative(nil, datum)
+		assert.NilError(t, err)
+		encoded = append(encoded, binary)
+	}
+	assert.DeepEqual(t, encoded[0], encoded[1])
+}
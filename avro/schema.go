@@ -2,6 +2,11 @@
 // to spec at http://avro.apache.org/docs/current/spec.html.
 package avro
 
+import (
+	"encoding/json"
+	"fmt"
+)
+
 // Schema describes an Avro schema.
 // JSON encoding of a Schema value matches the specification
 // for a schema declaration.
@@ -26,6 +31,8 @@ const (
 	RecordType  Type = "record"
 	EnumType    Type = "enum"
 	ArrayType   Type = "array"
+	MapType     Type = "map"
+	FixedType   Type = "fixed"
 )
 
 // LogicalType is an Avro primitive or complex type with extra attributes to represent a derived type.
@@ -36,6 +43,7 @@ const (
 	DateLogicalType            LogicalType = "date"
 	TimeMicrosLogicalType      LogicalType = "time-micros"
 	TimestampMicrosLogicalType LogicalType = "timestamp-micros"
+	TimestampMillisLogicalType LogicalType = "timestamp-millis"
 )
 
 type Reference string
@@ -53,6 +61,26 @@ type Primitive struct {
 
 func (p Primitive) isSchema() {}
 
+// AnnotatedPrimitive is a primitive type with custom properties, such as "java-class", which some tools
+// expect on logical types. The properties are encoded as extra attributes after the standard ones.
+type AnnotatedPrimitive struct {
+	Primitive
+	Props map[string]interface{}
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p AnnotatedPrimitive) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(p.Primitive)
+	if err != nil {
+		return nil, err
+	}
+	data, err = appendProps(data, p.Props, "type", "logicalType")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", p.Type, err)
+	}
+	return data, nil
+}
+
 func Null() Primitive {
 	return Primitive{Type: NullType}
 }
@@ -99,6 +127,11 @@ type Field struct {
 	Name string `json:"name"`
 	Doc  string `json:"doc,omitempty"`
 	Type Schema `json:"type"`
+	// Aliases are alternative names of the field, that readers match the fields of writer schemas against.
+	Aliases []string `json:"aliases,omitempty"`
+	// Props holds custom properties of the field, encoded as extra attributes after the standard ones.
+	// See: http://avro.apache.org/docs/current/spec.html#schema_complex
+	Props map[string]interface{} `json:"-"`
 }
 
 type Enum struct {
@@ -107,6 +140,8 @@ type Enum struct {
 	Doc       string   `json:"doc,omitempty"`
 	Name      string   `json:"name"`
 	Symbols   []string `json:"symbols"`
+	// Default is the symbol that readers use for symbols that are not in Symbols.
+	Default string `json:"default,omitempty"`
 }
 
 func (e Enum) isSchema() {}
@@ -118,6 +153,13 @@ type Array struct {
 
 func (e Array) isSchema() {}
 
+type Map struct {
+	Type   Type   `json:"type"`
+	Values Schema `json:"values"`
+}
+
+func (e Map) isSchema() {}
+
 type Fixed struct {
 	Type      Type   `json:"type"`
 	Name      string `json:"name"`
@@ -148,6 +190,13 @@ func TimestampMicros() Primitive {
 	}
 }
 
+func TimestampMillis() Primitive {
+	return Primitive{
+		Type:        LongType,
+		LogicalType: TimestampMillisLogicalType,
+	}
+}
+
 func Nullable(schema Schema) Union {
 	if union, ok := schema.(Union); ok {
 		var found bool
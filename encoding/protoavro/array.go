@@ -0,0 +1,104 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/linkedin/goavro/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// NewArrayDecoder returns a decoder of a JSON array of Avro JSON encoded messages read from reader.
+// Elements are read and decoded one at a time by Next, so that large arrays are not held in memory.
+// Each element is decoded into a message returned by newMessage, using the schema inferred for it.
+func (o UnmarshalOptions) NewArrayDecoder(reader io.Reader, newMessage func() proto.Message) *ArrayDecoder {
+	return &ArrayDecoder{
+		opts:       o,
+		newMessage: newMessage,
+		decoder:    json.NewDecoder(reader),
+	}
+}
+
+// ArrayDecoder decodes the elements of a JSON array of Avro JSON encoded messages.
+type ArrayDecoder struct {
+	opts       UnmarshalOptions
+	newMessage func() proto.Message
+	decoder    *json.Decoder
+	codec      *goavro.Codec
+	// index is the index of the next element.
+	index int
+	// started is true when the opening bracket of the array has been read.
+	started bool
+	// err is the error that decoding stopped with.
+	err error
+}
+
+// Next decodes the next element of the array.
+// It returns io.EOF after the last element, and keeps returning the same error after decoding has failed.
+func (d *ArrayDecoder) Next() (proto.Message, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	message, err := d.next()
+	if err != nil {
+		d.err = err
+		return nil, err
+	}
+	return message, nil
+}
+
+func (d *ArrayDecoder) next() (proto.Message, error) {
+	if !d.started {
+		token, err := d.decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("read array: %w", unexpectedEOF(err))
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != '[' {
+			return nil, fmt.Errorf("read array: expected '[', got %v", token)
+		}
+		d.started = true
+	}
+	if !d.decoder.More() {
+		token, err := d.decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("read array: %w", unexpectedEOF(err))
+		}
+		if delim, ok := token.(json.Delim); !ok || delim != ']' {
+			return nil, fmt.Errorf("read array: expected ']', got %v", token)
+		}
+		if _, err := d.decoder.Token(); err != io.EOF {
+			return nil, fmt.Errorf("read array: unexpected data after array")
+		}
+		return nil, io.EOF
+	}
+	var element json.RawMessage
+	if err := d.decoder.Decode(&element); err != nil {
+		return nil, fmt.Errorf("element %d: %w", d.index, unexpectedEOF(err))
+	}
+	message := d.newMessage()
+	if d.codec == nil {
+		codec, err := d.opts.newCodec(message.ProtoReflect().Descriptor())
+		if err != nil {
+			return nil, err
+		}
+		d.codec = codec
+	}
+	data, _, err := d.codec.NativeFromTextual(element)
+	if err != nil {
+		return nil, fmt.Errorf("element %d: parse: %w", d.index, err)
+	}
+	if err := d.opts.Unmarshal(data, message); err != nil {
+		return nil, fmt.Errorf("element %d: %w", d.index, err)
+	}
+	d.index++
+	return message, nil
+}
+
+// unexpectedEOF returns io.ErrUnexpectedEOF for io.EOF, since the array is truncated when it ends before ']'.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
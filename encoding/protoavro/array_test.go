@@ -0,0 +1,112 @@
+package protoavro
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnmarshalOptions_NewArrayDecoder(t *testing.T) {
+	newBook := func() proto.Message { return &library.Book{} }
+	const book = `{"google.example.library.v1.Book": {
+		"name": {"string": "shelves/1/books/1"},
+		"author": {"string": ""},
+		"title": {"string": ""},
+		"read": {"boolean": false}
+	}}`
+
+	t.Run("large array", func(t *testing.T) {
+		const n = 10000
+		codec, err := SchemaOptions{}.newCodec((&library.Book{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		var input strings.Builder
+		input.WriteString("[\n")
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				input.WriteString(",\n")
+			}
+			datum, err := SchemaOptions{}.Encode(&library.Book{Name: fmt.Sprintf("shelves/1/books/%d", i)})
+			assert.NilError(t, err)
+			text, err := codec.TextualFromNative(nil, datum)
+			assert.NilError(t, err)
+			input.Write(text)
+		}
+		input.WriteString("\n]\n")
+		decoder := UnmarshalOptions{}.NewArrayDecoder(strings.NewReader(input.String()), newBook)
+		for i := 0; i < n; i++ {
+			got, err := decoder.Next()
+			assert.NilError(t, err)
+			expected := &library.Book{Name: fmt.Sprintf("shelves/1/books/%d", i)}
+			assert.DeepEqual(t, expected, got, protocmp.Transform())
+		}
+		_, err = decoder.Next()
+		assert.Equal(t, io.EOF, err)
+		_, err = decoder.Next()
+		assert.Equal(t, io.EOF, err)
+	})
+
+	for _, tt := range []struct {
+		name        string
+		input       string
+		expected    int
+		errContains string
+	}{
+		{
+			name:  "empty array",
+			input: "[]",
+		},
+		{
+			name:        "empty input",
+			input:       "",
+			errContains: "read array: unexpected EOF",
+		},
+		{
+			name:        "not an array",
+			input:       `{"name": {"string": "shelves/1/books/1"}}`,
+			errContains: "read array: expected '[', got {",
+		},
+		{
+			name:        "truncated element",
+			input:       "[" + book + `, {"google`,
+			expected:    1,
+			errContains: "element 1: unexpected EOF",
+		},
+		{
+			name:        "missing closing bracket",
+			input:       "[" + book,
+			expected:    1,
+			errContains: "element 1: unexpected end of JSON input",
+		},
+		{
+			name:        "invalid element",
+			input:       `[{"name": "shelves/1/books/1"}]`,
+			errContains: "element 0: parse:",
+		},
+		{
+			name:        "data after array",
+			input:       `[] []`,
+			errContains: "read array: unexpected data after array",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			decoder := UnmarshalOptions{}.NewArrayDecoder(strings.NewReader(tt.input), newBook)
+			for i := 0; i < tt.expected; i++ {
+				_, err := decoder.Next()
+				assert.NilError(t, err)
+			}
+			_, err := decoder.Next()
+			if tt.errContains == "" {
+				assert.Equal(t, io.EOF, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// boolIntJSON returns the int encoding of a bool value, used with BoolAsInt.
+func (o MarshalOptions) boolIntJSON(value protoreflect.Value) interface{} {
+	if value.Bool() {
+		return o.unionValue("int", int32(1))
+	}
+	return o.unionValue("int", int32(0))
+}
+
+// decodeBoolInt decodes the int encoding of a bool value of field, which must be 0 or 1.
+func decodeBoolInt(i int64, field protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+	switch i {
+	case 0:
+		return protoreflect.ValueOfBool(false), nil
+	case 1:
+		return protoreflect.ValueOfBool(true), nil
+	}
+	return protoreflect.Value{}, fmt.Errorf("field %s: expected 0 or 1 for bool, got %d", field.Name(), i)
+}
@@ -0,0 +1,65 @@
+package protoavro
+
+import (
+	"testing"
+
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_BoolAsInt(t *testing.T) {
+	opts := SchemaOptions{BoolAsInt: true}
+	schema, err := opts.InferSchema((&examplev1.ExampleOneof{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Nullable(avro.Integer()), schema.(avro.Union)[1].(avro.Record).Fields[1].Type)
+	for _, tt := range []struct {
+		value    bool
+		expected int32
+	}{
+		{value: true, expected: 1},
+		{value: false, expected: 0},
+	} {
+		msg := &examplev1.ExampleOneof{
+			OneofFields_1: &examplev1.ExampleOneof_OneofBool_1{OneofBool_1: tt.value},
+		}
+		native := assertRoundTrip(t, opts, schema, msg)
+		record := native.(map[string]interface{})["einride.avro.example.v1.ExampleOneof"].(map[string]interface{})
+		assert.DeepEqual(t, map[string]interface{}{"int": tt.expected}, record["oneof_bool_1"])
+	}
+}
+
+func TestUnmarshalOptions_BoolAsInt(t *testing.T) {
+	opts := UnmarshalOptions{SchemaOptions: SchemaOptions{BoolAsInt: true}}
+	for _, tt := range []struct {
+		name        string
+		data        interface{}
+		expected    bool
+		errContains string
+	}{
+		{name: "int 1", data: map[string]interface{}{"int": int32(1)}, expected: true},
+		{name: "int 0", data: map[string]interface{}{"int": int32(0)}, expected: false},
+		{name: "bare int", data: int64(1), expected: true},
+		{name: "boolean", data: map[string]interface{}{"boolean": true}, expected: true},
+		{
+			name:        "out of range",
+			data:        map[string]interface{}{"int": int32(2)},
+			errContains: "field oneof_bool_1: expected 0 or 1 for bool, got 2",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleOneof
+			err := opts.Unmarshal(map[string]interface{}{"oneof_bool_1": tt.data}, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, &examplev1.ExampleOneof{
+				OneofFields_1: &examplev1.ExampleOneof_OneofBool_1{OneofBool_1: tt.expected},
+			}, &got, protocmp.Transform())
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package protoavro
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// BytesEncoding determines how protobuf bytes fields are represented in Avro.
+type BytesEncoding int
+
+const (
+	// BytesRaw represents bytes fields as Avro bytes.
+	// Decoding also accepts strings in the Avro JSON encoding of bytes, where each code point is a byte.
+	BytesRaw BytesEncoding = iota
+	// BytesIntArray represents bytes fields as Avro arrays of ints in the range 0-255,
+	// as emitted by some Avro JSON serializers.
+	BytesIntArray
+	// BytesDataURL represents bytes fields as Avro strings holding RFC 2397 data URLs,
+	// such as "data:image/png;base64,iVBORw0KGgo=", for inline attachments.
+	// Decoding also accepts Avro bytes.
+	BytesDataURL
+)
+
+// defaultDataURLMediaType is the media type of data URLs that omit it, as specified by RFC 2397.
+const defaultDataURLMediaType = "text/plain;charset=US-ASCII"
+
+func (s schemaInferrer) inferBytesSchema(field protoreflect.FieldDescriptor) (avro.Schema, error) {
+	if size, ok := s.opts.FixedFields[string(field.FullName())]; ok {
+		if _, ok := s.seen[field.FullName()]; ok {
+			return avro.Reference(field.FullName()), nil
+		}
+		s.seen[field.FullName()] = struct{}{}
+		return avro.Fixed{
+			Type:      avro.FixedType,
+			Name:      string(field.Name()),
+			Namespace: namespace(field),
+			Size:      size,
+		}, nil
+	}
+	if s.opts.BytesEncoding == BytesIntArray {
+		return avro.Array{
+			Type:  avro.ArrayType,
+			Items: avro.Integer(),
+		}, nil
+	}
+	if s.opts.BytesEncoding == BytesDataURL {
+		return avro.String(), nil
+	}
+	if logicalType, ok := s.opts.BytesLogicalTypes[string(field.FullName())]; ok {
+		switch logicalType {
+		case "":
+			return nil, fmt.Errorf("field %s: empty bytes logical type", field.Name())
+		case "decimal":
+			return nil, fmt.Errorf("field %s: bytes logical type decimal requires precision and scale", field.Name())
+		}
+		return avro.Primitive{Type: avro.BytesType, LogicalType: avro.LogicalType(logicalType)}, nil
+	}
+	return avro.Bytes(), nil
+}
+
+// validateBytesLogicalType validates bs with the validator of the logical type of field, if any.
+func (d *decoder) validateBytesLogicalType(f protoreflect.FieldDescriptor, bs []byte) error {
+	logicalType, ok := d.opts.BytesLogicalTypes[string(f.FullName())]
+	if !ok {
+		return nil
+	}
+	validate, ok := d.opts.BytesLogicalTypeValidators[logicalType]
+	if !ok {
+		return nil
+	}
+	if err := validate(bs); err != nil {
+		return fmt.Errorf("field %s: logical type %s: %w", f.Name(), logicalType, err)
+	}
+	return nil
+}
+
+func (o MarshalOptions) encodeBytes(field protoreflect.FieldDescriptor, bs []byte) (interface{}, error) {
+	if size, ok := o.FixedFields[string(field.FullName())]; ok {
+		if len(bs) != size {
+			return nil, fmt.Errorf("field %s: expected %d bytes for fixed, got %d", field.Name(), size, len(bs))
+		}
+		return o.unionValue(string(field.FullName()), bs), nil
+	}
+	if o.BytesEncoding == BytesIntArray {
+		ints := make([]interface{}, 0, len(bs))
+		for _, b := range bs {
+			ints = append(ints, int32(b))
+		}
+		return o.unionValue("array", ints), nil
+	}
+	if o.BytesEncoding == BytesDataURL {
+		return o.unionValue("string", "data:application/octet-stream;base64,"+base64.StdEncoding.EncodeToString(bs)), nil
+	}
+	return o.unionValue("bytes", bs), nil
+}
+
+func (d *decoder) decodeBytesLike(f protoreflect.FieldDescriptor, v interface{}) ([]byte, error) {
+	if size, ok := d.opts.FixedFields[string(f.FullName())]; ok {
+		bs, err := decodeBytesLike(v, string(f.FullName()))
+		if err != nil {
+			return nil, err
+		}
+		if len(bs) != size {
+			return nil, fmt.Errorf("expected %d bytes for fixed, got %d", size, len(bs))
+		}
+		return bs, nil
+	}
+	if d.opts.BytesEncoding == BytesDataURL {
+		if str, err := decodeStringLike(v, "string"); err == nil {
+			_, bs, err := parseDataURL(str)
+			return bs, err
+		}
+		return decodeBytesLike(v, "bytes")
+	}
+	if d.opts.BytesEncoding != BytesIntArray {
+		return decodeBytesLike(v, "bytes")
+	}
+	if m, ok := v.(map[string]interface{}); ok {
+		if _, ok := m["bytes"]; ok {
+			return decodeBytes(m, "bytes")
+		}
+		list, err := decodeList(m, "array")
+		if err != nil {
+			return nil, err
+		}
+		return decodeIntArrayBytes(list)
+	}
+	if list, ok := v.([]interface{}); ok {
+		return decodeIntArrayBytes(list)
+	}
+	return decodeBytesLike(v, "bytes")
+}
+
+func decodeIntArrayBytes(list []interface{}) ([]byte, error) {
+	bs := make([]byte, 0, len(list))
+	for i, el := range list {
+		b, err := decodeIntValue(el)
+		if err != nil {
+			return nil, fmt.Errorf("byte %d: %w", i, err)
+		}
+		if b < 0 || b > 255 {
+			return nil, fmt.Errorf("byte %d: value %d out of range 0-255", i, b)
+		}
+		bs = append(bs, byte(b))
+	}
+	return bs, nil
+}
+
+// decodeJSONBytes decodes bytes in the Avro JSON encoding, where each code point 0-255 of s is a byte.
+func decodeJSONBytes(s string) ([]byte, error) {
+	bs := make([]byte, 0, len(s))
+	for i, r := range s {
+		if r > 255 {
+			return nil, fmt.Errorf("byte %d: code point %U out of range 0-255", i, r)
+		}
+		bs = append(bs, byte(r))
+	}
+	return bs, nil
+}
+
+// nonNilBytes returns bs, or an empty slice if bs is nil, so that decoded empty values
+// set fields with explicit presence.
+func nonNilBytes(bs []byte) []byte {
+	if bs == nil {
+		return []byte{}
+	}
+	return bs
+}
+
+// parseDataURL returns the media type and the decoded payload of the RFC 2397 data URL s.
+func parseDataURL(s string) (string, []byte, error) {
+	if !strings.HasPrefix(s, "data:") {
+		return "", nil, fmt.Errorf("invalid data URL: expected prefix 'data:'")
+	}
+	comma := strings.IndexByte(s, ',')
+	if comma < 0 {
+		return "", nil, fmt.Errorf("invalid data URL: expected ',' before data")
+	}
+	mediaType, data := s[len("data:"):comma], s[comma+1:]
+	isBase64 := strings.HasSuffix(mediaType, ";base64")
+	mediaType = strings.TrimSuffix(mediaType, ";base64")
+	switch {
+	case mediaType == "":
+		mediaType = defaultDataURLMediaType
+	case strings.HasPrefix(mediaType, ";"):
+		mediaType = "text/plain" + mediaType
+	}
+	if isBase64 {
+		bs, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return "", nil, fmt.Errorf("invalid data URL: %w", err)
+		}
+		return mediaType, bs, nil
+	}
+	unescaped, err := url.PathUnescape(data)
+	if err != nil {
+		return "", nil, fmt.Errorf("invalid data URL: %w", err)
+	}
+	return mediaType, []byte(unescaped), nil
+}
+
+// setDataURLMediaType sets the field configured by UnmarshalOptions.DataURLMediaTypeFields for
+// the bytes field f of msg to the media type of the data URL in data.
+func (d *decoder) setDataURLMediaType(
+	data interface{},
+	msg protoreflect.Message,
+	f protoreflect.FieldDescriptor,
+) error {
+	name, ok := d.opts.DataURLMediaTypeFields[string(f.FullName())]
+	if !ok || d.opts.BytesEncoding != BytesDataURL {
+		return nil
+	}
+	mediaTypeField := msg.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if mediaTypeField == nil || mediaTypeField.Kind() != protoreflect.StringKind || mediaTypeField.IsList() {
+		return fmt.Errorf("field %s: media type field '%s' is not a singular string field", f.Name(), name)
+	}
+	str, err := decodeStringLike(data, "string")
+	if err != nil {
+		// raw bytes have no media type.
+		return nil
+	}
+	mediaType, _, err := parseDataURL(str)
+	if err != nil {
+		return fmt.Errorf("field %s: %w", f.Name(), err)
+	}
+	msg.Set(mediaTypeField, protoreflect.ValueOfString(mediaType))
+	return nil
+}
@@ -0,0 +1,410 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"gotest.tools/v3/assert"
+)
+
+func Test_BytesIntArray(t *testing.T) {
+	opts := SchemaOptions{BytesEncoding: BytesIntArray}
+	msg := &examplev1.ExampleBytes{Bytes: []byte{0, 1, 255}}
+
+	schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Nullable(avro.Record{
+		Type:      avro.RecordType,
+		Name:      "ExampleBytes",
+		Namespace: "einride.avro.example.v1",
+		Fields: []avro.Field{
+			{
+				Name: "bytes",
+				Type: avro.Nullable(avro.Array{Type: avro.ArrayType, Items: avro.Integer()}),
+			},
+		},
+	}), schema)
+
+	got, err := opts.Encode(msg)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]interface{}{
+		"einride.avro.example.v1.ExampleBytes": map[string]interface{}{
+			"bytes": map[string]interface{}{
+				"array": []interface{}{int32(0), int32(1), int32(255)},
+			},
+		},
+	}, got)
+
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	codec, err := goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+	binary, err := codec.BinaryFromNative(nil, got)
+	assert.NilError(t, err)
+	native, _, err := codec.NativeFromBinary(binary)
+	assert.NilError(t, err)
+
+	var decoded examplev1.ExampleBytes
+	assert.NilError(t, UnmarshalOptions{SchemaOptions: opts}.Unmarshal(native, &decoded))
+	assert.DeepEqual(t, msg, &decoded, protocmp.Transform())
+}
+
+func Test_DecodeBytesIntArray(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		data        interface{}
+		expected    []byte
+		errContains string
+	}{
+		{
+			name:     "int array",
+			data:     []interface{}{0, 127, 255},
+			expected: []byte{0, 127, 255},
+		},
+		{
+			name:     "union wrapped int array",
+			data:     map[string]interface{}{"array": []interface{}{int32(1), int64(2)}},
+			expected: []byte{1, 2},
+		},
+		{
+			name:     "raw bytes",
+			data:     map[string]interface{}{"bytes": []byte{3}},
+			expected: []byte{3},
+		},
+		{
+			name:        "out of range",
+			data:        []interface{}{1, 256},
+			errContains: "byte 1: value 256 out of range 0-255",
+		},
+		{
+			name:        "negative",
+			data:        []interface{}{-1},
+			errContains: "byte 0: value -1 out of range 0-255",
+		},
+		{
+			name:        "not an int",
+			data:        []interface{}{"1"},
+			errContains: "byte 0: expected int-like, got string",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := UnmarshalOptions{SchemaOptions: SchemaOptions{BytesEncoding: BytesIntArray}}
+			var msg examplev1.ExampleBytes
+			err := opts.Unmarshal(map[string]interface{}{"bytes": tt.data}, &msg)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, msg.GetBytes())
+		})
+	}
+}
+
+func Test_BytesDataURL(t *testing.T) {
+	opts := SchemaOptions{BytesEncoding: BytesDataURL}
+	msg := &examplev1.ExampleBytes{Bytes: []byte("hello")}
+	schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Nullable(avro.Record{
+		Type:      avro.RecordType,
+		Name:      "ExampleBytes",
+		Namespace: "einride.avro.example.v1",
+		Fields:    []avro.Field{{Name: "bytes", Type: avro.Nullable(avro.String())}},
+	}), schema)
+	got, err := opts.Encode(msg)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, map[string]interface{}{
+		"einride.avro.example.v1.ExampleBytes": map[string]interface{}{
+			"bytes": map[string]interface{}{"string": "data:application/octet-stream;base64,aGVsbG8="},
+		},
+	}, got)
+	assertRoundTrip(t, opts, schema, msg)
+}
+
+func Test_DecodeBytesDataURL(t *testing.T) {
+	for _, tt := range []struct {
+		name              string
+		data              interface{}
+		expected          []byte
+		expectedMediaType string
+		errContains       string
+	}{
+		{
+			name:              "base64",
+			data:              map[string]interface{}{"string": "data:image/png;base64,iVBORw0KGgo="},
+			expected:          []byte{0x89, 'P', 'N', 'G', '\r', '\n', 0x1a, '\n'},
+			expectedMediaType: "image/png",
+		},
+		{
+			name:              "percent encoded without media type",
+			data:              "data:,hello%20world",
+			expected:          []byte("hello world"),
+			expectedMediaType: "text/plain;charset=US-ASCII",
+		},
+		{
+			name:              "parameters without media type",
+			data:              "data:;charset=utf-8,hej",
+			expected:          []byte("hej"),
+			expectedMediaType: "text/plain;charset=utf-8",
+		},
+		{
+			name:     "raw bytes",
+			data:     map[string]interface{}{"bytes": []byte{1, 2}},
+			expected: []byte{1, 2},
+		},
+		{
+			name:        "missing scheme",
+			data:        "image/png;base64,iVBORw0KGgo=",
+			errContains: "invalid data URL: expected prefix 'data:'",
+		},
+		{
+			name:        "missing comma",
+			data:        "data:image/png;base64",
+			errContains: "invalid data URL: expected ',' before data",
+		},
+		{
+			name:        "malformed base64",
+			data:        "data:image/png;base64,not base64",
+			errContains: "invalid data URL: illegal base64 data",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := UnmarshalOptions{
+				SchemaOptions: SchemaOptions{BytesEncoding: BytesDataURL},
+				DataURLMediaTypeFields: map[string]string{
+					"google.protobuf.UninterpretedOption.string_value": "identifier_value",
+				},
+			}
+			var msg descriptorpb.UninterpretedOption
+			err := opts.Unmarshal(map[string]interface{}{"string_value": tt.data}, &msg)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, msg.GetStringValue())
+			assert.Equal(t, tt.expectedMediaType, msg.GetIdentifierValue())
+		})
+	}
+	t.Run("invalid media type field", func(t *testing.T) {
+		opts := UnmarshalOptions{
+			SchemaOptions: SchemaOptions{BytesEncoding: BytesDataURL},
+			DataURLMediaTypeFields: map[string]string{
+				"google.protobuf.UninterpretedOption.string_value": "positive_int_value",
+			},
+		}
+		var msg descriptorpb.UninterpretedOption
+		err := opts.Unmarshal(map[string]interface{}{"string_value": "data:,a"}, &msg)
+		assert.ErrorContains(t, err, "media type field 'positive_int_value' is not a singular string field")
+	})
+}
+
+func Test_DecodeEmptyBytes(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		opts        UnmarshalOptions
+		data        interface{}
+		expectedSet bool
+		expected    []byte
+		errContains string
+	}{
+		{
+			name: "null",
+			data: nil,
+		},
+		{
+			name:        "empty string",
+			data:        "",
+			expectedSet: true,
+			expected:    []byte{},
+		},
+		{
+			name:        "union wrapped empty string",
+			data:        map[string]interface{}{"bytes": ""},
+			expectedSet: true,
+			expected:    []byte{},
+		},
+		{
+			name:        "nil bytes",
+			data:        map[string]interface{}{"bytes": []byte(nil)},
+			expectedSet: true,
+			expected:    []byte{},
+		},
+		{
+			name:        "empty base64 data URL",
+			opts:        UnmarshalOptions{SchemaOptions: SchemaOptions{BytesEncoding: BytesDataURL}},
+			data:        "data:;base64,",
+			expectedSet: true,
+			expected:    []byte{},
+		},
+		{
+			name:        "empty int array",
+			opts:        UnmarshalOptions{SchemaOptions: SchemaOptions{BytesEncoding: BytesIntArray}},
+			data:        []interface{}{},
+			expectedSet: true,
+			expected:    []byte{},
+		},
+		{
+			name:        "Avro JSON string",
+			data:        "aÿ",
+			expectedSet: true,
+			expected:    []byte{'a', 0xff},
+		},
+		{
+			name:        "code point out of range",
+			data:        "aĀ",
+			errContains: "byte 1: code point U+0100 out of range 0-255",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			// string_value is a proto2 bytes field with explicit presence.
+			var msg descriptorpb.UninterpretedOption
+			err := tt.opts.Unmarshal(map[string]interface{}{"string_value": tt.data}, &msg)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expectedSet, msg.StringValue != nil)
+			assert.DeepEqual(t, tt.expected, msg.GetStringValue())
+		})
+	}
+}
+
+func Test_BytesFixed(t *testing.T) {
+	opts := SchemaOptions{FixedFields: map[string]int{"einride.avro.example.v1.ExampleBytes.bytes": 4}}
+	msg := &examplev1.ExampleBytes{Bytes: []byte{1, 2, 3, 4}}
+	schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Nullable(avro.Record{
+		Type:      avro.RecordType,
+		Name:      "ExampleBytes",
+		Namespace: "einride.avro.example.v1",
+		Fields: []avro.Field{
+			{
+				Name: "bytes",
+				Type: avro.Nullable(avro.Fixed{
+					Type:      avro.FixedType,
+					Name:      "bytes",
+					Namespace: "einride.avro.example.v1.ExampleBytes",
+					Size:      4,
+				}),
+			},
+		},
+	}), schema)
+	native := assertRoundTrip(t, opts, schema, msg)
+	assert.DeepEqual(t, map[string]interface{}{
+		"einride.avro.example.v1.ExampleBytes": map[string]interface{}{
+			"bytes": map[string]interface{}{"einride.avro.example.v1.ExampleBytes.bytes": []byte{1, 2, 3, 4}},
+		},
+	}, native)
+
+	t.Run("encode mismatched length", func(t *testing.T) {
+		_, err := opts.Encode(&examplev1.ExampleBytes{Bytes: []byte{1, 2, 3}})
+		assert.ErrorContains(t, err, "field bytes: expected 4 bytes for fixed, got 3")
+	})
+
+	for _, tt := range []struct {
+		name        string
+		data        interface{}
+		expected    []byte
+		errContains string
+	}{
+		{
+			name:     "union wrapped",
+			data:     map[string]interface{}{"einride.avro.example.v1.ExampleBytes.bytes": []byte{5, 6, 7, 8}},
+			expected: []byte{5, 6, 7, 8},
+		},
+		{
+			name:     "bare",
+			data:     []byte{5, 6, 7, 8},
+			expected: []byte{5, 6, 7, 8},
+		},
+		{
+			name:        "too short",
+			data:        []byte{5, 6, 7},
+			errContains: "field bytes: expected 4 bytes for fixed, got 3",
+		},
+		{
+			name:        "too long",
+			data:        map[string]interface{}{"einride.avro.example.v1.ExampleBytes.bytes": []byte{5, 6, 7, 8, 9}},
+			errContains: "field bytes: expected 4 bytes for fixed, got 5",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleBytes
+			err := UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{"bytes": tt.data}, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got.GetBytes())
+		})
+	}
+}
+
+func Test_BytesLogicalTypes(t *testing.T) {
+	opts := SchemaOptions{BytesLogicalTypes: map[string]string{"einride.avro.example.v1.ExampleBytes.bytes": "json"}}
+	msg := &examplev1.ExampleBytes{Bytes: []byte(`{"a":1}`)}
+	schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Field{
+		Name: "bytes",
+		Type: avro.Nullable(avro.Primitive{Type: avro.BytesType, LogicalType: "json"}),
+	}, schema.(avro.Union)[1].(avro.Record).Fields[0])
+	schemaJSON, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(schemaJSON), `{"type":"bytes","logicalType":"json"}`))
+	native := assertRoundTrip(t, opts, schema, msg)
+	assert.DeepEqual(t, map[string]interface{}{
+		"einride.avro.example.v1.ExampleBytes": map[string]interface{}{
+			"bytes": map[string]interface{}{"bytes": []byte(`{"a":1}`)},
+		},
+	}, native)
+
+	t.Run("encoded size", func(t *testing.T) {
+		size, err := MarshalOptions{SchemaOptions: opts}.EncodedSize(msg)
+		assert.NilError(t, err)
+		assert.Equal(t, 10, size)
+	})
+
+	t.Run("validators", func(t *testing.T) {
+		unmarshalOpts := UnmarshalOptions{
+			SchemaOptions: opts,
+			BytesLogicalTypeValidators: map[string]func([]byte) error{
+				"json": func(bs []byte) error {
+					if !json.Valid(bs) {
+						return fmt.Errorf("invalid json")
+					}
+					return nil
+				},
+			},
+		}
+		var got examplev1.ExampleBytes
+		assert.NilError(t, unmarshalOpts.Unmarshal(native, &got))
+		assert.DeepEqual(t, msg, &got, protocmp.Transform())
+		err := unmarshalOpts.Unmarshal(map[string]interface{}{
+			"bytes": map[string]interface{}{"bytes": []byte("{")},
+		}, &got)
+		assert.ErrorContains(t, err, "field bytes: logical type json: invalid json")
+	})
+
+	t.Run("decimal", func(t *testing.T) {
+		opts := SchemaOptions{BytesLogicalTypes: map[string]string{"einride.avro.example.v1.ExampleBytes.bytes": "decimal"}}
+		_, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.ErrorContains(t, err, "field bytes: bytes logical type decimal requires precision and scale")
+	})
+}
@@ -0,0 +1,90 @@
+package protoavro
+
+import (
+	"fmt"
+	"hash/crc32"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// checksumField is the name of the field that holds the checksum of the record with AppendChecksum.
+const checksumField = "__crc32"
+
+// withChecksumField returns the fields of the record of message with the checksum field appended,
+// when AppendChecksum is set.
+func (o SchemaOptions) withChecksumField(message protoreflect.MessageDescriptor, fields []avro.Field) (
+	[]avro.Field,
+	error,
+) {
+	if !o.AppendChecksum {
+		return fields, nil
+	}
+	for _, field := range fields {
+		if field.Name == checksumField {
+			return nil, fmt.Errorf("%s: field %s conflicts with the checksum field", message.FullName(), checksumField)
+		}
+	}
+	return append(fields, avro.Field{
+		Name: checksumField,
+		Type: avro.Integer(),
+	}), nil
+}
+
+// messageChecksum returns the CRC-32 (IEEE) checksum of the deterministic protobuf binary encoding of message,
+// as a signed int, since Avro has no unsigned integer types.
+func messageChecksum(message protoreflect.Message) (int32, error) {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(message.Interface())
+	if err != nil {
+		return 0, fmt.Errorf("%s: checksum: %w", message.Descriptor().FullName(), err)
+	}
+	return int32(crc32.ChecksumIEEE(data)), nil
+}
+
+// removeChecksum returns record without the checksum field, and the checksum it holds, if any.
+func (o *UnmarshalOptions) removeChecksum(record map[string]interface{}) (map[string]interface{}, *int32, error) {
+	if !o.AppendChecksum && !o.VerifyChecksum {
+		return record, nil, nil
+	}
+	data, ok := record[checksumField]
+	if !ok {
+		return record, nil, nil
+	}
+	result := make(map[string]interface{}, len(record)-1)
+	for key, value := range record {
+		if key != checksumField {
+			result[key] = value
+		}
+	}
+	if data == nil {
+		return result, nil, nil
+	}
+	checksum, err := decodeIntLike(data, string(avro.IntType))
+	if err != nil {
+		return nil, nil, fmt.Errorf("field %s: %w", checksumField, err)
+	}
+	checksum32 := int32(checksum)
+	return result, &checksum32, nil
+}
+
+// verifyChecksum returns an error if checksum does not match the checksum of the decoded msg,
+// with VerifyChecksum.
+func (d *decoder) verifyChecksum(msg protoreflect.Message, checksum *int32) error {
+	if !d.opts.VerifyChecksum {
+		return nil
+	}
+	if checksum == nil {
+		return fmt.Errorf("%s: missing checksum", msg.Descriptor().FullName())
+	}
+	actual, err := messageChecksum(msg)
+	if err != nil {
+		return err
+	}
+	if actual != *checksum {
+		return fmt.Errorf(
+			"%s: checksum %d does not match decoded checksum %d", msg.Descriptor().FullName(), *checksum, actual,
+		)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package protoavro
+
+import (
+	"hash/crc32"
+	"testing"
+
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_AppendChecksum(t *testing.T) {
+	opts := SchemaOptions{AppendChecksum: true}
+	schema, err := opts.InferSchema((&examplev1.ExampleNested{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	root := schema.(avro.Union)[1].(avro.Record)
+	assert.DeepEqual(t, avro.Field{Name: "__crc32", Type: avro.Integer()}, root.Fields[len(root.Fields)-1])
+
+	msg := &examplev1.ExampleNested{
+		Name: "a",
+		Level1: &examplev1.ExampleNested_Level1{
+			Level2: &examplev1.ExampleNested_Level2{
+				Level3Map: map[string]*examplev1.ExampleNested_Level3{"b": {Value: "c"}},
+			},
+		},
+	}
+	native := assertRoundTrip(t, opts, schema, msg)
+	record := native.(map[string]interface{})["einride.avro.example.v1.ExampleNested"].(map[string]interface{})
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	assert.NilError(t, err)
+	assert.Equal(t, int32(crc32.ChecksumIEEE(data)), record["__crc32"])
+
+	unmarshalOpts := UnmarshalOptions{SchemaOptions: opts, VerifyChecksum: true}
+	t.Run("valid", func(t *testing.T) {
+		var got examplev1.ExampleNested
+		assert.NilError(t, unmarshalOpts.Unmarshal(native, &got))
+		assert.DeepEqual(t, msg, &got, protocmp.Transform())
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		tampered := make(map[string]interface{}, len(record))
+		for key, value := range record {
+			tampered[key] = value
+		}
+		tampered["name"] = map[string]interface{}{"string": "b"}
+		var got examplev1.ExampleNested
+		err := unmarshalOpts.Unmarshal(tampered, &got)
+		assert.ErrorContains(t, err, "einride.avro.example.v1.ExampleNested: checksum")
+		assert.ErrorContains(t, err, "does not match decoded checksum")
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		var got examplev1.ExampleNested
+		err := unmarshalOpts.Unmarshal(map[string]interface{}{"name": "a"}, &got)
+		assert.ErrorContains(t, err, "einride.avro.example.v1.ExampleNested: missing checksum")
+	})
+}
@@ -0,0 +1,91 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Coercion is a conversion of values of one Avro primitive type into another, such as from "string" to "long",
+// registered in UnmarshalOptions.CoercionTable.
+type Coercion struct {
+	From, To string
+}
+
+// coerce converts data with the function registered in CoercionTable for the Avro type of data
+// and the Avro type of field f. Data is returned unchanged when no function is registered.
+func (d *decoder) coerce(data interface{}, f protoreflect.FieldDescriptor) (interface{}, error) {
+	if len(d.opts.CoercionTable) == 0 || f.IsMap() {
+		return data, nil
+	}
+	to, ok := fieldKindAvroType(f)
+	if !ok {
+		return data, nil
+	}
+	from, value, ok := avroTypeOf(data)
+	if !ok || from == to {
+		return data, nil
+	}
+	fn, ok := d.opts.CoercionTable[Coercion{From: from, To: to}]
+	if !ok {
+		return data, nil
+	}
+	coerced, err := fn(value)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: coerce %s to %s: %w", f.Name(), from, to, err)
+	}
+	return coerced, nil
+}
+
+// fieldKindAvroType returns the Avro primitive type that values of scalar field f are decoded from.
+func fieldKindAvroType(f protoreflect.FieldDescriptor) (string, bool) {
+	switch f.Kind() {
+	case protoreflect.BoolKind:
+		return string(avro.BooleanType), true
+	case protoreflect.Int32Kind, protoreflect.Sfixed32Kind, protoreflect.Sint32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return string(avro.IntType), true
+	case protoreflect.Int64Kind, protoreflect.Sfixed64Kind, protoreflect.Sint64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return string(avro.LongType), true
+	case protoreflect.FloatKind:
+		return string(avro.FloatType), true
+	case protoreflect.DoubleKind:
+		return string(avro.DoubleType), true
+	case protoreflect.StringKind:
+		return string(avro.StringType), true
+	case protoreflect.BytesKind:
+		return string(avro.BytesType), true
+	}
+	return "", false
+}
+
+// avroTypeOf returns the Avro primitive type of data, and the value of data without a union wrapper.
+func avroTypeOf(data interface{}) (string, interface{}, bool) {
+	if m, ok := data.(map[string]interface{}); ok && len(m) == 1 {
+		for key, value := range m {
+			if t, _, ok := avroTypeOf(value); ok && t == key {
+				return t, value, true
+			}
+		}
+		return "", nil, false
+	}
+	switch data.(type) {
+	case bool:
+		return string(avro.BooleanType), data, true
+	case int32:
+		return string(avro.IntType), data, true
+	case int64:
+		return string(avro.LongType), data, true
+	case float32:
+		return string(avro.FloatType), data, true
+	case float64:
+		return string(avro.DoubleType), data, true
+	case string:
+		return string(avro.StringType), data, true
+	case []byte:
+		return string(avro.BytesType), data, true
+	}
+	return "", nil, false
+}
@@ -0,0 +1,85 @@
+package protoavro
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnmarshalOptions_CoercionTable(t *testing.T) {
+	opts := UnmarshalOptions{
+		CoercionTable: map[Coercion]func(interface{}) (interface{}, error){
+			{From: "string", To: "long"}: func(v interface{}) (interface{}, error) {
+				return strconv.ParseInt(v.(string), 10, 64)
+			},
+			{From: "double", To: "long"}: func(v interface{}) (interface{}, error) {
+				f := v.(float64)
+				if f != math.Trunc(f) {
+					return nil, fmt.Errorf("%v is not an integer", f)
+				}
+				return int64(f), nil
+			},
+		},
+	}
+	for _, tt := range []struct {
+		name        string
+		opts        UnmarshalOptions
+		data        map[string]interface{}
+		expected    *descriptorpb.UninterpretedOption
+		errContains string
+	}{
+		{
+			name:     "string to long",
+			opts:     opts,
+			data:     map[string]interface{}{"negative_int_value": "-5"},
+			expected: &descriptorpb.UninterpretedOption{NegativeIntValue: proto.Int64(-5)},
+		},
+		{
+			name:     "string to long in union",
+			opts:     opts,
+			data:     map[string]interface{}{"negative_int_value": map[string]interface{}{"string": "-5"}},
+			expected: &descriptorpb.UninterpretedOption{NegativeIntValue: proto.Int64(-5)},
+		},
+		{
+			name:     "double to long",
+			opts:     opts,
+			data:     map[string]interface{}{"positive_int_value": float64(3)},
+			expected: &descriptorpb.UninterpretedOption{PositiveIntValue: proto.Uint64(3)},
+		},
+		{
+			name:        "coercion error",
+			opts:        opts,
+			data:        map[string]interface{}{"positive_int_value": 3.5},
+			errContains: "field positive_int_value: coerce double to long: 3.5 is not an integer",
+		},
+		{
+			name:     "matching type",
+			opts:     opts,
+			data:     map[string]interface{}{"negative_int_value": int64(-5), "double_value": 1.5},
+			expected: &descriptorpb.UninterpretedOption{NegativeIntValue: proto.Int64(-5), DoubleValue: proto.Float64(1.5)},
+		},
+		{
+			name:        "unregistered",
+			data:        map[string]interface{}{"negative_int_value": "-5"},
+			errContains: "negative_int_value",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got descriptorpb.UninterpretedOption
+			err := tt.opts.Unmarshal(tt.data, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, &got, protocmp.Transform())
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package protoavro
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Decimal is the precision and scale of values of the Avro decimal logical type.
+type Decimal struct {
+	// Precision is the maximum number of digits of values. Zero means no limit.
+	Precision int
+	// Scale is the number of digits of values after the decimal point.
+	Scale int
+}
+
+// decimalBranches are the union branches that decimal values are accepted in.
+var decimalBranches = []string{"bytes.decimal", "fixed.decimal", "bytes"}
+
+// decodeDecimal decodes an Avro decimal value of string field f as a decimal string, such as "-12.34".
+// Values are either *big.Rat, as decoded by goavro for the decimal logical type, or the unscaled value
+// as big-endian two's-complement bytes.
+func decodeDecimal(data interface{}, f protoreflect.FieldDescriptor, decimal Decimal) (protoreflect.Value, error) {
+	if m, ok := data.(map[string]interface{}); ok && len(m) == 1 {
+		for _, branch := range decimalBranches {
+			if value, ok := m[branch]; ok {
+				data = value
+			}
+		}
+	}
+	var unscaled *big.Int
+	switch value := data.(type) {
+	case *big.Rat:
+		factor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimal.Scale)), nil)
+		scaled := new(big.Rat).Mul(value, new(big.Rat).SetInt(factor))
+		if !scaled.IsInt() {
+			return protoreflect.Value{}, fmt.Errorf("field %s: decimal %s has more than %d digits after the decimal point",
+				f.Name(), value.RatString(), decimal.Scale)
+		}
+		unscaled = scaled.Num()
+	case []byte:
+		unscaled = new(big.Int).SetBytes(value)
+		if len(value) > 0 && value[0]&0x80 != 0 {
+			// negative two's-complement values.
+			unscaled.Sub(unscaled, new(big.Int).Lsh(big.NewInt(1), uint(len(value))*8))
+		}
+	default:
+		return protoreflect.Value{}, fmt.Errorf("field %s: expected decimal, got %T", f.Name(), data)
+	}
+	digits := new(big.Int).Abs(unscaled).String()
+	if decimal.Precision > 0 && len(digits) > decimal.Precision && unscaled.Sign() != 0 {
+		return protoreflect.Value{}, fmt.Errorf("field %s: decimal of %d digits exceeds precision %d",
+			f.Name(), len(digits), decimal.Precision)
+	}
+	return protoreflect.ValueOfString(formatDecimal(unscaled.Sign() < 0, digits, decimal.Scale)), nil
+}
+
+// formatDecimal returns the decimal string of the unscaled digits with scale digits after the decimal point.
+func formatDecimal(negative bool, digits string, scale int) string {
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	var b strings.Builder
+	if negative {
+		b.WriteByte('-')
+	}
+	b.WriteString(digits[:len(digits)-scale])
+	if scale > 0 {
+		b.WriteByte('.')
+		b.WriteString(digits[len(digits)-scale:])
+	}
+	return b.String()
+}
@@ -0,0 +1,74 @@
+package protoavro
+
+import (
+	"math/big"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnmarshalOptions_DecimalFields(t *testing.T) {
+	opts := UnmarshalOptions{
+		DecimalFields: map[string]Decimal{"google.example.library.v1.Book.title": {Precision: 6, Scale: 2}},
+	}
+	for _, tt := range []struct {
+		name        string
+		data        interface{}
+		expected    string
+		errContains string
+	}{
+		{
+			name:     "positive",
+			data:     map[string]interface{}{"bytes.decimal": big.NewRat(12345, 100)},
+			expected: "123.45",
+		},
+		{
+			name:     "negative",
+			data:     map[string]interface{}{"bytes.decimal": big.NewRat(-5, 100)},
+			expected: "-0.05",
+		},
+		{
+			name:     "zero",
+			data:     map[string]interface{}{"bytes.decimal": new(big.Rat)},
+			expected: "0.00",
+		},
+		{
+			name:     "positive bytes",
+			data:     map[string]interface{}{"bytes": []byte{0x30, 0x39}},
+			expected: "123.45",
+		},
+		{
+			name:     "negative bytes",
+			data:     map[string]interface{}{"bytes": []byte{0xCF, 0xC7}},
+			expected: "-123.45",
+		},
+		{
+			name:     "zero bytes",
+			data:     map[string]interface{}{"bytes": []byte{}},
+			expected: "0.00",
+		},
+		{
+			name:        "exceeds precision",
+			data:        map[string]interface{}{"bytes": []byte{0x0F, 0x42, 0x40}},
+			errContains: "field title: decimal of 7 digits exceeds precision 6",
+		},
+		{
+			name:        "exceeds scale",
+			data:        map[string]interface{}{"bytes.decimal": big.NewRat(1, 1000)},
+			errContains: "field title: decimal 1/1000 has more than 2 digits after the decimal point",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got library.Book
+			err := opts.Unmarshal(map[string]interface{}{"title": tt.data}, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, got.GetTitle())
+		})
+	}
+}
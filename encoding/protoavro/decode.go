@@ -1,71 +1,472 @@
 package protoavro
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	"go.einride.tech/protobuf-avro/avro"
+	"go.einride.tech/protobuf-avro/internal/wkt"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // decodeJSON decodes the JSON encoded avro data and places the
 // result in msg.
-func (o *SchemaOptions) decodeJSON(data interface{}, msg proto.Message) error {
-	return o.decodeMessage(data, msg.ProtoReflect())
+func (o *UnmarshalOptions) decodeJSON(data interface{}, msg proto.Message) error {
+	return o.newDecoder().decodeJSON(data, msg)
 }
 
-func (o *SchemaOptions) decodeMessage(data interface{}, msg protoreflect.Message) error {
+func (d *decoder) decodeJSON(data interface{}, msg proto.Message) error {
+	if len(d.opts.WriterSchema) > 0 {
+		if err := validateWriterSchema(d.opts.WriterSchema, data); err != nil {
+			return err
+		}
+	}
+	if d.opts.UnknownUnionBranchAsNull && d.opts.MaxNestDepth > 0 {
+		depths, err := d.opts.recordDepths(msg.ProtoReflect().Descriptor())
+		if err != nil {
+			return err
+		}
+		d.nestDepths = depths
+	}
+	if d.opts.UnwrapKey != "" {
+		data = d.opts.unwrap(data, msg.ProtoReflect().Descriptor())
+	}
+	payload := data
+	if d.opts.Envelope != nil {
+		var err error
+		if payload, err = d.opts.decodeEnvelope(data); err != nil {
+			return err
+		}
+	}
+	if err := checkTopLevel(payload, msg.ProtoReflect().Descriptor()); err != nil {
+		return err
+	}
+	if d.opts.CaptureFieldOrder != nil {
+		order, err := writerFieldOrder(d.opts.WriterSchema)
+		if err != nil {
+			return err
+		}
+		*d.opts.CaptureFieldOrder = order
+	}
+	if err := d.decodeMessage(payload, msg.ProtoReflect()); err != nil {
+		return err
+	}
+	if len(d.missing) > 0 {
+		sort.Strings(d.missing)
+		return fmt.Errorf("missing required fields: %s", strings.Join(d.missing, ", "))
+	}
+	return nil
+}
+
+// checkTopLevel returns a descriptive error if data is not a record, or null, as expected
+// for the top level of a message.
+func checkTopLevel(data interface{}, desc protoreflect.MessageDescriptor) error {
+	const expected = "expected Avro record encoded as map[string]interface{}"
+	switch data := data.(type) {
+	case nil, map[string]interface{}:
+		return nil
+	case []interface{}:
+		return fmt.Errorf(
+			"%s: %s, got array of %d elements: decode each element as a separate message",
+			desc.FullName(), expected, len(data),
+		)
+	default:
+		return fmt.Errorf(
+			"%s: %s such as {\"%s\": value}, got scalar %T",
+			desc.FullName(), expected, firstFieldName(desc), data,
+		)
+	}
+}
+
+func firstFieldName(desc protoreflect.MessageDescriptor) string {
+	if desc.Fields().Len() == 0 {
+		return "field"
+	}
+	return string(desc.Fields().Get(0).Name())
+}
+
+// stringData returns the string in data, optionally wrapped in a union.
+func stringData(data interface{}) (string, bool) {
+	if union, ok := data.(map[string]interface{}); ok && len(union) == 1 {
+		for _, value := range union {
+			data = value
+		}
+	}
+	str, ok := data.(string)
+	return str, ok
+}
+
+// checkStringLength returns an error if str is longer than MaxStringLength.
+// It applies to every string in the data, including strings that encode other types, such as enum symbols.
+func (d *decoder) checkStringLength(f protoreflect.FieldDescriptor, str string) error {
+	if d.opts.MaxStringLength > 0 && len(str) > d.opts.MaxStringLength {
+		return fmt.Errorf("field %s: string length %d exceeds max length %d", f.Name(), len(str), d.opts.MaxStringLength)
+	}
+	return nil
+}
+
+// decoder holds the state of decoding a single message.
+type decoder struct {
+	opts UnmarshalOptions
+	// path holds the field names and element indices leading to the value being decoded.
+	path []string
+	// required holds the full names of UnmarshalOptions.RequiredFields.
+	required map[protoreflect.FullName]struct{}
+	// missing holds the paths of required fields that were not present.
+	missing []string
+	// depth is the number of records enclosing the value being decoded.
+	depth int
+	// leftover, when non-nil, collects the top-level record fields that match no field of the message.
+	leftover map[string]interface{}
+	// resolutions is the number of message types resolved by name.
+	resolutions int
+	// deadline is the time that decoding is aborted at, when MaxDuration is set.
+	deadline time.Time
+	// nestDepths holds the depths of the records in the schema of the decoded message, for MaxNestDepth.
+	nestDepths map[protoreflect.FullName]int
+	// unionBranches caches the union branches of fields, for UnknownUnionBranchAsNull.
+	unionBranches map[unionBranchesKey]map[string]struct{}
+}
+
+func (o UnmarshalOptions) newDecoder() *decoder {
+	d := &decoder{opts: o}
+	if o.MaxDuration > 0 {
+		d.deadline = time.Now().Add(o.MaxDuration)
+	}
+	if len(o.RequiredFields) > 0 || o.EnforceProto2Required {
+		d.required = make(map[protoreflect.FullName]struct{}, len(o.RequiredFields))
+		for _, name := range o.RequiredFields {
+			d.required[protoreflect.FullName(name)] = struct{}{}
+		}
+	}
+	return d
+}
+
+// checkDeadline returns an error wrapping context.DeadlineExceeded if decoding has exceeded MaxDuration.
+func (d *decoder) checkDeadline() error {
+	if d.deadline.IsZero() || time.Now().Before(d.deadline) {
+		return nil
+	}
+	return fmt.Errorf(
+		"%s: decode exceeded max duration %s: %w", d.fieldPath(), d.opts.MaxDuration, context.DeadlineExceeded,
+	)
+}
+
+// fieldPath returns the path to the value being decoded, for example "books[1].name".
+func (d *decoder) fieldPath() string {
+	var b strings.Builder
+	for i, segment := range d.path {
+		if i > 0 && !strings.HasPrefix(segment, "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(segment)
+	}
+	return b.String()
+}
+
+// stashRaw records the raw value being decoded in UnmarshalOptions.RawExtras, keyed by its path.
+func (d *decoder) stashRaw(value interface{}) {
+	if *d.opts.RawExtras == nil {
+		*d.opts.RawExtras = make(map[string]interface{})
+	}
+	(*d.opts.RawExtras)[d.fieldPath()] = value
+}
+
+// warn records a non-fatal observation about the value being decoded in UnmarshalOptions.Warnings.
+func (d *decoder) warn(format string, args ...interface{}) {
+	if d.opts.Warnings == nil {
+		return
+	}
+	warning := fmt.Sprintf(format, args...)
+	if len(d.path) > 0 {
+		warning = d.fieldPath() + ": " + warning
+	}
+	*d.opts.Warnings = append(*d.opts.Warnings, warning)
+}
+
+func (d *decoder) pushPath(segment string) {
+	d.path = append(d.path, segment)
+}
+
+func (d *decoder) popPath() {
+	d.path = d.path[:len(d.path)-1]
+}
+
+func (d *decoder) decodeMessage(data interface{}, msg protoreflect.Message) error {
 	if data == nil {
 		return nil
 	}
-	d, ok := data.(map[string]interface{})
+	if d.opts.isExpandedWKT(msg.Descriptor().FullName()) {
+		data = unionWrapperValue(msg.Descriptor().FullName(), data)
+	}
+	record, ok := data.(map[string]interface{})
 	if !ok {
 		return fmt.Errorf("expected message encoded as map[string]interface{}, got %T", data)
 	}
 
-	if isWKT(msg.Descriptor().FullName()) {
-		return decodeWKT(d, msg)
+	if d.opts.isExpandedWKT(msg.Descriptor().FullName()) {
+		return d.decodeWKT(record, msg)
 	}
 	// unwrap union
 	desc := msg.Descriptor()
-	if msgData, ok := d[string(desc.FullName())]; len(d) == 1 && ok {
-		return o.decodeMessage(msgData, msg)
+	if msgData, ok := record[d.opts.recordFullName(desc)]; len(record) == 1 && ok {
+		return d.decodeMessage(msgData, msg)
 	}
-	for fieldName, fieldValue := range d {
-		fd, ok := findField(desc, fieldName)
-		if !ok {
+	if d.opts.MaxDepth > 0 && d.depth >= d.opts.MaxDepth {
+		return fmt.Errorf("%s: records nested deeper than max depth %d", d.fieldPath(), d.opts.MaxDepth)
+	}
+	d.depth++
+	defer func() { d.depth-- }()
+	if d.opts.BeforeMessage != nil {
+		if err := d.opts.BeforeMessage(desc, record); err != nil {
+			return fmt.Errorf("%s: before message: %w", desc.FullName(), err)
+		}
+	}
+	record, err := d.opts.flattenGroups(desc, record)
+	if err != nil {
+		return err
+	}
+	if record, err = d.opts.untagOneofs(desc, record); err != nil {
+		return err
+	}
+	if record, err = d.opts.removeTypeName(desc, record); err != nil {
+		return err
+	}
+	record, checksum, err := d.opts.removeChecksum(record)
+	if err != nil {
+		return err
+	}
+	var present map[protoreflect.FullName]struct{}
+	if d.required != nil {
+		present = make(map[protoreflect.FullName]struct{}, len(record))
+	}
+	var presentFields map[protoreflect.FieldNumber]struct{}
+	if d.opts.UseEnumDefaultOnAbsence {
+		presentFields = make(map[protoreflect.FieldNumber]struct{}, len(record))
+	}
+	var nonNullFields map[protoreflect.FieldNumber]struct{}
+	if len(d.opts.AbsentEnumMember) > 0 {
+		nonNullFields = make(map[protoreflect.FieldNumber]struct{}, len(record))
+	}
+	var oneofs map[protoreflect.FullName]protoreflect.FieldDescriptor
+	if d.opts.RejectMultipleOneofFields {
+		oneofs = make(map[protoreflect.FullName]protoreflect.FieldDescriptor)
+	}
+	for fieldName, fieldValue := range record {
+		if len(d.path) == 0 && d.opts.isExtraTopLevelKey(fieldName) {
+			d.warn("skipped extra top-level key %s", fieldName)
+			continue
+		}
+		fd, err := d.opts.findField(desc, fieldName)
+		if err != nil {
+			return err
+		}
+		if fd == nil {
+			if d.leftover != nil && d.depth == 1 {
+				d.leftover[fieldName] = fieldValue
+				continue
+			}
+			if d.opts.RawExtras != nil {
+				d.pushPath(fieldName)
+				d.stashRaw(fieldValue)
+				d.popPath()
+				continue
+			}
+			if d.opts.OnUnknownField != nil {
+				if err := d.opts.OnUnknownField(desc.FullName(), fieldName, fieldValue); err != nil {
+					return fmt.Errorf("unknown field %s: %w", fieldName, err)
+				}
+				continue
+			}
+			if d.opts.DiscardUnknownFields {
+				d.warn("skipped unknown field %s", fieldName)
+				continue
+			}
 			return fmt.Errorf("unexpected field %s", fieldName)
 		}
-		if err := o.decodeField(fieldValue, msg, fd); err != nil {
+		if isRedacted(fd, d.opts.RedactOption) && isRedactedPlaceholder(fieldValue) {
+			d.pushPath(string(fd.Name()))
+			d.warn("skipped redacted value")
+			d.popPath()
+			continue
+		}
+		if present != nil && fieldValue != nil {
+			present[fd.FullName()] = struct{}{}
+		}
+		if presentFields != nil {
+			presentFields[fd.Number()] = struct{}{}
+		}
+		if nonNullFields != nil && fieldValue != nil {
+			nonNullFields[fd.Number()] = struct{}{}
+		}
+		if oneofs != nil && fieldValue != nil {
+			if err := checkOneof(oneofs, fd); err != nil {
+				return err
+			}
+		}
+		d.pushPath(string(fd.Name()))
+		err = d.decodeField(fieldValue, msg, fd)
+		d.popPath()
+		if err != nil {
 			return err
 		}
 	}
+	if d.required != nil {
+		d.checkRequired(desc, present)
+	}
+	if presentFields != nil {
+		d.applyEnumDefaults(msg, presentFields)
+	}
+	if nonNullFields != nil {
+		if err := d.applyAbsentEnumMembers(msg, nonNullFields); err != nil {
+			return err
+		}
+	}
+	if err := d.verifyChecksum(msg, checksum); err != nil {
+		return err
+	}
+	if d.opts.AfterMessage != nil {
+		if err := d.opts.AfterMessage(msg.Interface()); err != nil {
+			return fmt.Errorf("%s: after message: %w", desc.FullName(), err)
+		}
+	}
 	return nil
 }
 
-func (o *SchemaOptions) decodeField(data interface{}, val protoreflect.Message, f protoreflect.FieldDescriptor) error {
+// checkOneof records fd as the set field of its oneof in oneofs, and returns an error if another field
+// of the oneof is already set.
+func checkOneof(oneofs map[protoreflect.FullName]protoreflect.FieldDescriptor, fd protoreflect.FieldDescriptor) error {
+	oneof := fd.ContainingOneof()
+	if oneof == nil || oneof.IsSynthetic() {
+		return nil
+	}
+	if other, ok := oneofs[oneof.FullName()]; ok && other.Number() != fd.Number() {
+		names := []string{string(other.Name()), string(fd.Name())}
+		sort.Strings(names)
+		return fmt.Errorf("oneof %s: fields %s and %s are both set", oneof.Name(), names[0], names[1])
+	}
+	oneofs[oneof.FullName()] = fd
+	return nil
+}
+
+// checkElements returns an error if n exceeds UnmarshalOptions.MaxElements for repeated or map field f.
+func (d *decoder) checkElements(f protoreflect.FieldDescriptor, n int) error {
+	if d.opts.MaxElements > 0 && n > d.opts.MaxElements {
+		return fmt.Errorf("field %s: %d elements exceed max elements %d", f.Name(), n, d.opts.MaxElements)
+	}
+	return nil
+}
+
+// checkRequired records the required fields of desc that are not present.
+func (d *decoder) checkRequired(desc protoreflect.MessageDescriptor, present map[protoreflect.FullName]struct{}) {
+	for i := 0; i < desc.Fields().Len(); i++ {
+		field := desc.Fields().Get(i)
+		_, ok := d.required[field.FullName()]
+		if !ok && !(d.opts.EnforceProto2Required && field.Cardinality() == protoreflect.Required) {
+			continue
+		}
+		if _, ok := present[field.FullName()]; ok {
+			continue
+		}
+		d.pushPath(string(field.Name()))
+		d.missing = append(d.missing, d.fieldPath())
+		d.popPath()
+	}
+}
+
+func (d *decoder) decodeField(
+	data interface{},
+	val protoreflect.Message,
+	f protoreflect.FieldDescriptor,
+) error {
 	if data == nil {
 		return nil
 	}
+	if d.opts.NestedPayloadField != "" && string(f.FullName()) == d.opts.NestedPayloadField {
+		return d.decodeNestedPayload(data, val, f)
+	}
+	if d.opts.isEmptyAsNullField(f) {
+		return decodeEmptyField(data, val, f)
+	}
+	if d.isUnknownUnionBranch(data, f, false) {
+		return nil
+	}
+	encrypted, err := d.opts.isEncryptedField(f)
+	if err != nil {
+		return err
+	}
+	if encrypted {
+		return d.decodeEncryptedField(data, val, f)
+	}
+	delimiter, delimited, err := d.opts.delimiter(f)
+	if err != nil {
+		return err
+	}
+	if delimited {
+		return decodeDelimitedField(data, val, f, delimiter)
+	}
 	switch {
 	case f.IsMap():
 		mp := val.NewField(f).Map()
-		if err := o.decodeMap(data, f, mp); err != nil {
+		if err := d.decodeMap(data, f, mp); err != nil {
 			return err
 		}
 		val.Set(f, protoreflect.ValueOfMap(mp))
 		return nil
 	case f.IsList():
+		keyField, err := d.opts.repeatedMapKey(f)
+		if err != nil {
+			return err
+		}
+		if keyField == nil && isMapUnion(data) {
+			if keyField, err = d.opts.mapToRepeatedKey(f); err != nil {
+				return err
+			}
+		}
+		if keyField != nil {
+			list := val.NewField(f).List()
+			if err := d.decodeRepeatedMap(data, f, keyField, list); err != nil {
+				return err
+			}
+			val.Set(f, protoreflect.ValueOfList(list))
+			return nil
+		}
 		listData, err := decodeListLike(data, "array")
 		if err != nil {
+			if !d.opts.ScalarAsList {
+				return err
+			}
+			listData = []interface{}{data}
+		}
+		if listData, err = d.flattenNestedArrays(listData); err != nil {
+			return err
+		}
+		if err := d.checkElements(f, len(listData)); err != nil {
 			return err
 		}
 		list := val.NewField(f).List()
-		for _, el := range listData {
+		for i, el := range listData {
+			d.pushPath(fmt.Sprintf("[%d]", i))
 			if el == nil {
+				err := d.appendNullListElement(list)
+				d.popPath()
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if d.isUnknownUnionBranch(el, f, true) {
+				d.popPath()
 				list.Append(list.NewElement())
 				continue
 			}
-			fieldValue, err := o.decodeFieldKind(el, list.NewElement(), f)
+			fieldValue, err := d.decodeFieldKind(el, list.NewElement(), f)
+			d.popPath()
 			if err != nil {
 				return err
 			}
@@ -74,23 +475,98 @@ func (o *SchemaOptions) decodeField(data interface{}, val protoreflect.Message,
 		val.Set(f, protoreflect.ValueOfList(list))
 		return nil
 	default:
-		fieldValue, err := o.decodeFieldKind(data, val.NewField(f), f)
+		fieldValue, err := d.decodeFieldKind(data, val.NewField(f), f)
 		if err != nil {
 			return err
 		}
 		val.Set(f, fieldValue)
+		if f.Kind() == protoreflect.BytesKind {
+			return d.setDataURLMediaType(data, val, f)
+		}
 	}
 	return nil
 }
 
-func (o *SchemaOptions) decodeFieldKind(
+func (d *decoder) decodeFieldKind(
 	data interface{},
 	mutable protoreflect.Value,
 	f protoreflect.FieldDescriptor,
 ) (protoreflect.Value, error) {
+	if err := d.checkDeadline(); err != nil {
+		return protoreflect.Value{}, err
+	}
+	data, err := d.transform(data, f)
+	if err != nil {
+		return protoreflect.Value{}, err
+	}
+	if str, ok := stringData(data); ok {
+		if err := d.checkStringLength(f, str); err != nil {
+			return protoreflect.Value{}, err
+		}
+	}
+	symbols, isEnumInt, err := d.opts.enumIntSymbols(f)
+	if err != nil {
+		return protoreflect.Value{}, err
+	}
+	if isEnumInt {
+		return decodeEnumInt(data, f, symbols)
+	}
+	if decimal, ok := d.opts.DecimalFields[string(f.FullName())]; ok && f.Kind() == protoreflect.StringKind {
+		return decodeDecimal(data, f, decimal)
+	}
+	if d.opts.Int64AsString && isInt64Kind(f.Kind()) {
+		if str, err := decodeStringLike(data, "string"); err == nil {
+			return parseInt64String(str, f)
+		}
+	}
+	if d.opts.BoolAsInt && f.Kind() == protoreflect.BoolKind {
+		if i, err := decodeIntLike(data, "int"); err == nil {
+			return decodeBoolInt(i, f)
+		}
+	}
+	data, err = d.coerce(data, f)
+	if err != nil {
+		return protoreflect.Value{}, err
+	}
+	data = d.parseStringifiedScalar(data, f)
+	data = d.coerceScalar(data, f)
 	switch f.Kind() {
 	case protoreflect.MessageKind, protoreflect.GroupKind:
-		if err := o.decodeMessage(data, mutable.Message()); err != nil {
+		protoBytes, err := d.opts.isProtoBytesField(f)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+		if protoBytes {
+			if err := decodeProtoBytes(data, f, mutable.Message()); err != nil {
+				return protoreflect.Value{}, err
+			}
+			return mutable, nil
+		}
+		if d.opts.MaxNestDepth > 0 && !d.opts.isExpandedWKT(f.Message().FullName()) {
+			if str, err := decodeStringLike(data, "string"); err == nil {
+				if err := decodeProtoJSON(f, str, mutable.Message()); err != nil {
+					return protoreflect.Value{}, err
+				}
+				return mutable, nil
+			}
+		}
+		if d.opts.AutoParseStringifiedMessages && !d.opts.isExpandedWKT(f.Message().FullName()) {
+			if str, err := decodeStringLike(data, "string"); err == nil {
+				var record map[string]interface{}
+				if err := json.Unmarshal([]byte(str), &record); err != nil {
+					return protoreflect.Value{}, fmt.Errorf("field %s: parse stringified message: %w", f.Name(), err)
+				}
+				data = record
+			}
+		}
+		if d.opts.TimestampInput == TimestampInputFloatSeconds && f.Message().FullName() == wkt.Timestamp &&
+			isFloatSeconds(data) {
+			data = map[string]interface{}{"double": data}
+		}
+		if s, ok := data.(string); ok && f.Message().FullName() == wkt.Timestamp {
+			data = map[string]interface{}{"string": s}
+		}
+		if err := d.decodeMessage(data, mutable.Message()); err != nil {
 			return protoreflect.Value{}, err
 		}
 		return mutable, nil
@@ -99,6 +575,12 @@ func (o *SchemaOptions) decodeFieldKind(
 		if err != nil {
 			return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
 		}
+		if err := d.checkSizeHint(f, len(str)); err != nil {
+			return protoreflect.Value{}, err
+		}
+		if err := d.validatePattern(f, str); err != nil {
+			return protoreflect.Value{}, err
+		}
 		return protoreflect.ValueOfString(str), nil
 	case protoreflect.BoolKind:
 		bo, err := decodeBoolLike(data, "boolean")
@@ -107,66 +589,154 @@ func (o *SchemaOptions) decodeFieldKind(
 		}
 		return protoreflect.ValueOfBool(bo), nil
 	case protoreflect.Int32Kind, protoreflect.Sfixed32Kind, protoreflect.Sint32Kind:
+		if err := d.checkIntegralFloat(f, data); err != nil {
+			return protoreflect.Value{}, err
+		}
 		i, err := decodeIntLike(data, "int")
 		if err != nil {
 			return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
 		}
+		if int64(int32(i)) != i {
+			if err := d.lossyConversion(f, "value %d truncated to int32", i); err != nil {
+				return protoreflect.Value{}, err
+			}
+		}
 		return protoreflect.ValueOfInt32(int32(i)), nil
 	case protoreflect.Int64Kind, protoreflect.Sfixed64Kind, protoreflect.Sint64Kind:
-		i, err := decodeIntLike(data, "long")
+		if err := d.checkIntegralFloat(f, data); err != nil {
+			return protoreflect.Value{}, err
+		}
+		i, err := decodeLongLike(data)
 		if err != nil {
 			return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
 		}
 		return protoreflect.ValueOfInt64(i), nil
 	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		if err := d.checkIntegralFloat(f, data); err != nil {
+			return protoreflect.Value{}, err
+		}
 		i, err := decodeIntLike(data, "int")
 		if err != nil {
 			return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
 		}
+		if int64(uint32(i)) != i {
+			if err := d.lossyConversion(f, "value %d truncated to uint32", i); err != nil {
+				return protoreflect.Value{}, err
+			}
+		}
 		return protoreflect.ValueOfUint32(uint32(i)), nil
 	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
-		i, err := decodeIntLike(data, "long")
+		if err := d.checkIntegralFloat(f, data); err != nil {
+			return protoreflect.Value{}, err
+		}
+		i, err := decodeLongLike(data)
 		if err != nil {
 			return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
 		}
+		if i < 0 {
+			if err := d.lossyConversion(f, "negative value %d converted to uint64", i); err != nil {
+				return protoreflect.Value{}, err
+			}
+		}
 		return protoreflect.ValueOfUint64(uint64(i)), nil
 	case protoreflect.BytesKind:
-		bs, err := decodeBytesLike(data, "bytes")
+		bs, err := d.decodeBytesLike(f, data)
 		if err != nil {
 			return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
 		}
+		if d.opts.MaxBytesLength > 0 && len(bs) > d.opts.MaxBytesLength {
+			return protoreflect.Value{}, fmt.Errorf(
+				"field %s: bytes length %d exceeds max length %d", f.Name(), len(bs), d.opts.MaxBytesLength,
+			)
+		}
+		if err := d.checkSizeHint(f, len(bs)); err != nil {
+			return protoreflect.Value{}, err
+		}
+		if err := d.validateBytesLogicalType(f, bs); err != nil {
+			return protoreflect.Value{}, err
+		}
 		return protoreflect.ValueOfBytes(bs), nil
 	case protoreflect.EnumKind:
+		if d.opts.AcceptEnumObjects {
+			if obj, ok := enumObject(data, f); ok {
+				symbol, err := decodeEnumObject(obj, f)
+				if err != nil {
+					return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
+				}
+				data = symbol
+			}
+		}
+		if d.opts.AcceptEnumNumbers {
+			if number, ok := enumNumber(data); ok {
+				v := f.Enum().Values().ByNumber(protoreflect.EnumNumber(number))
+				switch {
+				case v != nil:
+					data = string(v.Name())
+				case d.opts.RejectUnknownEnums:
+					return protoreflect.Value{}, fmt.Errorf("field %s: unknown enum number %d", f.Name(), number)
+				default:
+					return d.unknownEnumValue(f, fmt.Sprintf("number %d", number)), nil
+				}
+			}
+		}
 		str, err := decodeStringLike(data, string(f.Enum().FullName()))
 		if err != nil {
 			return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
 		}
+		if d.opts.CaptureEnumSymbols != nil {
+			d.opts.CaptureEnumSymbols[d.fieldPath()] = str
+		}
+		if d.opts.TrimEnumSymbols {
+			if trimmed := strings.TrimSpace(str); trimmed != str {
+				d.warn("trimmed whitespace around enum symbol %q", str)
+				str = trimmed
+			}
+		}
+		if d.opts.StripEnumNamespace {
+			str = strings.TrimPrefix(str, string(f.Enum().FullName())+".")
+		}
+		if !d.opts.isFrozenEnumSymbol(f.Enum(), str) {
+			return protoreflect.Value{}, fmt.Errorf("field %s: enum symbol %s is not a frozen symbol", f.Name(), str)
+		}
 		if v := f.Enum().Values().ByName(protoreflect.Name(str)); v != nil {
 			return protoreflect.ValueOfEnum(v.Number()), nil
 		}
-		return protoreflect.ValueOfEnum(0), nil
+		if d.opts.RejectUnknownEnums {
+			return protoreflect.Value{}, fmt.Errorf("field %s: unknown enum symbol %s", f.Name(), str)
+		}
+		return d.unknownEnumValue(f, "symbol "+str), nil
 	case protoreflect.DoubleKind:
+		if m, ok := data.(map[string]interface{}); ok {
+			dbl, err := decodeDoubleLike(m)
+			if err != nil {
+				return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
+			}
+			return protoreflect.ValueOfFloat64(dbl), nil
+		}
 		dbl, ok := data.(float64)
 		if !ok {
 			return protoreflect.Value{}, fmt.Errorf("field %s: expected float64, got %T", f.Name(), data)
 		}
 		return protoreflect.ValueOfFloat64(dbl), nil
 	case protoreflect.FloatKind:
-		flt, ok := data.(float32)
-		if !ok {
-			return protoreflect.Value{}, fmt.Errorf("field %s: expected float32, got %T", f.Name(), data)
+		if m, ok := data.(map[string]interface{}); ok {
+			key := string(avro.FloatType)
+			if _, ok := m[string(avro.DoubleType)]; ok && len(m) == 1 {
+				key = string(avro.DoubleType)
+			}
+			flt, err := decodeFloatLike(m, key)
+			if err != nil {
+				return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
+			}
+			return d.narrowFloat(f, flt)
+		}
+		switch flt := data.(type) {
+		case float32:
+			return protoreflect.ValueOfFloat32(flt), nil
+		case float64:
+			return d.narrowFloat(f, flt)
 		}
-		return protoreflect.ValueOfFloat32(flt), nil
+		return protoreflect.Value{}, fmt.Errorf("field %s: expected float32, got %T", f.Name(), data)
 	}
 	return protoreflect.Value{}, fmt.Errorf("unexpected kind %s", f.Kind())
 }
-
-func findField(desc protoreflect.MessageDescriptor, name string) (protoreflect.FieldDescriptor, bool) {
-	if fd := desc.Fields().ByJSONName(name); fd != nil {
-		return fd, true
-	}
-	if fd := desc.Fields().ByTextName(name); fd != nil {
-		return fd, true
-	}
-	return nil, false
-}
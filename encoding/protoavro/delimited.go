@@ -0,0 +1,108 @@
+package protoavro
+
+import (
+	"fmt"
+	"strings"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// delimitedEscape is the character that escapes delimiters and itself in elements of delimited fields.
+const delimitedEscape = '\\'
+
+// delimiter returns the delimiter of field, when it is one of the DelimitedRepeatedFields.
+func (o SchemaOptions) delimiter(field protoreflect.FieldDescriptor) (string, bool, error) {
+	delimiter, ok := o.DelimitedRepeatedFields[string(field.FullName())]
+	if !ok {
+		return "", false, nil
+	}
+	if !field.IsList() || field.Kind() != protoreflect.StringKind {
+		return "", false, fmt.Errorf("field %s: only repeated string fields can be delimited", field.Name())
+	}
+	if delimiter == "" || strings.ContainsRune(delimiter, delimitedEscape) {
+		return "", false, fmt.Errorf("field %s: invalid delimiter %q", field.Name(), delimiter)
+	}
+	return delimiter, true, nil
+}
+
+// delimitedFieldSchema returns the schema of a delimited field, which holds the joined elements as a string.
+func delimitedFieldSchema(field protoreflect.FieldDescriptor, doc string) avro.Field {
+	return avro.Field{
+		Name: string(field.Name()),
+		Doc:  doc,
+		Type: avro.String(),
+	}
+}
+
+// delimitedJSON returns the elements of list joined by delimiter, with the first byte of the delimiter
+// and the escape character escaped in elements.
+func (o MarshalOptions) delimitedJSON(list protoreflect.List, delimiter string) interface{} {
+	var b strings.Builder
+	for i := 0; i < list.Len(); i++ {
+		if i > 0 {
+			b.WriteString(delimiter)
+		}
+		element := list.Get(i).String()
+		for j := 0; j < len(element); j++ {
+			// escaping the first byte of the delimiter keeps multi-byte delimiters unambiguous,
+			// such as "||" after an element that ends with "|".
+			if element[j] == delimitedEscape || element[j] == delimiter[0] {
+				b.WriteByte(delimitedEscape)
+			}
+			b.WriteByte(element[j])
+		}
+	}
+	return o.unionValue(string(avro.StringType), b.String())
+}
+
+// splitDelimited splits str into the elements joined by delimitedJSON.
+// The empty string is split into no elements.
+func splitDelimited(str string, delimiter string) ([]string, error) {
+	if str == "" {
+		return nil, nil
+	}
+	var elements []string
+	var b strings.Builder
+	for len(str) > 0 {
+		switch {
+		case str[0] == delimitedEscape:
+			if len(str) == 1 {
+				return nil, fmt.Errorf("trailing escape character")
+			}
+			b.WriteByte(str[1])
+			str = str[2:]
+		case strings.HasPrefix(str, delimiter):
+			elements = append(elements, b.String())
+			b.Reset()
+			str = str[len(delimiter):]
+		default:
+			b.WriteByte(str[0])
+			str = str[1:]
+		}
+	}
+	return append(elements, b.String()), nil
+}
+
+// decodeDelimitedField splits the string in data into the elements of the repeated field f of msg.
+func decodeDelimitedField(
+	data interface{},
+	msg protoreflect.Message,
+	f protoreflect.FieldDescriptor,
+	delimiter string,
+) error {
+	str, err := decodeStringLike(data, string(avro.StringType))
+	if err != nil {
+		return fmt.Errorf("field %s: %w", f.Name(), err)
+	}
+	elements, err := splitDelimited(str, delimiter)
+	if err != nil {
+		return fmt.Errorf("field %s: split delimited: %w", f.Name(), err)
+	}
+	list := msg.NewField(f).List()
+	for _, element := range elements {
+		list.Append(protoreflect.ValueOfString(element))
+	}
+	msg.Set(f, protoreflect.ValueOfList(list))
+	return nil
+}
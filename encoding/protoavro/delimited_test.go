@@ -0,0 +1,63 @@
+package protoavro
+
+import (
+	"testing"
+
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_DelimitedRepeatedFields(t *testing.T) {
+	opts := SchemaOptions{
+		DelimitedRepeatedFields: map[string]string{"einride.avro.example.v1.ExampleList.string_list": ","},
+	}
+	schema, err := opts.InferSchema((&examplev1.ExampleList{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	stringList := schema.(avro.Union)[1].(avro.Record).Fields[1]
+	assert.Equal(t, "string_list", stringList.Name)
+	assert.DeepEqual(t, avro.Nullable(avro.String()), stringList.Type)
+	for _, tt := range []struct {
+		name     string
+		list     []string
+		expected string
+	}{
+		{name: "empty", expected: ""},
+		{name: "single", list: []string{"a"}, expected: "a"},
+		{name: "multiple", list: []string{"a", "b", "c"}, expected: "a,b,c"},
+		{name: "embedded delimiter", list: []string{"a,b", "c"}, expected: `a\,b,c`},
+		{name: "embedded escape", list: []string{`a\`, `\,`}, expected: `a\\,\\\,`},
+		{name: "empty elements", list: []string{"a", "", ""}, expected: "a,,"},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			native := assertRoundTrip(t, opts, schema, &examplev1.ExampleList{StringList: tt.list})
+			record := native.(map[string]interface{})["einride.avro.example.v1.ExampleList"].(map[string]interface{})
+			assert.DeepEqual(t, map[string]interface{}{"string": tt.expected}, record["string_list"])
+		})
+	}
+	t.Run("multi-character delimiter", func(t *testing.T) {
+		opts := SchemaOptions{
+			DelimitedRepeatedFields: map[string]string{"einride.avro.example.v1.ExampleList.string_list": "||"},
+		}
+		schema, err := opts.InferSchema((&examplev1.ExampleList{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		native := assertRoundTrip(t, opts, schema, &examplev1.ExampleList{StringList: []string{"a|", "||b", "c"}})
+		record := native.(map[string]interface{})["einride.avro.example.v1.ExampleList"].(map[string]interface{})
+		assert.DeepEqual(t, map[string]interface{}{"string": `a\|||\|\|b||c`}, record["string_list"])
+	})
+	t.Run("trailing escape", func(t *testing.T) {
+		var got examplev1.ExampleList
+		err := UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{
+			"string_list": map[string]interface{}{"string": `a\`},
+		}, &got)
+		assert.ErrorContains(t, err, "field string_list: split delimited: trailing escape character")
+	})
+	t.Run("not a repeated string field", func(t *testing.T) {
+		opts := SchemaOptions{
+			DelimitedRepeatedFields: map[string]string{"einride.avro.example.v1.ExampleList.int64_list": ","},
+		}
+		_, err := opts.InferSchema((&examplev1.ExampleList{}).ProtoReflect().Descriptor())
+		assert.ErrorContains(t, err, "field int64_list: only repeated string fields can be delimited")
+	})
+}
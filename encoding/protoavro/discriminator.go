@@ -0,0 +1,60 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Discriminator selects the message type of a record from a string field in the record,
+// for envelopes that carry several message types without using Avro unions.
+type Discriminator struct {
+	// Field is the name of the record field holding the discriminator value.
+	Field string
+	// Types maps discriminator values to the message types they select.
+	Types map[string]protoreflect.MessageType
+}
+
+// UnmarshalDiscriminated decodes the Avro JSON encoded record into a new message
+// of the type selected by the Discriminator field of the record.
+// The discriminator field is only decoded into the message if the message has a field with the same name.
+func (o UnmarshalOptions) UnmarshalDiscriminated(data interface{}) (proto.Message, error) {
+	if o.Discriminator.Field == "" {
+		return nil, fmt.Errorf("no discriminator field configured")
+	}
+	record, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("expected record encoded as map[string]interface{}, got %T", data)
+	}
+	discriminatorData, ok := record[o.Discriminator.Field]
+	if !ok || discriminatorData == nil {
+		return nil, fmt.Errorf("missing discriminator field '%s'", o.Discriminator.Field)
+	}
+	value, err := decodeStringLike(discriminatorData, "string")
+	if err != nil {
+		return nil, fmt.Errorf("discriminator field '%s': %w", o.Discriminator.Field, err)
+	}
+	messageType, ok := o.Discriminator.Types[value]
+	if !ok {
+		return nil, fmt.Errorf("unknown discriminator value '%s'", value)
+	}
+	message := messageType.New()
+	fd, err := o.findField(message.Descriptor(), o.Discriminator.Field)
+	if err != nil {
+		return nil, err
+	}
+	if fd == nil {
+		payload := make(map[string]interface{}, len(record)-1)
+		for key, value := range record {
+			if key != o.Discriminator.Field {
+				payload[key] = value
+			}
+		}
+		record = payload
+	}
+	if err := o.Unmarshal(record, message.Interface()); err != nil {
+		return nil, err
+	}
+	return message.Interface(), nil
+}
@@ -0,0 +1,80 @@
+package protoavro
+
+import (
+	"testing"
+
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnmarshalOptions_UnmarshalDiscriminated(t *testing.T) {
+	opts := UnmarshalOptions{
+		Discriminator: Discriminator{
+			Field: "type",
+			Types: map[string]protoreflect.MessageType{
+				"book": (&library.Book{}).ProtoReflect().Type(),
+				"enum": (&examplev1.ExampleEnum{}).ProtoReflect().Type(),
+			},
+		},
+	}
+	for _, tt := range []struct {
+		name        string
+		data        interface{}
+		expected    proto.Message
+		errContains string
+	}{
+		{
+			name: "book",
+			data: map[string]interface{}{
+				"type":  map[string]interface{}{"string": "book"},
+				"name":  map[string]interface{}{"string": "shelves/1/books/1"},
+				"title": map[string]interface{}{"string": "Harry Potter"},
+			},
+			expected: &library.Book{
+				Name:  "shelves/1/books/1",
+				Title: "Harry Potter",
+			},
+		},
+		{
+			name: "enum",
+			data: map[string]interface{}{
+				"type": "enum",
+				"enum_value": map[string]interface{}{
+					"einride.avro.example.v1.ExampleEnum.Enum": "ENUM_VALUE1",
+				},
+			},
+			expected: &examplev1.ExampleEnum{
+				EnumValue: examplev1.ExampleEnum_ENUM_VALUE1,
+			},
+		},
+		{
+			name: "unknown",
+			data: map[string]interface{}{
+				"type": "unknown",
+			},
+			errContains: "unknown discriminator value 'unknown'",
+		},
+		{
+			name: "missing",
+			data: map[string]interface{}{
+				"name": map[string]interface{}{"string": "shelves/1/books/1"},
+			},
+			errContains: "missing discriminator field 'type'",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := opts.UnmarshalDiscriminated(tt.data)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got, protocmp.Transform())
+		})
+	}
+}
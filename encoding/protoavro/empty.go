@@ -0,0 +1,49 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// emptyFullName is the full name of google.protobuf.Empty.
+const emptyFullName protoreflect.FullName = "google.protobuf.Empty"
+
+// isEmptyAsNullField returns true if field is a singular google.protobuf.Empty field represented
+// as a presence flag by EmptyAsNull.
+func (o SchemaOptions) isEmptyAsNullField(field protoreflect.FieldDescriptor) bool {
+	return o.EmptyAsNull && !field.IsList() && !field.IsMap() &&
+		field.Message() != nil && field.Message().FullName() == emptyFullName
+}
+
+// emptyFieldSchema returns the schema of a google.protobuf.Empty field represented by EmptyAsNull,
+// which is made nullable like other fields unless it is required.
+func emptyFieldSchema(field protoreflect.FieldDescriptor, doc string) avro.Field {
+	return avro.Field{
+		Name: string(field.Name()),
+		Doc:  doc,
+		Type: avro.Boolean(),
+	}
+}
+
+// emptyJSON returns the encoding of the google.protobuf.Empty field of message, which is true if it is set.
+func (o MarshalOptions) emptyJSON(message protoreflect.Message, field protoreflect.FieldDescriptor) interface{} {
+	value := o.unionValue(string(avro.BooleanType), message.Has(field))
+	if o.isRequiredField(field) {
+		return unionMember(value)
+	}
+	return value
+}
+
+// decodeEmptyField sets field f of msg to an empty message if data is true.
+func decodeEmptyField(data interface{}, msg protoreflect.Message, f protoreflect.FieldDescriptor) error {
+	present, err := decodeBoolLike(data, string(avro.BooleanType))
+	if err != nil {
+		return fmt.Errorf("field %s: %w", f.Name(), err)
+	}
+	if present {
+		msg.Set(f, msg.NewField(f))
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package protoavro
+
+import (
+	"testing"
+
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_EmptyAsNull(t *testing.T) {
+	opts := SchemaOptions{EmptyAsNull: true}
+	schema, err := opts.InferSchema((&examplev1.ExampleEmpty{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Nullable(avro.Record{
+		Type:      avro.RecordType,
+		Name:      "ExampleEmpty",
+		Namespace: "einride.avro.example.v1",
+		Fields: []avro.Field{
+			{Name: "marker", Type: avro.Nullable(avro.Boolean())},
+			{Name: "name", Type: avro.Nullable(avro.String())},
+		},
+	}), schema)
+	t.Run("present", func(t *testing.T) {
+		native := assertRoundTrip(t, opts, schema, &examplev1.ExampleEmpty{Marker: &emptypb.Empty{}, Name: "a"})
+		record := native.(map[string]interface{})["einride.avro.example.v1.ExampleEmpty"].(map[string]interface{})
+		assert.DeepEqual(t, map[string]interface{}{"boolean": true}, record["marker"])
+	})
+	t.Run("absent", func(t *testing.T) {
+		native := assertRoundTrip(t, opts, schema, &examplev1.ExampleEmpty{Name: "a"})
+		record := native.(map[string]interface{})["einride.avro.example.v1.ExampleEmpty"].(map[string]interface{})
+		assert.DeepEqual(t, map[string]interface{}{"boolean": false}, record["marker"])
+	})
+	t.Run("null", func(t *testing.T) {
+		var got examplev1.ExampleEmpty
+		assert.NilError(t, UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{"marker": nil}, &got))
+		assert.Assert(t, got.Marker == nil)
+	})
+	t.Run("without option", func(t *testing.T) {
+		schema, err := SchemaOptions{}.InferSchema((&examplev1.ExampleEmpty{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		markerType := schema.(avro.Union)[1].(avro.Record).Fields[0].Type
+		_, ok := markerType.(avro.Union)[1].(avro.Record)
+		assert.Assert(t, ok)
+	})
+}
@@ -1,72 +1,170 @@
 package protoavro
 
 import (
+	"fmt"
+	"strconv"
+
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
 // encodeJSON returns the Avro JSON encoding of message.
-func (o SchemaOptions) encodeJSON(message proto.Message) (interface{}, error) {
-	return o.messageJSON(message.ProtoReflect(), 0)
+func (o MarshalOptions) encodeJSON(message proto.Message) (interface{}, error) {
+	o, err := o.withNestDepths(message.ProtoReflect().Descriptor())
+	if err != nil {
+		return nil, err
+	}
+	payload, err := o.messageJSON(message.ProtoReflect(), 0)
+	if err != nil || o.Envelope == nil {
+		return payload, err
+	}
+	return o.encodeEnvelope(payload)
 }
 
-func (o SchemaOptions) unionValue(key string, value interface{}) map[string]interface{} {
+func (o MarshalOptions) unionValue(key string, value interface{}) map[string]interface{} {
 	return map[string]interface{}{
 		key: value,
 	}
 }
 
-func (o SchemaOptions) messageJSON(message protoreflect.Message, recursiveIndex int) (interface{}, error) {
+func (o MarshalOptions) messageJSON(message protoreflect.Message, recursiveIndex int) (interface{}, error) {
 	if !message.IsValid() {
 		return nil, nil
 	}
-	if isWKT(message.Descriptor().FullName()) {
+	if o.isExpandedWKT(message.Descriptor().FullName()) {
 		value, err := o.encodeWKT(message)
 		if err != nil {
 			return nil, err
 		}
+		if value == nil {
+			return nil, nil
+		}
 		return value, nil
 	}
 	desc := message.Descriptor()
 	record := make(map[string]interface{}, desc.Fields().Len())
 	for i := 0; i < desc.Fields().Len(); i++ {
 		field := desc.Fields().Get(i)
-		if field.ContainingOneof() != nil {
-			if !message.Has(field) {
-				// dont populate scalar fields belonging to
-				// a oneof (.Get returns the default value)
-				record[string(field.Name())] = nil
-			} else {
-				value := message.Get(field)
-				jsonValue, err := o.fieldJSON(field, value, recursiveIndex+1)
-				if err != nil {
-					return nil, err
-				}
-				record[string(field.Name())] = jsonValue
-			}
-			continue
-		}
-		value := message.Get(field)
-		jsonValue, err := o.fieldJSON(field, value, recursiveIndex+1)
+		jsonValue, err := o.recordFieldJSON(message, field, recursiveIndex)
 		if err != nil {
 			return nil, err
 		}
 		record[string(field.Name())] = jsonValue
 	}
+	if !desc.IsMapEntry() {
+		o.tagOneofRecord(message, record)
+		if err := o.groupRecord(desc, record); err != nil {
+			return nil, err
+		}
+		if o.EmbedTypeName {
+			record[o.typeNameField()] = string(desc.FullName())
+		}
+		if o.AppendChecksum {
+			checksum, err := messageChecksum(message)
+			if err != nil {
+				return nil, err
+			}
+			record[checksumField] = checksum
+		}
+	}
+	if o.PostProcess != nil && recursiveIndex == 0 {
+		if err := o.PostProcess(record); err != nil {
+			return nil, fmt.Errorf("post process: %w", err)
+		}
+	}
 	if o.OmitRootElement && recursiveIndex == 0 {
 		return record, nil
 	}
 	return map[string]interface{}{
-		string(desc.FullName()): record,
+		o.recordFullName(desc): record,
 	}, nil
 }
 
-func (o SchemaOptions) fieldJSON(
+// recordFieldJSON returns the value of field in the record of message.
+func (o MarshalOptions) recordFieldJSON(
+	message protoreflect.Message,
+	field protoreflect.FieldDescriptor,
+	recursiveIndex int,
+) (interface{}, error) {
+	if jsonValue, ok, err := o.encodedFieldJSON(message, field); ok || err != nil {
+		return jsonValue, err
+	}
+	if field.ContainingOneof() != nil && !o.isRequiredField(field) {
+		if !message.Has(field) {
+			// dont populate scalar fields belonging to
+			// a oneof (.Get returns the default value)
+			return nil, nil
+		}
+		return o.fieldJSON(field, message.Get(field), recursiveIndex+1)
+	}
+	value := message.Get(field)
+	if o.isRequiredField(field) {
+		if field.Message() != nil && !field.IsList() && !field.IsMap() && !message.Has(field) {
+			// required messages are encoded as empty records when not set.
+			value = message.NewField(field)
+		}
+		jsonValue, err := o.fieldJSON(field, value, recursiveIndex+1)
+		if err != nil {
+			return nil, err
+		}
+		return unionMember(jsonValue), nil
+	}
+	if o.OmitEmptyMessages && isEmptyMessageField(field, value) {
+		return nil, nil
+	}
+	return o.fieldJSON(field, value, recursiveIndex+1)
+}
+
+// encodedFieldJSON returns the value of field in the record of message, and true, if the field has an
+// encoding of its own, such as redacted and encrypted fields.
+func (o MarshalOptions) encodedFieldJSON(
+	message protoreflect.Message,
+	field protoreflect.FieldDescriptor,
+) (interface{}, bool, error) {
+	if isRedacted(field, o.RedactOption) {
+		if field.ContainingOneof() != nil && !message.Has(field) {
+			return nil, true, nil
+		}
+		return o.redactedJSON(field), true, nil
+	}
+	encrypted, err := o.isEncryptedField(field)
+	if err != nil {
+		return nil, false, err
+	}
+	if encrypted {
+		jsonValue, err := o.encryptedJSON(message, field)
+		return jsonValue, true, err
+	}
+	if o.isEmptyAsNullField(field) {
+		return o.emptyJSON(message, field), true, nil
+	}
+	delimiter, delimited, err := o.delimiter(field)
+	if err != nil {
+		return nil, false, err
+	}
+	if delimited {
+		jsonValue := o.delimitedJSON(message.Get(field).List(), delimiter)
+		if o.isRequiredField(field) {
+			jsonValue = unionMember(jsonValue)
+		}
+		return jsonValue, true, nil
+	}
+	return nil, false, nil
+}
+
+func (o MarshalOptions) fieldJSON(
 	field protoreflect.FieldDescriptor,
 	value protoreflect.Value,
 	recursiveIndex int,
 ) (interface{}, error) {
 	if field.IsList() {
+		keyField, err := o.repeatedMapKey(field)
+		if err != nil {
+			return nil, err
+		}
+		if keyField != nil {
+			return o.encodeRepeatedMap(field, keyField, value.List(), recursiveIndex)
+		}
 		list := make([]interface{}, 0, value.List().Len())
 		for i := 0; i < value.List().Len(); i++ {
 			v := value.List().Get(i)
@@ -84,43 +182,100 @@ func (o SchemaOptions) fieldJSON(
 	return o.fieldKindJSON(field, value, recursiveIndex)
 }
 
-func (o SchemaOptions) fieldKindJSON(
+func (o MarshalOptions) fieldKindJSON(
 	field protoreflect.FieldDescriptor,
 	value protoreflect.Value,
 	recursiveIndex int,
 ) (interface{}, error) {
+	symbols, isEnumInt, err := o.enumIntSymbols(field)
+	if err != nil {
+		return nil, err
+	}
+	if isEnumInt {
+		return o.encodeEnumInt(field, value, symbols)
+	}
 	switch field.Kind() {
 	case protoreflect.MessageKind, protoreflect.GroupKind:
+		protoBytes, err := o.isProtoBytesField(field)
+		if err != nil {
+			return nil, err
+		}
+		if protoBytes {
+			return o.protoBytesJSON(field, value.Message())
+		}
+		if o.isJSONMessageField(field, o.nestDepth(field, recursiveIndex)) {
+			return o.messageProtoJSON(field, value.Message())
+		}
 		return o.messageJSON(value.Message(), recursiveIndex)
 	case protoreflect.EnumKind:
-		return o.unionValue(
-			string(field.Enum().FullName()),
-			string(field.Enum().Values().Get(int(value.Enum())).Name()),
-		), nil
+		symbol := string(field.Enum().Values().Get(int(value.Enum())).Name())
+		if !o.isFrozenEnumSymbol(field.Enum(), symbol) {
+			return nil, fmt.Errorf("field %s: enum value %s is not a frozen symbol", field.Name(), symbol)
+		}
+		return o.unionValue(string(field.Enum().FullName()), symbol), nil
 	case protoreflect.StringKind:
 		return o.unionValue("string", value.String()), nil
 	case protoreflect.Int32Kind,
-		protoreflect.Fixed32Kind,
 		protoreflect.Sfixed32Kind,
 		protoreflect.Sint32Kind:
 		return o.unionValue("int", int32(value.Int())), nil
-	case protoreflect.Uint32Kind:
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
 		return o.unionValue("int", int32(value.Uint())), nil
 	case protoreflect.Int64Kind,
-		protoreflect.Fixed64Kind,
 		protoreflect.Sfixed64Kind,
 		protoreflect.Sint64Kind:
+		if o.Int64AsString {
+			return o.int64StringJSON(field, value), nil
+		}
 		return o.unionValue("long", value.Int()), nil
-	case protoreflect.Uint64Kind:
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if o.Int64AsString {
+			return o.int64StringJSON(field, value), nil
+		}
 		return o.unionValue("long", int64(value.Uint())), nil
 	case protoreflect.BoolKind:
+		if o.BoolAsInt {
+			return o.boolIntJSON(value), nil
+		}
 		return o.unionValue("boolean", value.Bool()), nil
 	case protoreflect.BytesKind:
-		return o.unionValue("bytes", value.Bytes()), nil
-	case protoreflect.DoubleKind:
-		return o.unionValue("double", value.Float()), nil
-	case protoreflect.FloatKind:
-		return o.unionValue("float", float32(value.Float())), nil
+		return o.encodeBytes(field, value.Bytes())
+	case protoreflect.DoubleKind, protoreflect.FloatKind:
+		if o.isSpecialFloat(value.Float()) {
+			if o.isRequiredField(field) && !field.IsList() {
+				return nil, fmt.Errorf("field %s: %v can not be encoded as null in a required field", field.Name(), value.Float())
+			}
+			return nil, nil
+		}
+		if field.Kind() == protoreflect.FloatKind {
+			return o.unionValue("float", float32(o.roundFloat(value.Float(), 32))), nil
+		}
+		return o.unionValue("double", o.roundFloat(value.Float(), 64)), nil
 	}
 	return value.Interface(), nil
 }
+
+// isEmptyMessageField returns true if value is a singular message with only default field values.
+// Well-known types are never considered empty, since their default values are meaningful.
+func isEmptyMessageField(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+	if field.Kind() != protoreflect.MessageKind || field.IsList() || field.IsMap() {
+		return false
+	}
+	if isWKT(field.Message().FullName()) {
+		return false
+	}
+	return value.Message().IsValid() && proto.Size(value.Message().Interface()) == 0
+}
+
+// roundFloat rounds f to FloatPrecision significant digits, when set.
+// bitSize is 32 for float values and 64 for double values.
+func (o MarshalOptions) roundFloat(f float64, bitSize int) float64 {
+	if o.FloatPrecision <= 0 {
+		return f
+	}
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(f, 'g', o.FloatPrecision, bitSize), bitSize)
+	if err != nil {
+		return f
+	}
+	return rounded
+}
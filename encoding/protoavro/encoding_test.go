@@ -571,7 +571,7 @@ func Test_JSON(t *testing.T) {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			// t.Parallel()
-			got, err := tt.opts.encodeJSON(tt.msg)
+			got, err := MarshalOptions{SchemaOptions: tt.opts}.encodeJSON(tt.msg)
 			assert.NilError(t, err)
 			assert.DeepEqual(t, tt.expected, got)
 
@@ -587,7 +587,7 @@ func Test_JSON(t *testing.T) {
 
 			next := proto.Clone(tt.msg)
 			proto.Reset(next)
-			assert.NilError(t, tt.opts.decodeJSON(got, next))
+			assert.NilError(t, UnmarshalOptions{SchemaOptions: tt.opts}.Unmarshal(got, next))
 			assert.DeepEqual(t, tt.msg, next, protocmp.Transform())
 		})
 	}
@@ -1109,7 +1109,7 @@ func Test_OmitRoot_JSON(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.opts = SchemaOptions{OmitRootElement: true}
 			// t.Parallel()
-			got, err := tt.opts.encodeJSON(tt.msg)
+			got, err := MarshalOptions{SchemaOptions: tt.opts}.encodeJSON(tt.msg)
 			assert.NilError(t, err)
 			assert.DeepEqual(t, tt.expected, got)
 
@@ -1125,7 +1125,7 @@ func Test_OmitRoot_JSON(t *testing.T) {
 
 			next := proto.Clone(tt.msg)
 			proto.Reset(next)
-			assert.NilError(t, tt.opts.decodeJSON(got, next))
+			assert.NilError(t, UnmarshalOptions{SchemaOptions: tt.opts}.Unmarshal(got, next))
 			assert.DeepEqual(t, tt.msg, next, protocmp.Transform())
 		})
 	}
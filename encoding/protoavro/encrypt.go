@@ -0,0 +1,90 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// encryptedProp is the custom field property that marks fields with encrypted values.
+const encryptedProp = "proto.encrypted"
+
+// isEncryptedField returns true if field is one of EncryptedFields.
+// An error is returned for encrypted fields that are not singular string or bytes fields.
+func (o SchemaOptions) isEncryptedField(field protoreflect.FieldDescriptor) (bool, error) {
+	var found bool
+	for _, name := range o.EncryptedFields {
+		if name == string(field.FullName()) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return false, nil
+	}
+	if field.IsList() || field.IsMap() ||
+		field.Kind() != protoreflect.StringKind && field.Kind() != protoreflect.BytesKind {
+		return false, fmt.Errorf("field %s: only singular string and bytes fields can be encrypted", field.Name())
+	}
+	return true, nil
+}
+
+// encryptedFieldSchema returns the schema of an encrypted field, which holds the ciphertext as bytes.
+func encryptedFieldSchema(field protoreflect.FieldDescriptor, doc string) avro.Field {
+	return avro.Field{
+		Name:  string(field.Name()),
+		Doc:   doc,
+		Type:  avro.Bytes(),
+		Props: map[string]interface{}{encryptedProp: true},
+	}
+}
+
+// encryptedJSON returns the encoding of the encrypted field of message.
+func (o MarshalOptions) encryptedJSON(
+	message protoreflect.Message,
+	field protoreflect.FieldDescriptor,
+) (interface{}, error) {
+	if field.HasPresence() && !message.Has(field) {
+		return nil, nil
+	}
+	if o.FieldEncryptor == nil {
+		return nil, fmt.Errorf("field %s: encrypted field without FieldEncryptor", field.Name())
+	}
+	var plaintext []byte
+	if field.Kind() == protoreflect.StringKind {
+		plaintext = []byte(message.Get(field).String())
+	} else {
+		plaintext = message.Get(field).Bytes()
+	}
+	ciphertext, err := o.FieldEncryptor(field, plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: encrypt: %w", field.Name(), err)
+	}
+	return o.unionValue(string(avro.BytesType), ciphertext), nil
+}
+
+// decodeEncryptedField decrypts the ciphertext in data and sets it as the value of field f of msg.
+func (d *decoder) decodeEncryptedField(
+	data interface{},
+	msg protoreflect.Message,
+	f protoreflect.FieldDescriptor,
+) error {
+	if d.opts.FieldDecryptor == nil {
+		return fmt.Errorf("field %s: encrypted field without FieldDecryptor", f.Name())
+	}
+	ciphertext, err := decodeBytesLike(data, string(avro.BytesType))
+	if err != nil {
+		return fmt.Errorf("field %s: %w", f.Name(), err)
+	}
+	plaintext, err := d.opts.FieldDecryptor(f, ciphertext)
+	if err != nil {
+		return fmt.Errorf("field %s: decrypt: %w", f.Name(), err)
+	}
+	if f.Kind() == protoreflect.StringKind {
+		msg.Set(f, protoreflect.ValueOfString(string(plaintext)))
+	} else {
+		msg.Set(f, protoreflect.ValueOfBytes(nonNilBytes(plaintext)))
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+// xorCipher is a stub cipher that flips the bits of every byte.
+func xorCipher(_ protoreflect.FieldDescriptor, in []byte) ([]byte, error) {
+	out := make([]byte, len(in))
+	for i, b := range in {
+		out[i] = b ^ 0xFF
+	}
+	return out, nil
+}
+
+func TestSchemaOptions_EncryptedFields(t *testing.T) {
+	msg := &examplev1.ExampleRedact{
+		Name:   "name",
+		Secret: "secret",
+		Pin:    1234,
+	}
+	schemaOpts := SchemaOptions{EncryptedFields: []string{"einride.avro.example.v1.ExampleRedact.secret"}}
+	t.Run("round trip", func(t *testing.T) {
+		got, err := MarshalOptions{SchemaOptions: schemaOpts, FieldEncryptor: xorCipher}.Encode(msg)
+		assert.NilError(t, err)
+		record := got.(map[string]interface{})["einride.avro.example.v1.ExampleRedact"].(map[string]interface{})
+		assert.DeepEqual(t, map[string]interface{}{"string": "name"}, record["name"])
+		ciphertext := record["secret"].(map[string]interface{})["bytes"].([]byte)
+		assert.Assert(t, string(ciphertext) != "secret")
+
+		schema, err := schemaOpts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		secretField := schema.(avro.Union)[1].(avro.Record).Fields[1]
+		assert.Equal(t, "secret", secretField.Name)
+		assert.DeepEqual(t, avro.Nullable(avro.Bytes()), secretField.Type)
+		assert.DeepEqual(t, map[string]interface{}{encryptedProp: true}, secretField.Props)
+		codec, err := goavro.NewCodec(string(schemaBytes))
+		assert.NilError(t, err)
+		binary, err := codec.BinaryFromNative(nil, got)
+		assert.NilError(t, err)
+		native, _, err := codec.NativeFromBinary(binary)
+		assert.NilError(t, err)
+
+		var decoded examplev1.ExampleRedact
+		opts := UnmarshalOptions{SchemaOptions: schemaOpts, FieldDecryptor: xorCipher}
+		assert.NilError(t, opts.Unmarshal(native, &decoded))
+		assert.DeepEqual(t, msg, &decoded, protocmp.Transform())
+	})
+	t.Run("without encryptor", func(t *testing.T) {
+		_, err := MarshalOptions{SchemaOptions: schemaOpts}.Encode(msg)
+		assert.ErrorContains(t, err, "field secret: encrypted field without FieldEncryptor")
+	})
+	t.Run("without decryptor", func(t *testing.T) {
+		var decoded examplev1.ExampleRedact
+		err := UnmarshalOptions{SchemaOptions: schemaOpts}.Unmarshal(map[string]interface{}{
+			"secret": map[string]interface{}{"bytes": []byte("ciphertext")},
+		}, &decoded)
+		assert.ErrorContains(t, err, "field secret: encrypted field without FieldDecryptor")
+	})
+	t.Run("unsupported field", func(t *testing.T) {
+		opts := SchemaOptions{EncryptedFields: []string{"einride.avro.example.v1.ExampleRedact.pin"}}
+		_, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.ErrorContains(t, err, "field pin: only singular string and bytes fields can be encrypted")
+	})
+}
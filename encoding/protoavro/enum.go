@@ -0,0 +1,140 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// enumObject returns the object of an enum value encoded as an object with symbol and value fields,
+// such as {"symbol": "RED", "value": 2}, optionally wrapped in a union.
+func enumObject(data interface{}, f protoreflect.FieldDescriptor) (map[string]interface{}, bool) {
+	m, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	if wrapped, ok := m[string(f.Enum().FullName())].(map[string]interface{}); ok && len(m) == 1 {
+		m = wrapped
+	}
+	_, hasSymbol := m["symbol"]
+	_, hasValue := m["value"]
+	if !hasSymbol && !hasValue {
+		return nil, false
+	}
+	return m, true
+}
+
+// decodeEnumObject returns the symbol of an enum value encoded as an object.
+// The symbol is preferred, and the value is used when the symbol is absent.
+// An error is returned if the symbol and the value do not refer to the same enum value.
+func decodeEnumObject(obj map[string]interface{}, f protoreflect.FieldDescriptor) (string, error) {
+	var symbol string
+	if data, ok := obj["symbol"]; ok && data != nil {
+		str, err := decodeStringLike(data, "string")
+		if err != nil {
+			return "", fmt.Errorf("symbol: %w", err)
+		}
+		symbol = str
+	}
+	data, ok := obj["value"]
+	if !ok || data == nil {
+		return symbol, nil
+	}
+	number, err := decodeIntLike(data, "int")
+	if err != nil {
+		return "", fmt.Errorf("value: %w", err)
+	}
+	value := f.Enum().Values().ByNumber(protoreflect.EnumNumber(number))
+	switch {
+	case symbol != "" && (value == nil || string(value.Name()) != symbol):
+		return "", fmt.Errorf("enum symbol %s does not match value %d", symbol, number)
+	case symbol != "":
+		return symbol, nil
+	case value == nil:
+		return "", fmt.Errorf("unknown enum value %d", number)
+	}
+	return string(value.Name()), nil
+}
+
+// enumNumber returns the number of an enum value encoded as an integer, optionally wrapped in a union.
+func enumNumber(data interface{}) (int64, bool) {
+	if m, ok := data.(map[string]interface{}); ok && len(m) == 1 {
+		for _, key := range []string{"int", "long"} {
+			if value, ok := m[key]; ok {
+				data = value
+			}
+		}
+	}
+	switch data.(type) {
+	case int, int32, int64, float64, json.Number:
+		number, err := decodeIntValue(data)
+		return number, err == nil
+	}
+	return 0, false
+}
+
+// unknownEnumValue returns the value that an unknown enum value of field f is decoded as, which is the
+// value of the symbol of SchemaOptions.EnumDefaults for the enum, or the zero value.
+func (d *decoder) unknownEnumValue(f protoreflect.FieldDescriptor, unknown string) protoreflect.Value {
+	if def, ok := d.opts.EnumDefaults[f.Enum().FullName()]; ok {
+		if v := f.Enum().Values().ByName(protoreflect.Name(def)); v != nil {
+			d.warn("unknown enum %s decoded as default %s", unknown, def)
+			return protoreflect.ValueOfEnum(v.Number())
+		}
+	}
+	d.warn("unknown enum %s decoded as zero value", unknown)
+	return protoreflect.ValueOfEnum(0)
+}
+
+// applyEnumDefaults sets the singular enum fields of msg that are absent from the record to the symbol of
+// SchemaOptions.EnumDefaults for their enum, when UseEnumDefaultOnAbsence is set.
+// present holds the fields of msg that are present in the record.
+func (d *decoder) applyEnumDefaults(msg protoreflect.Message, present map[protoreflect.FieldNumber]struct{}) {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.Get(i)
+		if f.Kind() != protoreflect.EnumKind || f.IsList() || f.IsMap() || f.ContainingOneof() != nil {
+			continue
+		}
+		if _, ok := present[f.Number()]; ok {
+			continue
+		}
+		def, ok := d.opts.EnumDefaults[f.Enum().FullName()]
+		if !ok {
+			continue
+		}
+		if v := f.Enum().Values().ByName(protoreflect.Name(def)); v != nil && v.Number() != 0 {
+			msg.Set(f, protoreflect.ValueOfEnum(v.Number()))
+		}
+	}
+}
+
+// applyAbsentEnumMembers sets the singular enum fields of msg that are null or absent from the record to the
+// member of AbsentEnumMember for their enum. nonNull holds the fields of msg that are non-null in the record.
+func (d *decoder) applyAbsentEnumMembers(
+	msg protoreflect.Message,
+	nonNull map[protoreflect.FieldNumber]struct{},
+) error {
+	fields := msg.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		f := fields.Get(i)
+		if f.Kind() != protoreflect.EnumKind || f.IsList() || f.IsMap() || f.ContainingOneof() != nil &&
+			!f.ContainingOneof().IsSynthetic() {
+			continue
+		}
+		if _, ok := nonNull[f.Number()]; ok {
+			continue
+		}
+		member, ok := d.opts.AbsentEnumMember[f.Enum().FullName()]
+		if !ok {
+			continue
+		}
+		v := f.Enum().Values().ByName(protoreflect.Name(member))
+		if v == nil {
+			return fmt.Errorf("field %s: absent enum member %s is not a value of %s", f.Name(), member, f.Enum().FullName())
+		}
+		msg.Set(f, protoreflect.ValueOfEnum(v.Number()))
+	}
+	return nil
+}
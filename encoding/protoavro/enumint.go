@@ -0,0 +1,90 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// isIntegerKind returns true if kind is a signed or unsigned integer kind.
+func isIntegerKind(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int32Kind,
+		protoreflect.Sfixed32Kind,
+		protoreflect.Sint32Kind,
+		protoreflect.Uint32Kind,
+		protoreflect.Fixed32Kind:
+		return true
+	}
+	return isInt64Kind(kind)
+}
+
+// enumIntSymbols returns the symbols of field if it is one of EnumIntFields.
+func (o SchemaOptions) enumIntSymbols(field protoreflect.FieldDescriptor) ([]string, bool, error) {
+	symbols, ok := o.EnumIntFields[string(field.FullName())]
+	if !ok {
+		return nil, false, nil
+	}
+	if !isIntegerKind(field.Kind()) {
+		return nil, false, fmt.Errorf("field %s: enum int field of kind %s", field.Name(), field.Kind())
+	}
+	return symbols, true, nil
+}
+
+func (s schemaInferrer) inferEnumIntSchema(field protoreflect.FieldDescriptor, symbols []string) avro.Schema {
+	if _, ok := s.seen[field.FullName()]; ok {
+		return avro.Reference(field.FullName())
+	}
+	s.seen[field.FullName()] = struct{}{}
+	return avro.Enum{
+		Type:      avro.EnumType,
+		Name:      string(field.Name()),
+		Namespace: namespace(field),
+		Symbols:   append([]string(nil), symbols...),
+	}
+}
+
+func (o MarshalOptions) encodeEnumInt(
+	field protoreflect.FieldDescriptor,
+	value protoreflect.Value,
+	symbols []string,
+) (interface{}, error) {
+	var i int64
+	switch field.Kind() {
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if value.Uint() >= uint64(len(symbols)) {
+			return nil, fmt.Errorf("field %s: value %d out of range of %d symbols", field.Name(), value.Uint(), len(symbols))
+		}
+		i = int64(value.Uint())
+	default:
+		if value.Int() < 0 || value.Int() >= int64(len(symbols)) {
+			return nil, fmt.Errorf("field %s: value %d out of range of %d symbols", field.Name(), value.Int(), len(symbols))
+		}
+		i = value.Int()
+	}
+	return o.unionValue(string(field.FullName()), symbols[i]), nil
+}
+
+func decodeEnumInt(data interface{}, f protoreflect.FieldDescriptor, symbols []string) (protoreflect.Value, error) {
+	symbol, err := decodeStringLike(data, string(f.FullName()))
+	if err != nil {
+		return protoreflect.Value{}, fmt.Errorf("field %s: %w", f.Name(), err)
+	}
+	for i, s := range symbols {
+		if s != symbol {
+			continue
+		}
+		switch f.Kind() {
+		case protoreflect.Int32Kind, protoreflect.Sfixed32Kind, protoreflect.Sint32Kind:
+			return protoreflect.ValueOfInt32(int32(i)), nil
+		case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+			return protoreflect.ValueOfUint32(uint32(i)), nil
+		case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			return protoreflect.ValueOfUint64(uint64(i)), nil
+		default:
+			return protoreflect.ValueOfInt64(int64(i)), nil
+		}
+	}
+	return protoreflect.Value{}, fmt.Errorf("field %s: unknown enum symbol %s", f.Name(), symbol)
+}
@@ -0,0 +1,53 @@
+package protoavro
+
+import (
+	"testing"
+
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_EnumIntFields(t *testing.T) {
+	opts := SchemaOptions{
+		EnumIntFields: map[string][]string{
+			"einride.avro.example.v1.ExampleGroup.age": {"INFANT", "CHILD", "ADULT"},
+		},
+	}
+	desc := (&examplev1.ExampleGroup{}).ProtoReflect().Descriptor()
+	t.Run("schema", func(t *testing.T) {
+		schema, err := opts.InferSchema(desc)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, avro.Nullable(avro.Enum{
+			Type:      avro.EnumType,
+			Name:      "age",
+			Namespace: "einride.avro.example.v1.ExampleGroup",
+			Symbols:   []string{"INFANT", "CHILD", "ADULT"},
+		}), schema.(avro.Union)[1].(avro.Record).Fields[2].Type)
+	})
+	t.Run("valid value", func(t *testing.T) {
+		schema, err := opts.InferSchema(desc)
+		assert.NilError(t, err)
+		native := assertRoundTrip(t, opts, schema, &examplev1.ExampleGroup{Name: "name", Age: 2})
+		record := native.(map[string]interface{})["einride.avro.example.v1.ExampleGroup"].(map[string]interface{})
+		assert.DeepEqual(t, map[string]interface{}{"einride.avro.example.v1.ExampleGroup.age": "ADULT"}, record["age"])
+	})
+	t.Run("out of range value", func(t *testing.T) {
+		_, err := opts.Encode(&examplev1.ExampleGroup{Age: 3})
+		assert.ErrorContains(t, err, "field age: value 3 out of range of 3 symbols")
+		_, err = opts.Encode(&examplev1.ExampleGroup{Age: -1})
+		assert.ErrorContains(t, err, "field age: value -1 out of range of 3 symbols")
+	})
+	t.Run("unknown symbol", func(t *testing.T) {
+		var got examplev1.ExampleGroup
+		err := UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{
+			"age": map[string]interface{}{"einride.avro.example.v1.ExampleGroup.age": "SENIOR"},
+		}, &got)
+		assert.ErrorContains(t, err, "field age: unknown enum symbol SENIOR")
+	})
+	t.Run("non-integer field", func(t *testing.T) {
+		opts := SchemaOptions{EnumIntFields: map[string][]string{"einride.avro.example.v1.ExampleGroup.name": {"A"}}}
+		_, err := opts.InferSchema(desc)
+		assert.ErrorContains(t, err, "field name: enum int field of kind string")
+	})
+}
@@ -0,0 +1,99 @@
+package protoavro
+
+import (
+	"fmt"
+	"strings"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Envelope describes a record that encoded messages are nested in, alongside metadata fields,
+// for consistent event schemas across message types.
+type Envelope struct {
+	// RecordName is the Avro full name of the envelope record.
+	RecordName string
+	// PayloadField is the name of the envelope field that holds the message.
+	PayloadField string
+	// ExtraFields are the metadata fields of the envelope, which precede the payload field.
+	// Their values are set from MarshalOptions.EnvelopeValues.
+	ExtraFields []avro.Field
+}
+
+// envelopeSchema returns the schema of the envelope record with payload as the schema of the message.
+func (e *Envelope) envelopeSchema(payload avro.Schema) (avro.Schema, error) {
+	if e.PayloadField == "" {
+		return nil, fmt.Errorf("envelope: missing payload field")
+	}
+	for _, field := range e.ExtraFields {
+		if field.Name == e.PayloadField {
+			return nil, fmt.Errorf("envelope: extra field '%s' has the name of the payload field", field.Name)
+		}
+	}
+	fields := make([]avro.Field, 0, len(e.ExtraFields)+1)
+	fields = append(fields, e.ExtraFields...)
+	fields = append(fields, avro.Field{Name: e.PayloadField, Type: payload})
+	return avro.Record{
+		Type:      avro.RecordType,
+		Name:      e.RecordName[strings.LastIndex(e.RecordName, ".")+1:],
+		Namespace: avro.NamespaceOf(e.RecordName),
+		Fields:    fields,
+	}, nil
+}
+
+// inferSchema returns the schema of messages of desc, nested in the Envelope when it is set.
+func (s schemaInferrer) inferSchema(desc protoreflect.MessageDescriptor) (avro.Schema, error) {
+	schema, err := s.inferMessageSchema(desc, 0)
+	if err != nil {
+		return nil, err
+	}
+	if s.opts.Envelope == nil {
+		return schema, nil
+	}
+	return s.opts.Envelope.envelopeSchema(schema)
+}
+
+// encodeEnvelope returns the envelope record with payload as the encoded message.
+func (o MarshalOptions) encodeEnvelope(payload interface{}) (interface{}, error) {
+	record := make(map[string]interface{}, len(o.Envelope.ExtraFields)+1)
+	for _, field := range o.Envelope.ExtraFields {
+		record[field.Name] = o.EnvelopeValues[field.Name]
+	}
+	for name := range o.EnvelopeValues {
+		if _, ok := record[name]; !ok {
+			return nil, fmt.Errorf("envelope: value of unknown extra field '%s'", name)
+		}
+	}
+	record[o.Envelope.PayloadField] = payload
+	return record, nil
+}
+
+// decodeEnvelope returns the payload of the envelope record in data.
+func (o *UnmarshalOptions) decodeEnvelope(data interface{}) (interface{}, error) {
+	record, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("envelope: expected record encoded as map[string]interface{}, got %T", data)
+	}
+	payload, ok := record[o.Envelope.PayloadField]
+	if !ok {
+		return nil, fmt.Errorf("envelope: missing payload field '%s'", o.Envelope.PayloadField)
+	}
+	return payload, nil
+}
+
+// unwrap returns the value of UnwrapKey if it is the only key of the record in data,
+// and not the name of a field of desc.
+func (o *UnmarshalOptions) unwrap(data interface{}, desc protoreflect.MessageDescriptor) interface{} {
+	record, ok := data.(map[string]interface{})
+	if !ok || len(record) != 1 {
+		return data
+	}
+	value, ok := record[o.UnwrapKey]
+	if !ok {
+		return data
+	}
+	if fd, err := o.findField(desc, o.UnwrapKey); fd != nil || err != nil {
+		return data
+	}
+	return value
+}
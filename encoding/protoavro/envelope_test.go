@@ -0,0 +1,117 @@
+package protoavro
+
+import (
+	"testing"
+
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_Envelope(t *testing.T) {
+	envelope := &Envelope{
+		RecordName:   "einride.events.v1.Event",
+		PayloadField: "payload",
+		ExtraFields: []avro.Field{
+			{Name: "event_id", Type: avro.String()},
+			{Name: "source", Type: avro.Nullable(avro.String())},
+		},
+	}
+	opts := SchemaOptions{Envelope: envelope}
+	msg := &library.Book{Name: "shelves/1/books/1", Title: "Harry Potter"}
+
+	t.Run("schema", func(t *testing.T) {
+		schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		book, err := InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, avro.Record{
+			Type:      avro.RecordType,
+			Name:      "Event",
+			Namespace: "einride.events.v1",
+			Fields: []avro.Field{
+				{Name: "event_id", Type: avro.String()},
+				{Name: "source", Type: avro.Nullable(avro.String())},
+				{Name: "payload", Type: book},
+			},
+		}, schema)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		marshal := MarshalOptions{
+			SchemaOptions:  opts,
+			EnvelopeValues: map[string]interface{}{"event_id": "1"},
+		}
+		codec, err := opts.newCodec(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		datum, err := marshal.Encode(msg)
+		assert.NilError(t, err)
+		record := datum.(map[string]interface{})
+		assert.Equal(t, "1", record["event_id"])
+		assert.Equal(t, nil, record["source"])
+		binary, err := codec.BinaryFromNative(nil, datum)
+		assert.NilError(t, err)
+		native, _, err := codec.NativeFromBinary(binary)
+		assert.NilError(t, err)
+		var got library.Book
+		assert.NilError(t, UnmarshalOptions{SchemaOptions: opts}.Unmarshal(native, &got))
+		assert.DeepEqual(t, msg, &got, protocmp.Transform())
+	})
+
+	t.Run("unknown extra field value", func(t *testing.T) {
+		_, err := MarshalOptions{
+			SchemaOptions:  opts,
+			EnvelopeValues: map[string]interface{}{"trace_id": "1"},
+		}.Encode(msg)
+		assert.ErrorContains(t, err, "envelope: value of unknown extra field 'trace_id'")
+	})
+
+	t.Run("missing payload", func(t *testing.T) {
+		var got library.Book
+		err := UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{"event_id": "1"}, &got)
+		assert.ErrorContains(t, err, "envelope: missing payload field 'payload'")
+	})
+
+	t.Run("extra field named like payload", func(t *testing.T) {
+		_, err := SchemaOptions{Envelope: &Envelope{
+			RecordName:   "Event",
+			PayloadField: "payload",
+			ExtraFields:  []avro.Field{{Name: "payload", Type: avro.String()}},
+		}}.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.ErrorContains(t, err, "envelope: extra field 'payload' has the name of the payload field")
+	})
+}
+
+func TestUnmarshalOptions_UnwrapKey(t *testing.T) {
+	book := map[string]interface{}{
+		"google.example.library.v1.Book": map[string]interface{}{
+			"title": map[string]interface{}{"string": "Harry Potter"},
+		},
+	}
+	opts := UnmarshalOptions{UnwrapKey: "value"}
+	t.Run("wrapped", func(t *testing.T) {
+		var got library.Book
+		assert.NilError(t, opts.Unmarshal(map[string]interface{}{"value": book}, &got))
+		assert.Equal(t, "Harry Potter", got.GetTitle())
+	})
+	t.Run("unwrapped", func(t *testing.T) {
+		var got library.Book
+		assert.NilError(t, opts.Unmarshal(book, &got))
+		assert.Equal(t, "Harry Potter", got.GetTitle())
+	})
+	t.Run("not the only key", func(t *testing.T) {
+		var got library.Book
+		err := opts.Unmarshal(map[string]interface{}{"value": book, "key": "k"}, &got)
+		assert.ErrorContains(t, err, "unexpected field")
+	})
+	t.Run("field with the name of the key", func(t *testing.T) {
+		var got examplev1.ExampleRedact
+		opts := UnmarshalOptions{UnwrapKey: "name"}
+		assert.NilError(t, opts.Unmarshal(map[string]interface{}{
+			"name": map[string]interface{}{"string": "n"},
+		}, &got))
+		assert.Equal(t, "n", got.GetName())
+	})
+}
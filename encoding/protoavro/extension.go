@@ -0,0 +1,42 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ExtensionResolver resolves the extensions of messages, such as *protoregistry.Types.
+type ExtensionResolver interface {
+	RangeExtensionsByMessage(message protoreflect.FullName, f func(protoreflect.ExtensionType) bool)
+}
+
+// findExtension returns the descriptor of the extension of desc that is named name, or nil if desc has no
+// extension ranges or no extension matches. Extensions are matched by their name without the package,
+// since Avro field names can not contain dots, and names of more than one extension are rejected.
+func (o *UnmarshalOptions) findExtension(
+	desc protoreflect.MessageDescriptor,
+	name string,
+) (protoreflect.FieldDescriptor, error) {
+	if desc.ExtensionRanges().Len() == 0 {
+		return nil, nil
+	}
+	var match protoreflect.ExtensionTypeDescriptor
+	var err error
+	o.ExtensionResolver.RangeExtensionsByMessage(desc.FullName(), func(xt protoreflect.ExtensionType) bool {
+		xd := xt.TypeDescriptor()
+		if string(xd.Name()) != name {
+			return true
+		}
+		if match != nil {
+			err = fmt.Errorf("ambiguous field name %s: matches extensions %s and %s", name, match.FullName(), xd.FullName())
+			return false
+		}
+		match = xd
+		return true
+	})
+	if err != nil || match == nil {
+		return nil, err
+	}
+	return match, nil
+}
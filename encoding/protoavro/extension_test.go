@@ -0,0 +1,45 @@
+package protoavro
+
+import (
+	"testing"
+
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnmarshalOptions_ExtensionResolver(t *testing.T) {
+	data := map[string]interface{}{
+		"name":     map[string]interface{}{"string": "a"},
+		"note":     map[string]interface{}{"string": "b"},
+		"priority": int64(1),
+	}
+	t.Run("extensions by name", func(t *testing.T) {
+		var got examplev1.ExampleExtendable
+		assert.NilError(t, UnmarshalOptions{ExtensionResolver: protoregistry.GlobalTypes}.Unmarshal(data, &got))
+		expected := &examplev1.ExampleExtendable{Name: proto.String("a")}
+		proto.SetExtension(expected, examplev1.E_Note, "b")
+		proto.SetExtension(expected, examplev1.E_Priority, int64(1))
+		assert.DeepEqual(t, expected, &got, protocmp.Transform())
+	})
+	t.Run("unknown extension", func(t *testing.T) {
+		var got examplev1.ExampleExtendable
+		err := UnmarshalOptions{ExtensionResolver: protoregistry.GlobalTypes}.Unmarshal(
+			map[string]interface{}{"other": "c"}, &got,
+		)
+		assert.ErrorContains(t, err, "unexpected field other")
+	})
+	t.Run("message without extension ranges", func(t *testing.T) {
+		var got examplev1.ExampleProto2
+		err := UnmarshalOptions{ExtensionResolver: protoregistry.GlobalTypes}.Unmarshal(
+			map[string]interface{}{"note": "c"}, &got,
+		)
+		assert.ErrorContains(t, err, "unexpected field note")
+	})
+	t.Run("without resolver", func(t *testing.T) {
+		var got examplev1.ExampleExtendable
+		assert.ErrorContains(t, UnmarshalOptions{}.Unmarshal(data, &got), "unexpected field")
+	})
+}
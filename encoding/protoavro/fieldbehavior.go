@@ -0,0 +1,118 @@
+package protoavro
+
+import (
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// isRequiredField returns true if field has a non-nullable schema, because UseFieldBehavior is set
+// and the field is annotated with the REQUIRED field behavior, because NonNullableProto2Required is set
+// and the field is a proto2 required field, because of ScalarPresence, unless AllScalarsNullable applies,
+// or because NonNullableMapValues is set and the field is the value of a map entry.
+func (o SchemaOptions) isRequiredField(field protoreflect.FieldDescriptor) bool {
+	if o.isNonNullableMapValue(field) {
+		return true
+	}
+	if !o.UseFieldBehavior && !o.NonNullableProto2Required && o.ScalarPresence == ScalarPresenceNullable {
+		return false
+	}
+	if encrypted, _ := o.isEncryptedField(field); encrypted {
+		return false
+	}
+	if o.AllScalarsNullable && field.Message() == nil && !field.IsList() {
+		return false
+	}
+	if o.isNonNullableScalar(field) {
+		return true
+	}
+	if !o.UseFieldBehavior && !o.NonNullableProto2Required || field.ContainingOneof() != nil {
+		return false
+	}
+	if field.Message() != nil && !field.IsList() && !field.IsMap() && o.isExpandedWKT(field.Message().FullName()) {
+		return false
+	}
+	if o.NonNullableProto2Required && field.Cardinality() == protoreflect.Required {
+		return true
+	}
+	if !o.UseFieldBehavior {
+		return false
+	}
+	behaviors, ok := proto.GetExtension(field.Options(), annotations.E_FieldBehavior).([]annotations.FieldBehavior)
+	if !ok {
+		return false
+	}
+	for _, behavior := range behaviors {
+		if behavior == annotations.FieldBehavior_REQUIRED {
+			return true
+		}
+	}
+	return false
+}
+
+// isNonNullableMapValue returns true if field is the value of a map entry that NonNullableMapValues gives
+// a non-nullable schema. Values of wrapper types and other expanded well-known types stay nullable.
+func (o SchemaOptions) isNonNullableMapValue(field protoreflect.FieldDescriptor) bool {
+	if !o.NonNullableMapValues || !field.ContainingMessage().IsMapEntry() || field.Name() != "value" {
+		return false
+	}
+	return field.Message() == nil || !o.isExpandedWKT(field.Message().FullName())
+}
+
+// ScalarPresence determines how the presence of singular scalar and enum fields maps to the nullability
+// of their schemas.
+type ScalarPresence int
+
+const (
+	// ScalarPresenceNullable gives all scalar fields nullable schemas, regardless of presence.
+	ScalarPresenceNullable ScalarPresence = iota
+	// ScalarPresenceOptionalAsUnion gives fields with explicit presence, such as proto3 optional fields,
+	// nullable schemas, and fields with implicit presence non-nullable schemas, so that schemas reflect
+	// which fields can be unset.
+	ScalarPresenceOptionalAsUnion
+	// ScalarPresenceNonNullable gives all scalar fields non-nullable schemas, including fields with explicit
+	// presence. Unset fields are encoded as their default values, and decoded as set.
+	ScalarPresenceNonNullable
+)
+
+// isNonNullableScalar returns true if field is a singular scalar or enum field that ScalarPresence gives
+// a non-nullable schema. Members of oneofs other than the synthetic oneofs of proto3 optional fields
+// stay nullable.
+func (o SchemaOptions) isNonNullableScalar(field protoreflect.FieldDescriptor) bool {
+	if o.ScalarPresence == ScalarPresenceNullable || field.Message() != nil || field.IsList() {
+		return false
+	}
+	if oneof := field.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+		return false
+	}
+	return o.ScalarPresence == ScalarPresenceNonNullable || !field.HasPresence()
+}
+
+// nonNullable returns schema without the null branch of a nullable union.
+func nonNullable(schema avro.Schema) avro.Schema {
+	union, ok := schema.(avro.Union)
+	if !ok {
+		return schema
+	}
+	members := make(avro.Union, 0, len(union))
+	for _, member := range union {
+		if member != avro.Null() {
+			members = append(members, member)
+		}
+	}
+	if len(members) == 1 {
+		return members[0]
+	}
+	return members
+}
+
+// unionMember returns the value of a union encoded value, for fields with non-nullable schemas.
+func unionMember(value interface{}) interface{} {
+	if union, ok := value.(map[string]interface{}); ok && len(union) == 1 {
+		for _, member := range union {
+			return member
+		}
+	}
+	return value
+}
@@ -0,0 +1,295 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_UseFieldBehavior(t *testing.T) {
+	nested := avro.Record{
+		Type:      avro.RecordType,
+		Name:      "Nested",
+		Namespace: "einride.avro.example.v1.ExampleFieldBehavior",
+		Fields: []avro.Field{
+			{Name: "value", Type: avro.Nullable(avro.String())},
+		},
+	}
+	opts := SchemaOptions{UseFieldBehavior: true}
+	schema, err := opts.InferSchema((&examplev1.ExampleFieldBehavior{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Nullable(avro.Record{
+		Type:      avro.RecordType,
+		Name:      "ExampleFieldBehavior",
+		Namespace: "einride.avro.example.v1",
+		Fields: []avro.Field{
+			{Name: "required_string", Type: avro.String()},
+			{Name: "optional_string", Type: avro.Nullable(avro.String())},
+			{Name: "string", Type: avro.Nullable(avro.String())},
+			{Name: "required_nested", Type: nested},
+			{
+				Name: "required_list",
+				Type: avro.Array{Type: avro.ArrayType, Items: avro.Nullable(avro.String())},
+			},
+			{Name: "output_only_string", Type: avro.Nullable(avro.String())},
+		},
+	}), schema)
+
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	codec, err := goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+	for _, tt := range []struct {
+		name     string
+		msg      *examplev1.ExampleFieldBehavior
+		expected map[string]interface{}
+	}{
+		{
+			name: "set",
+			msg: &examplev1.ExampleFieldBehavior{
+				RequiredString: "a",
+				OptionalString: "b",
+				RequiredNested: &examplev1.ExampleFieldBehavior_Nested{Value: "c"},
+				RequiredList:   []string{"d"},
+			},
+			expected: map[string]interface{}{
+				"required_string":    "a",
+				"optional_string":    map[string]interface{}{"string": "b"},
+				"string":             map[string]interface{}{"string": ""},
+				"required_nested":    map[string]interface{}{"value": map[string]interface{}{"string": "c"}},
+				"required_list":      []interface{}{map[string]interface{}{"string": "d"}},
+				"output_only_string": map[string]interface{}{"string": ""},
+			},
+		},
+		{
+			name: "unset",
+			msg:  &examplev1.ExampleFieldBehavior{},
+			expected: map[string]interface{}{
+				"required_string":    "",
+				"optional_string":    map[string]interface{}{"string": ""},
+				"string":             map[string]interface{}{"string": ""},
+				"required_nested":    map[string]interface{}{"value": map[string]interface{}{"string": ""}},
+				"required_list":      []interface{}{},
+				"output_only_string": map[string]interface{}{"string": ""},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MarshalOptions{SchemaOptions: opts}.Encode(tt.msg)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, map[string]interface{}{
+				"einride.avro.example.v1.ExampleFieldBehavior": tt.expected,
+			}, got)
+			binary, err := codec.BinaryFromNative(nil, got)
+			assert.NilError(t, err)
+			native, _, err := codec.NativeFromBinary(binary)
+			assert.NilError(t, err)
+			var decoded examplev1.ExampleFieldBehavior
+			assert.NilError(t, UnmarshalOptions{SchemaOptions: opts}.Unmarshal(native, &decoded))
+			expected := tt.msg
+			if expected.RequiredNested == nil {
+				expected = &examplev1.ExampleFieldBehavior{RequiredNested: &examplev1.ExampleFieldBehavior_Nested{}}
+			}
+			assert.DeepEqual(t, expected, &decoded, protocmp.Transform())
+		})
+	}
+}
+
+func TestSchemaOptions_UseFieldBehavior_Disabled(t *testing.T) {
+	schema, err := SchemaOptions{}.InferSchema((&examplev1.ExampleFieldBehavior{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	record, ok := schema.(avro.Union)[1].(avro.Record)
+	assert.Assert(t, ok)
+	for _, field := range record.Fields {
+		_, ok := field.Type.(avro.Union)
+		assert.Assert(t, ok, field.Name)
+	}
+}
+
+func TestSchemaOptions_AllScalarsNullable(t *testing.T) {
+	opts := SchemaOptions{UseFieldBehavior: true, AllScalarsNullable: true}
+	schema, err := opts.InferSchema((&examplev1.ExampleFieldBehavior{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	record, ok := schema.(avro.Union)[1].(avro.Record)
+	assert.Assert(t, ok)
+	nullable := map[string]bool{}
+	for _, field := range record.Fields {
+		_, nullable[field.Name] = field.Type.(avro.Union)
+	}
+	assert.DeepEqual(t, map[string]bool{
+		"required_string":    true,
+		"optional_string":    true,
+		"string":             true,
+		"required_nested":    false,
+		"required_list":      false,
+		"output_only_string": true,
+	}, nullable)
+
+	msg := &examplev1.ExampleFieldBehavior{
+		RequiredString: "a",
+		RequiredNested: &examplev1.ExampleFieldBehavior_Nested{},
+	}
+	native := assertRoundTrip(t, opts, schema, msg)
+	fields := native.(map[string]interface{})["einride.avro.example.v1.ExampleFieldBehavior"].(map[string]interface{})
+	assert.DeepEqual(t, map[string]interface{}{"string": "a"}, fields["required_string"])
+
+	t.Run("null decodes to zero value", func(t *testing.T) {
+		var got examplev1.ExampleFieldBehavior
+		err := UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{
+			"required_string": nil,
+			"string":          nil,
+		}, &got)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, &examplev1.ExampleFieldBehavior{}, &got, protocmp.Transform())
+	})
+}
+
+func TestSchemaOptions_NonNullableProto2Required(t *testing.T) {
+	opts := SchemaOptions{NonNullableProto2Required: true}
+	schema, err := opts.InferSchema((&examplev1.ExampleProto2{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Nullable(avro.Record{
+		Type:      avro.RecordType,
+		Name:      "ExampleProto2",
+		Namespace: "einride.avro.example.v1",
+		Fields: []avro.Field{
+			{Name: "name", Type: avro.String()},
+			{Name: "description", Type: avro.Nullable(avro.String())},
+			{Name: "count", Type: avro.Long()},
+			{Name: "nested", Type: avro.Nullable(avro.Record{
+				Type:      avro.RecordType,
+				Name:      "Nested",
+				Namespace: "einride.avro.example.v1.ExampleProto2",
+				Fields: []avro.Field{
+					{Name: "value", Type: avro.Nullable(avro.String())},
+				},
+			})},
+			{
+				Name: "required_nested",
+				Type: avro.Reference("einride.avro.example.v1.ExampleProto2.Nested"),
+			},
+		},
+	}), schema)
+
+	defaultSchema, err := SchemaOptions{}.InferSchema((&examplev1.ExampleProto2{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	defaultRecord, ok := defaultSchema.(avro.Union)[1].(avro.Record)
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, avro.Nullable(avro.String()), defaultRecord.Fields[0].Type)
+
+	msg := &examplev1.ExampleProto2{
+		Name:           proto.String("name"),
+		Description:    proto.String("description"),
+		Count:          proto.Int64(1),
+		RequiredNested: &examplev1.ExampleProto2_Nested{Value: proto.String("value")},
+	}
+	assertRoundTrip(t, opts, schema, msg)
+
+	t.Run("missing required", func(t *testing.T) {
+		unmarshalOpts := UnmarshalOptions{SchemaOptions: opts, EnforceProto2Required: true}
+		err := unmarshalOpts.Unmarshal(map[string]interface{}{
+			"name":  "name",
+			"count": int64(1),
+		}, &examplev1.ExampleProto2{})
+		assert.ErrorContains(t, err, "missing required fields: required_nested")
+	})
+}
+
+func TestSchemaOptions_ScalarPresence(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		presence ScalarPresence
+		expected map[string]bool
+	}{
+		{
+			name:     "nullable",
+			presence: ScalarPresenceNullable,
+			expected: map[string]bool{
+				"optional_enum":   true,
+				"enum_value":      true,
+				"optional_string": true,
+				"optional_int64":  true,
+				"optional_nested": true,
+				"nested":          true,
+			},
+		},
+		{
+			name:     "optional as union",
+			presence: ScalarPresenceOptionalAsUnion,
+			expected: map[string]bool{
+				"optional_enum":   true,
+				"enum_value":      false,
+				"optional_string": true,
+				"optional_int64":  true,
+				"optional_nested": true,
+				"nested":          true,
+			},
+		},
+		{
+			name:     "non-nullable",
+			presence: ScalarPresenceNonNullable,
+			expected: map[string]bool{
+				"optional_enum":   false,
+				"enum_value":      false,
+				"optional_string": false,
+				"optional_int64":  false,
+				"optional_nested": true,
+				"nested":          true,
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := SchemaOptions{ScalarPresence: tt.presence}
+			schema, err := opts.InferSchema((&examplev1.ExampleOptional{}).ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			nullable := map[string]bool{}
+			for _, field := range schema.(avro.Union)[1].(avro.Record).Fields {
+				_, nullable[field.Name] = field.Type.(avro.Union)
+			}
+			assert.DeepEqual(t, tt.expected, nullable)
+			assertRoundTrip(t, opts, schema, &examplev1.ExampleOptional{
+				OptionalEnum:   examplev1.ExampleOptional_ENUM_VALUE1.Enum(),
+				EnumValue:      examplev1.ExampleOptional_ENUM_VALUE2,
+				OptionalString: proto.String("a"),
+				OptionalInt64:  proto.Int64(1),
+				Nested:         &examplev1.ExampleOptional_Nested{Value: "b"},
+			})
+		})
+	}
+	t.Run("unset optional fields", func(t *testing.T) {
+		opts := SchemaOptions{ScalarPresence: ScalarPresenceOptionalAsUnion}
+		schema, err := opts.InferSchema((&examplev1.ExampleOptional{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		native := assertRoundTrip(t, opts, schema, &examplev1.ExampleOptional{})
+		fields := native.(map[string]interface{})["einride.avro.example.v1.ExampleOptional"].(map[string]interface{})
+		assert.Assert(t, fields["optional_string"] == nil)
+		assert.Equal(t, "ENUM_UNSPECIFIED", fields["enum_value"])
+	})
+	t.Run("non-nullable encodes unset optional fields as defaults", func(t *testing.T) {
+		opts := SchemaOptions{ScalarPresence: ScalarPresenceNonNullable}
+		schema, err := opts.InferSchema((&examplev1.ExampleOptional{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		codec, err := goavro.NewCodec(string(schemaBytes))
+		assert.NilError(t, err)
+		datum, err := opts.Encode(&examplev1.ExampleOptional{})
+		assert.NilError(t, err)
+		binary, err := codec.BinaryFromNative(nil, datum)
+		assert.NilError(t, err)
+		native, _, err := codec.NativeFromBinary(binary)
+		assert.NilError(t, err)
+		var got examplev1.ExampleOptional
+		assert.NilError(t, UnmarshalOptions{SchemaOptions: opts}.Unmarshal(native, &got))
+		assert.Equal(t, "", got.GetOptionalString())
+		assert.Assert(t, got.OptionalString != nil)
+	})
+}
@@ -0,0 +1,66 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+)
+
+// writerFieldOrder returns the names of the fields of the top-level record of the writer schema, in order.
+// The record may be wrapped in a union, as in nullable schemas.
+func writerFieldOrder(schema json.RawMessage) ([]string, error) {
+	if len(schema) == 0 {
+		return nil, fmt.Errorf("capture field order: no writer schema")
+	}
+	var parsed interface{}
+	if err := json.Unmarshal(schema, &parsed); err != nil {
+		return nil, fmt.Errorf("capture field order: parse writer schema: %w", err)
+	}
+	if union, ok := parsed.([]interface{}); ok {
+		for _, member := range union {
+			if record, ok := member.(map[string]interface{}); ok && record["type"] == string(avro.RecordType) {
+				parsed = record
+				break
+			}
+		}
+	}
+	record, ok := parsed.(map[string]interface{})
+	if !ok || record["type"] != string(avro.RecordType) {
+		return nil, fmt.Errorf("capture field order: writer schema is not a record")
+	}
+	fields, _ := record["fields"].([]interface{})
+	order := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if field, ok := field.(map[string]interface{}); ok {
+			if name, ok := field["name"].(string); ok {
+				order = append(order, name)
+			}
+		}
+	}
+	return order, nil
+}
+
+// orderFields returns the fields of the top-level record in the order of FieldOrder.
+// Fields that are not listed follow in declaration order, and listed names without fields are ignored.
+func (o SchemaOptions) orderFields(fields []avro.Field) []avro.Field {
+	if len(o.FieldOrder) == 0 {
+		return fields
+	}
+	ordered := make([]avro.Field, 0, len(fields))
+	placed := make(map[string]struct{}, len(fields))
+	for _, name := range o.FieldOrder {
+		for _, field := range fields {
+			if _, ok := placed[field.Name]; !ok && field.Name == name {
+				ordered = append(ordered, field)
+				placed[name] = struct{}{}
+			}
+		}
+	}
+	for _, field := range fields {
+		if _, ok := placed[field.Name]; !ok {
+			ordered = append(ordered, field)
+		}
+	}
+	return ordered
+}
@@ -0,0 +1,85 @@
+package protoavro
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnmarshalOptions_CaptureFieldOrder(t *testing.T) {
+	const writerSchema = `{
+  "type": "record",
+  "name": "Book",
+  "namespace": "google.example.library.v1",
+  "fields": [
+    {"name": "title", "type": ["null", "string"]},
+    {"name": "read", "type": ["null", "boolean"]},
+    {"name": "name", "type": ["null", "string"]},
+    {"name": "author", "type": ["null", "string"]}
+  ]
+}`
+	writerCodec, err := goavro.NewCodec(writerSchema)
+	assert.NilError(t, err)
+	expected := &library.Book{Name: "shelves/1/books/1", Author: "J. K. Rowling", Title: "Harry Potter", Read: true}
+	source, err := writerCodec.BinaryFromNative(nil, map[string]interface{}{
+		"title":  goavro.Union("string", expected.Title),
+		"read":   goavro.Union("boolean", expected.Read),
+		"name":   goavro.Union("string", expected.Name),
+		"author": goavro.Union("string", expected.Author),
+	})
+	assert.NilError(t, err)
+	native, _, err := writerCodec.NativeFromBinary(source)
+	assert.NilError(t, err)
+
+	var order []string
+	opts := UnmarshalOptions{WriterSchema: json.RawMessage(writerSchema), CaptureFieldOrder: &order}
+	var got library.Book
+	assert.NilError(t, opts.Unmarshal(native, &got))
+	assert.DeepEqual(t, expected, &got, protocmp.Transform())
+	assert.DeepEqual(t, []string{"title", "read", "name", "author"}, order)
+
+	// re-encode with the captured order.
+	schemaOpts := SchemaOptions{FieldOrder: order, OmitRootElement: true}
+	schema, err := schemaOpts.InferSchema(got.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	record, ok := schema.(avro.Record)
+	assert.Assert(t, ok)
+	names := make([]string, 0, len(record.Fields))
+	for _, field := range record.Fields {
+		names = append(names, field.Name)
+	}
+	assert.DeepEqual(t, order, names)
+	codec, err := schemaOpts.newCodec(got.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	datum, err := MarshalOptions{SchemaOptions: schemaOpts}.Encode(&got)
+	assert.NilError(t, err)
+	reencoded, err := codec.BinaryFromNative(nil, datum)
+	assert.NilError(t, err)
+	assert.Assert(t, bytes.Equal(source, reencoded))
+
+	t.Run("unmarshaler", func(t *testing.T) {
+		var file bytes.Buffer
+		w, err := goavro.NewOCFWriter(goavro.OCFConfig{W: &file, Codec: writerCodec})
+		assert.NilError(t, err)
+		assert.NilError(t, w.Append([]interface{}{native}))
+		var order []string
+		u, err := UnmarshalOptions{CaptureFieldOrder: &order}.NewUnmarshaler(&file)
+		assert.NilError(t, err)
+		assert.Assert(t, u.Scan())
+		var got library.Book
+		assert.NilError(t, u.Unmarshal(&got))
+		assert.DeepEqual(t, []string{"title", "read", "name", "author"}, order)
+	})
+
+	t.Run("no writer schema", func(t *testing.T) {
+		var order []string
+		err := UnmarshalOptions{CaptureFieldOrder: &order}.Unmarshal(native, &library.Book{})
+		assert.ErrorContains(t, err, "capture field order: no writer schema")
+	})
+}
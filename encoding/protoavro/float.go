@@ -0,0 +1,44 @@
+package protoavro
+
+import (
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// FloatOverflowPolicy determines how double values that exceed the range of float32 are decoded into float fields.
+type FloatOverflowPolicy int
+
+const (
+	// FloatOverflowAllow converts values that exceed the range of float32 to infinity.
+	FloatOverflowAllow FloatOverflowPolicy = iota
+	// FloatOverflowError rejects values that exceed the range of float32.
+	FloatOverflowError
+	// FloatOverflowSaturate converts values that exceed the range of float32 to the largest float32 of the same sign.
+	FloatOverflowSaturate
+)
+
+// narrowFloat returns the float32 value of v for float field f, according to UnmarshalOptions.FloatOverflowPolicy.
+// Infinities and NaN are not affected by the policy. Values within range that are rounded are rejected
+// with UnmarshalOptions.FailOnPrecisionLoss.
+func (d *decoder) narrowFloat(f protoreflect.FieldDescriptor, v float64) (protoreflect.Value, error) {
+	if math.IsInf(v, 0) || math.Abs(v) <= math.MaxFloat32 {
+		if d.opts.FailOnPrecisionLoss && float64(float32(v)) != v && !math.IsNaN(v) {
+			return protoreflect.Value{}, fmt.Errorf("field %s: precision loss: %v rounded to float", f.Name(), v)
+		}
+		return protoreflect.ValueOfFloat32(float32(v)), nil
+	}
+	switch d.opts.FloatOverflowPolicy {
+	case FloatOverflowError:
+		return protoreflect.Value{}, fmt.Errorf("field %s: value %v overflows float", f.Name(), v)
+	case FloatOverflowSaturate:
+		return protoreflect.ValueOfFloat32(float32(math.Copysign(math.MaxFloat32, v))), nil
+	}
+	return protoreflect.ValueOfFloat32(float32(v)), nil
+}
+
+// isSpecialFloat returns true if MarshalOptions.SpecialFloatAsNull is set and f is NaN or infinite.
+func (o MarshalOptions) isSpecialFloat(f float64) bool {
+	return o.SpecialFloatAsNull && (math.IsNaN(f) || math.IsInf(f, 0))
+}
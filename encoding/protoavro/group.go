@@ -0,0 +1,165 @@
+package protoavro
+
+import (
+	"fmt"
+	"strings"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fieldGroup is a group of fields of a message with a prefix of SchemaOptions.GroupByPrefix.
+type fieldGroup struct {
+	// prefix is the prefix of the names of the grouped fields.
+	prefix string
+	// name is the name of the record field that holds the group.
+	name string
+	// recordFullName is the Avro full name of the record of the group.
+	recordFullName string
+	fields         []protoreflect.FieldDescriptor
+}
+
+// fieldGroups returns the groups of the fields of message, in the order of their first field.
+// Fields are grouped by the longest matching prefix.
+func (o SchemaOptions) fieldGroups(message protoreflect.MessageDescriptor) ([]*fieldGroup, error) {
+	if len(o.GroupByPrefix) == 0 {
+		return nil, nil
+	}
+	var groups []*fieldGroup
+	byPrefix := make(map[string]*fieldGroup)
+	for i := 0; i < message.Fields().Len(); i++ {
+		field := message.Fields().Get(i)
+		var prefix string
+		for p := range o.GroupByPrefix {
+			if len(p) > len(prefix) && len(field.Name()) > len(p) && strings.HasPrefix(string(field.Name()), p) {
+				prefix = p
+			}
+		}
+		if prefix == "" {
+			continue
+		}
+		group, ok := byPrefix[prefix]
+		if !ok {
+			group = &fieldGroup{
+				prefix:         prefix,
+				name:           strings.TrimSuffix(prefix, "_"),
+				recordFullName: string(message.FullName()) + "." + o.GroupByPrefix[prefix],
+			}
+			if other := message.Fields().ByName(protoreflect.Name(group.name)); other != nil {
+				return nil, fmt.Errorf("field %s: conflicts with the group of prefix %s", other.Name(), prefix)
+			}
+			byPrefix[prefix] = group
+			groups = append(groups, group)
+		}
+		group.fields = append(group.fields, field)
+	}
+	return groups, nil
+}
+
+// groupOf returns the group of field, or nil if field is not grouped.
+func groupOf(groups []*fieldGroup, field string) *fieldGroup {
+	for _, group := range groups {
+		for _, fd := range group.fields {
+			if string(fd.Name()) == field {
+				return group
+			}
+		}
+	}
+	return nil
+}
+
+// groupFieldSchemas returns the schemas of the fields of message with grouped fields nested in records,
+// which take the place of the first field of the group.
+func (o SchemaOptions) groupFieldSchemas(
+	message protoreflect.MessageDescriptor,
+	fields []avro.Field,
+) ([]avro.Field, error) {
+	groups, err := o.fieldGroups(message)
+	if err != nil || len(groups) == 0 {
+		return fields, err
+	}
+	records := make(map[*fieldGroup]int, len(groups))
+	result := make([]avro.Field, 0, len(fields))
+	for _, field := range fields {
+		group := groupOf(groups, field.Name)
+		if group == nil {
+			result = append(result, field)
+			continue
+		}
+		i, ok := records[group]
+		if !ok {
+			i = len(result)
+			records[group] = i
+			result = append(result, avro.Field{
+				Name: group.name,
+				Type: avro.Record{
+					Type:      avro.RecordType,
+					Name:      o.GroupByPrefix[group.prefix],
+					Namespace: string(message.FullName()),
+				},
+			})
+		}
+		record := result[i].Type.(avro.Record)
+		field.Name = strings.TrimPrefix(field.Name, group.prefix)
+		record.Fields = append(record.Fields, field)
+		result[i].Type = record
+	}
+	for _, i := range records {
+		result[i].Type = avro.Nullable(result[i].Type)
+	}
+	return result, nil
+}
+
+// groupRecord nests the values of grouped fields in the encoded record of message.
+func (o MarshalOptions) groupRecord(message protoreflect.MessageDescriptor, record map[string]interface{}) error {
+	groups, err := o.fieldGroups(message)
+	if err != nil {
+		return err
+	}
+	for _, group := range groups {
+		nested := make(map[string]interface{}, len(group.fields))
+		for _, field := range group.fields {
+			name := string(field.Name())
+			nested[strings.TrimPrefix(name, group.prefix)] = record[name]
+			delete(record, name)
+		}
+		record[group.name] = o.unionValue(group.recordFullName, nested)
+	}
+	return nil
+}
+
+// flattenGroups returns record with the values of nested groups moved back to the grouped fields.
+func (o *UnmarshalOptions) flattenGroups(
+	message protoreflect.MessageDescriptor,
+	record map[string]interface{},
+) (map[string]interface{}, error) {
+	groups, err := o.fieldGroups(message)
+	if err != nil || len(groups) == 0 {
+		return record, err
+	}
+	flat := make(map[string]interface{}, len(record))
+	for name, value := range record {
+		flat[name] = value
+	}
+	for _, group := range groups {
+		data, ok := flat[group.name]
+		if !ok {
+			continue
+		}
+		delete(flat, group.name)
+		if data == nil {
+			continue
+		}
+		nested, ok := data.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("field %s: expected record encoded as map[string]interface{}, got %T", group.name, data)
+		}
+		if unwrapped, ok := nested[group.recordFullName].(map[string]interface{}); ok && len(nested) == 1 {
+			nested = unwrapped
+		}
+		for name, value := range nested {
+			flat[group.prefix+name] = value
+		}
+	}
+	return flat, nil
+}
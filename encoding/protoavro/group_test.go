@@ -0,0 +1,70 @@
+package protoavro
+
+import (
+	"testing"
+
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_GroupByPrefix(t *testing.T) {
+	opts := SchemaOptions{GroupByPrefix: map[string]string{"address_": "Address"}}
+	msg := &examplev1.ExampleGroup{
+		Name:          "name",
+		AddressStreet: "street",
+		Age:           42,
+		AddressCity:   "city",
+	}
+	t.Run("schema", func(t *testing.T) {
+		schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, avro.Nullable(avro.Record{
+			Type:      avro.RecordType,
+			Name:      "ExampleGroup",
+			Namespace: "einride.avro.example.v1",
+			Fields: []avro.Field{
+				{Name: "name", Type: avro.Nullable(avro.String())},
+				{
+					Name: "address",
+					Type: avro.Nullable(avro.Record{
+						Type:      avro.RecordType,
+						Name:      "Address",
+						Namespace: "einride.avro.example.v1.ExampleGroup",
+						Fields: []avro.Field{
+							{Name: "street", Type: avro.Nullable(avro.String())},
+							{Name: "city", Type: avro.Nullable(avro.String())},
+						},
+					}),
+				},
+				{Name: "age", Type: avro.Nullable(avro.Long())},
+			},
+		}), schema)
+	})
+	t.Run("round trip", func(t *testing.T) {
+		schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		native := assertRoundTrip(t, opts, schema, msg)
+		record := native.(map[string]interface{})["einride.avro.example.v1.ExampleGroup"].(map[string]interface{})
+		assert.DeepEqual(t, map[string]interface{}{
+			"einride.avro.example.v1.ExampleGroup.Address": map[string]interface{}{
+				"street": map[string]interface{}{"string": "street"},
+				"city":   map[string]interface{}{"string": "city"},
+			},
+		}, record["address"])
+	})
+	t.Run("null group", func(t *testing.T) {
+		var got examplev1.ExampleGroup
+		assert.NilError(t, UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{
+			"name":    map[string]interface{}{"string": "name"},
+			"address": nil,
+		}, &got))
+		assert.Equal(t, "name", got.GetName())
+		assert.Equal(t, "", got.GetAddressStreet())
+	})
+	t.Run("conflict", func(t *testing.T) {
+		opts := SchemaOptions{GroupByPrefix: map[string]string{"secret_": "Secret"}}
+		_, err := opts.InferSchema((&examplev1.ExampleRedact{}).ProtoReflect().Descriptor())
+		assert.ErrorContains(t, err, "field secret: conflicts with the group of prefix secret_")
+	})
+}
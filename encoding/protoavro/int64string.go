@@ -0,0 +1,45 @@
+package protoavro
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// isInt64Kind returns true if kind is a 64-bit integer kind.
+func isInt64Kind(kind protoreflect.Kind) bool {
+	switch kind {
+	case protoreflect.Int64Kind,
+		protoreflect.Sfixed64Kind,
+		protoreflect.Sint64Kind,
+		protoreflect.Uint64Kind,
+		protoreflect.Fixed64Kind:
+		return true
+	}
+	return false
+}
+
+// int64StringJSON returns the string encoding of a 64-bit integer value of field, used with Int64AsString.
+func (o MarshalOptions) int64StringJSON(field protoreflect.FieldDescriptor, value protoreflect.Value) interface{} {
+	if field.Kind() == protoreflect.Uint64Kind || field.Kind() == protoreflect.Fixed64Kind {
+		return o.unionValue("string", strconv.FormatUint(value.Uint(), 10))
+	}
+	return o.unionValue("string", strconv.FormatInt(value.Int(), 10))
+}
+
+// parseInt64String parses the string encoding of a 64-bit integer value of field.
+func parseInt64String(str string, field protoreflect.FieldDescriptor) (protoreflect.Value, error) {
+	if field.Kind() == protoreflect.Uint64Kind || field.Kind() == protoreflect.Fixed64Kind {
+		u, err := strconv.ParseUint(str, 10, 64)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("field %s: %w", field.Name(), err)
+		}
+		return protoreflect.ValueOfUint64(u), nil
+	}
+	i, err := strconv.ParseInt(str, 10, 64)
+	if err != nil {
+		return protoreflect.Value{}, fmt.Errorf("field %s: %w", field.Name(), err)
+	}
+	return protoreflect.ValueOfInt64(i), nil
+}
@@ -0,0 +1,84 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_Int64AsString(t *testing.T) {
+	opts := SchemaOptions{Int64AsString: true}
+	msg := &descriptorpb.UninterpretedOption{
+		PositiveIntValue: proto.Uint64(math.MaxUint64),
+		NegativeIntValue: proto.Int64(math.MinInt64),
+	}
+	got, err := MarshalOptions{SchemaOptions: opts}.Encode(msg)
+	assert.NilError(t, err)
+	record := got.(map[string]interface{})["google.protobuf.UninterpretedOption"].(map[string]interface{})
+	assert.DeepEqual(t, map[string]interface{}{"string": "18446744073709551615"}, record["positive_int_value"])
+	assert.DeepEqual(t, map[string]interface{}{"string": "-9223372036854775808"}, record["negative_int_value"])
+
+	schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	codec, err := goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+	textual, err := codec.TextualFromNative(nil, got)
+	assert.NilError(t, err)
+	native, _, err := codec.NativeFromTextual(textual)
+	assert.NilError(t, err)
+	var decoded descriptorpb.UninterpretedOption
+	assert.NilError(t, UnmarshalOptions{SchemaOptions: opts}.Unmarshal(native, &decoded))
+	assert.Equal(t, uint64(math.MaxUint64), decoded.GetPositiveIntValue())
+	assert.Equal(t, int64(math.MinInt64), decoded.GetNegativeIntValue())
+}
+
+func TestUnmarshalOptions_Int64AsString(t *testing.T) {
+	opts := UnmarshalOptions{SchemaOptions: SchemaOptions{Int64AsString: true}}
+	for _, tt := range []struct {
+		name        string
+		data        map[string]interface{}
+		expected    *descriptorpb.UninterpretedOption
+		errContains string
+	}{
+		{
+			name:     "raw string",
+			data:     map[string]interface{}{"negative_int_value": "-1"},
+			expected: &descriptorpb.UninterpretedOption{NegativeIntValue: proto.Int64(-1)},
+		},
+		{
+			name:     "long",
+			data:     map[string]interface{}{"positive_int_value": map[string]interface{}{"long": int64(1)}},
+			expected: &descriptorpb.UninterpretedOption{PositiveIntValue: proto.Uint64(1)},
+		},
+		{
+			name:        "out of range",
+			data:        map[string]interface{}{"negative_int_value": "-9223372036854775809"},
+			errContains: "field negative_int_value: strconv.ParseInt",
+		},
+		{
+			name:        "negative unsigned",
+			data:        map[string]interface{}{"positive_int_value": map[string]interface{}{"string": "-1"}},
+			errContains: "field positive_int_value: strconv.ParseUint",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got descriptorpb.UninterpretedOption
+			err := opts.Unmarshal(tt.data, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, &got, protocmp.Transform())
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// keyRecordSuffix is the suffix of the record name of key records.
+const keyRecordSuffix = "Key"
+
+// KeySchema returns the schema of the key records written by MarshalKey: a record with the KeyFields of desc,
+// in the order they are listed, named by the record of desc with the suffix Key.
+func (o MarshalOptions) KeySchema(desc protoreflect.MessageDescriptor) (avro.Schema, error) {
+	fields, err := o.keyFields(desc)
+	if err != nil {
+		return nil, err
+	}
+	s := o.newSchemaInferrer()
+	fullName := o.recordFullName(desc) + keyRecordSuffix
+	record := avro.Record{
+		Type:      avro.RecordType,
+		Name:      fullName[strings.LastIndex(fullName, ".")+1:],
+		Namespace: avro.NamespaceOf(fullName),
+		Fields:    make([]avro.Field, 0, len(fields)),
+	}
+	for _, field := range fields {
+		fieldSchema, err := s.inferField(field, 1)
+		if err != nil {
+			return nil, err
+		}
+		if o.isRequiredField(field) {
+			fieldSchema.Type = nonNullable(fieldSchema.Type)
+		} else {
+			fieldSchema.Type = avro.Nullable(fieldSchema.Type)
+		}
+		record.Fields = append(record.Fields, fieldSchema)
+	}
+	return record, nil
+}
+
+// MarshalKey encodes the KeyFields of message as a key record with the schema from KeySchema,
+// in Avro binary format without a container file, for producers that separate keys from values.
+func (o MarshalOptions) MarshalKey(message proto.Message) ([]byte, error) {
+	desc := message.ProtoReflect().Descriptor()
+	schema, err := o.KeySchema(desc)
+	if err != nil {
+		return nil, err
+	}
+	schemaBytes, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("json marshal key schema: %w", err)
+	}
+	codec, err := goavro.NewCodec(string(schemaBytes))
+	if err != nil {
+		return nil, fmt.Errorf("new key codec: %w", err)
+	}
+	fields, err := o.keyFields(desc)
+	if err != nil {
+		return nil, err
+	}
+	msg := message.ProtoReflect()
+	record := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		if field.ContainingOneof() != nil && !o.isRequiredField(field) && !msg.Has(field) {
+			record[string(field.Name())] = nil
+			continue
+		}
+		value := msg.Get(field)
+		required := o.isRequiredField(field)
+		if required && field.Message() != nil && !field.IsList() && !field.IsMap() && !msg.Has(field) {
+			// required messages are encoded as empty records when not set.
+			value = msg.NewField(field)
+		}
+		jsonValue, err := o.fieldJSON(field, value, 1)
+		if err != nil {
+			return nil, err
+		}
+		if required {
+			jsonValue = unionMember(jsonValue)
+		}
+		record[string(field.Name())] = jsonValue
+	}
+	data, err := codec.BinaryFromNative(nil, record)
+	if err != nil {
+		return nil, fmt.Errorf("binary from native: %w", err)
+	}
+	return data, nil
+}
+
+// keyFields returns the fields of desc listed in KeyFields.
+func (o MarshalOptions) keyFields(desc protoreflect.MessageDescriptor) ([]protoreflect.FieldDescriptor, error) {
+	if len(o.KeyFields) == 0 {
+		return nil, fmt.Errorf("%s: no key fields", desc.FullName())
+	}
+	fields := make([]protoreflect.FieldDescriptor, 0, len(o.KeyFields))
+	seen := make(map[protoreflect.Name]struct{}, len(o.KeyFields))
+	for _, name := range o.KeyFields {
+		field := desc.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return nil, fmt.Errorf("%s: unknown key field %s", desc.FullName(), name)
+		}
+		if _, ok := seen[field.Name()]; ok {
+			return nil, fmt.Errorf("%s: duplicate key field %s", desc.FullName(), name)
+		}
+		seen[field.Name()] = struct{}{}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
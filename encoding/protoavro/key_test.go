@@ -0,0 +1,52 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"gotest.tools/v3/assert"
+)
+
+func TestMarshalOptions_MarshalKey(t *testing.T) {
+	opts := MarshalOptions{KeyFields: []string{"name", "author"}}
+	book := &library.Book{Name: "shelves/1/books/1", Author: "J. K. Rowling", Title: "Harry Potter", Read: true}
+
+	schema, err := opts.KeySchema(book.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Record{
+		Type:      avro.RecordType,
+		Name:      "BookKey",
+		Namespace: "google.example.library.v1",
+		Fields: []avro.Field{
+			{Name: "name", Type: avro.Nullable(avro.String())},
+			{Name: "author", Type: avro.Nullable(avro.String())},
+		},
+	}, schema)
+
+	data, err := opts.MarshalKey(book)
+	assert.NilError(t, err)
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	codec, err := goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+	native, rest, err := codec.NativeFromBinary(data)
+	assert.NilError(t, err)
+	assert.Equal(t, 0, len(rest))
+	assert.DeepEqual(t, map[string]interface{}{
+		"name":   map[string]interface{}{"string": "shelves/1/books/1"},
+		"author": map[string]interface{}{"string": "J. K. Rowling"},
+	}, native)
+
+	t.Run("unknown key field", func(t *testing.T) {
+		_, err := MarshalOptions{KeyFields: []string{"isbn"}}.MarshalKey(book)
+		assert.ErrorContains(t, err, "google.example.library.v1.Book: unknown key field isbn")
+	})
+
+	t.Run("no key fields", func(t *testing.T) {
+		_, err := MarshalOptions{}.MarshalKey(book)
+		assert.ErrorContains(t, err, "google.example.library.v1.Book: no key fields")
+	})
+}
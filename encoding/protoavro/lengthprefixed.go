@@ -0,0 +1,105 @@
+package protoavro
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/linkedin/goavro/v2"
+	"google.golang.org/protobuf/proto"
+)
+
+// lengthPrefixSize is the size of the big-endian length prefix of records of length-prefixed streams.
+const lengthPrefixSize = 4
+
+// NewLengthPrefixedDecoder returns a decoder of a stream of Avro encoded messages read from reader, each
+// prefixed by its length in bytes as a 4-byte big-endian unsigned integer. Messages are Avro binary encoded,
+// or Avro JSON encoded when LengthPrefixedJSON is set, and are read and decoded one at a time by Next.
+// Each message is decoded into a message returned by newMessage, using the schema inferred for it.
+func (o UnmarshalOptions) NewLengthPrefixedDecoder(
+	reader io.Reader,
+	newMessage func() proto.Message,
+) *LengthPrefixedDecoder {
+	return &LengthPrefixedDecoder{
+		opts:       o,
+		newMessage: newMessage,
+		reader:     reader,
+	}
+}
+
+// LengthPrefixedDecoder decodes the messages of a stream of length-prefixed Avro encoded messages.
+type LengthPrefixedDecoder struct {
+	opts       UnmarshalOptions
+	newMessage func() proto.Message
+	reader     io.Reader
+	codec      *goavro.Codec
+	// index is the index of the next record.
+	index int
+	// err is the error that decoding stopped with.
+	err error
+}
+
+// Next decodes the next message of the stream.
+// It returns io.EOF after the last message, and keeps returning the same error after decoding has failed.
+func (d *LengthPrefixedDecoder) Next() (proto.Message, error) {
+	if d.err != nil {
+		return nil, d.err
+	}
+	message, err := d.next()
+	if err != nil {
+		d.err = err
+		return nil, err
+	}
+	return message, nil
+}
+
+func (d *LengthPrefixedDecoder) next() (proto.Message, error) {
+	var prefix [lengthPrefixSize]byte
+	if n, err := io.ReadFull(d.reader, prefix[:]); err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		if err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("record %d: truncated length prefix of %d bytes", d.index, n)
+		}
+		return nil, fmt.Errorf("record %d: read length prefix: %w", d.index, err)
+	}
+	size := int64(binary.BigEndian.Uint32(prefix[:]))
+	// records are read through a limit reader, so that truncated streams with large length prefixes
+	// are not allocated in full.
+	record, err := io.ReadAll(io.LimitReader(d.reader, size))
+	if err != nil {
+		return nil, fmt.Errorf("record %d: read: %w", d.index, err)
+	}
+	if int64(len(record)) < size {
+		return nil, fmt.Errorf("record %d: truncated record of %d bytes, expected %d", d.index, len(record), size)
+	}
+	message := d.newMessage()
+	if d.codec == nil {
+		codec, err := d.opts.newCodec(message.ProtoReflect().Descriptor())
+		if err != nil {
+			return nil, err
+		}
+		d.codec = codec
+	}
+	var data interface{}
+	var rest []byte
+	if d.opts.LengthPrefixedJSON {
+		data, rest, err = d.codec.NativeFromTextual(record)
+		rest = bytes.TrimSpace(rest)
+	} else {
+		data, rest, err = d.codec.NativeFromBinary(record)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("record %d: parse: %w", d.index, err)
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("record %d: parse: unexpected data after message", d.index)
+	}
+	if err := d.opts.Unmarshal(data, message); err != nil {
+		return nil, fmt.Errorf("record %d: %w", d.index, err)
+	}
+	d.index++
+	return message, nil
+}
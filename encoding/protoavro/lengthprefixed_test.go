@@ -0,0 +1,124 @@
+package protoavro
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnmarshalOptions_NewLengthPrefixedDecoder(t *testing.T) {
+	newBook := func() proto.Message { return &library.Book{} }
+	codec, err := SchemaOptions{}.newCodec((&library.Book{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	books := make([]*library.Book, 0, 3)
+	for i := 0; i < 3; i++ {
+		books = append(books, &library.Book{Name: fmt.Sprintf("shelves/1/books/%d", i), Read: i%2 == 0})
+	}
+	stream := func(t *testing.T, textual bool) []byte {
+		t.Helper()
+		var b bytes.Buffer
+		for _, book := range books {
+			datum, err := SchemaOptions{}.Encode(book)
+			assert.NilError(t, err)
+			var record []byte
+			if textual {
+				record, err = codec.TextualFromNative(nil, datum)
+			} else {
+				record, err = codec.BinaryFromNative(nil, datum)
+			}
+			assert.NilError(t, err)
+			var prefix [4]byte
+			binary.BigEndian.PutUint32(prefix[:], uint32(len(record)))
+			b.Write(prefix[:])
+			b.Write(record)
+		}
+		return b.Bytes()
+	}
+
+	for _, tt := range []struct {
+		name        string
+		textual     bool
+		truncate    int
+		expected    int
+		errContains string
+	}{
+		{
+			name:     "binary",
+			expected: 3,
+		},
+		{
+			name:     "json",
+			textual:  true,
+			expected: 3,
+		},
+		{
+			name:        "truncated record",
+			truncate:    1,
+			expected:    2,
+			errContains: "record 2: truncated record of",
+		},
+		{
+			name:        "truncated json record",
+			textual:     true,
+			truncate:    1,
+			expected:    2,
+			errContains: "record 2: truncated record of",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			input := stream(t, tt.textual)
+			input = input[:len(input)-tt.truncate]
+			decoder := UnmarshalOptions{LengthPrefixedJSON: tt.textual}.NewLengthPrefixedDecoder(
+				bytes.NewReader(input),
+				newBook,
+			)
+			for i := 0; i < tt.expected; i++ {
+				got, err := decoder.Next()
+				assert.NilError(t, err)
+				assert.DeepEqual(t, books[i], got, protocmp.Transform())
+			}
+			_, err := decoder.Next()
+			if tt.errContains == "" {
+				assert.Equal(t, io.EOF, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errContains)
+			}
+			// errors are sticky.
+			_, err2 := decoder.Next()
+			assert.Equal(t, err, err2)
+		})
+	}
+
+	t.Run("truncated length prefix", func(t *testing.T) {
+		input := append(stream(t, false), 0, 0)
+		decoder := UnmarshalOptions{}.NewLengthPrefixedDecoder(bytes.NewReader(input), newBook)
+		for range books {
+			_, err := decoder.Next()
+			assert.NilError(t, err)
+		}
+		_, err := decoder.Next()
+		assert.Error(t, err, "record 3: truncated length prefix of 2 bytes")
+	})
+
+	t.Run("trailing data", func(t *testing.T) {
+		datum, err := SchemaOptions{}.Encode(books[0])
+		assert.NilError(t, err)
+		record, err := codec.BinaryFromNative(nil, datum)
+		assert.NilError(t, err)
+		record = append(record, 0)
+		input := make([]byte, 4, 4+len(record))
+		binary.BigEndian.PutUint32(input, uint32(len(record)))
+		input = append(input, record...)
+		decoder := UnmarshalOptions{}.NewLengthPrefixedDecoder(bytes.NewReader(input), newBook)
+		_, err = decoder.Next()
+		assert.Error(t, err, "record 0: parse: unexpected data after message")
+	})
+}
@@ -0,0 +1,68 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NullListElementPolicy determines how null elements of Avro arrays are decoded into repeated fields,
+// which can not hold null elements, for data from producers with nullable array items.
+type NullListElementPolicy int
+
+const (
+	// NullListElementZero decodes null elements as the zero value of the element,
+	// such as 0 for numbers and empty messages for messages. Null elements of wrapper types, such as
+	// google.protobuf.StringValue, decode as wrappers of the zero value.
+	NullListElementZero NullListElementPolicy = iota
+	// NullListElementDrop leaves null elements out of the list.
+	NullListElementDrop
+	// NullListElementError rejects null elements.
+	NullListElementError
+)
+
+// appendNullListElement handles a null element of list according to NullListElementPolicy.
+func (d *decoder) appendNullListElement(list protoreflect.List) error {
+	switch d.opts.NullListElementPolicy {
+	case NullListElementDrop:
+		return nil
+	case NullListElementError:
+		return fmt.Errorf("%s: null list element", d.fieldPath())
+	default:
+		list.Append(list.NewElement())
+		return nil
+	}
+}
+
+// nestedArray returns the elements of data if it is an array, optionally wrapped in a union.
+func nestedArray(data interface{}) ([]interface{}, bool) {
+	if union, ok := data.(map[string]interface{}); ok && len(union) == 1 {
+		data = union[string(avro.ArrayType)]
+	}
+	list, ok := data.([]interface{})
+	return list, ok
+}
+
+// flattenNestedArrays returns the elements of the arrays in data, and the other elements of data, in order,
+// with FlattenNestedArrays. Arrays nested more than one level deep are rejected.
+func (d *decoder) flattenNestedArrays(data []interface{}) ([]interface{}, error) {
+	if !d.opts.FlattenNestedArrays {
+		return data, nil
+	}
+	var flat []interface{}
+	for i, el := range data {
+		nested, ok := nestedArray(el)
+		if !ok {
+			flat = append(flat, el)
+			continue
+		}
+		for j, nestedEl := range nested {
+			if _, ok := nestedArray(nestedEl); ok {
+				return nil, fmt.Errorf("%s[%d][%d]: arrays nested more than one level deep", d.fieldPath(), i, j)
+			}
+		}
+		flat = append(flat, nested...)
+	}
+	return flat, nil
+}
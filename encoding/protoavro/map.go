@@ -9,36 +9,41 @@ import (
 )
 
 func (s schemaInferrer) inferMapSchema(field protoreflect.FieldDescriptor, recursiveIndex int) (avro.Schema, error) {
+	if err := checkMapKey(field); err != nil {
+		return nil, err
+	}
 	fieldKind, err := s.inferFieldKind(field, recursiveIndex)
 	if err != nil {
 		return nil, err
 	}
+	if s.opts.MapEncoding == MapAsParallelArrays {
+		return parallelArraysSchema(field, fieldKind)
+	}
 	return avro.Nullable(avro.Array{
 		Type:  avro.ArrayType,
 		Items: fieldKind,
 	}), nil
 }
 
-func (o *SchemaOptions) encodeMap(
+func (o *MarshalOptions) encodeMap(
 	field protoreflect.FieldDescriptor,
 	m protoreflect.Map,
 	recursiveIndex int,
 ) (interface{}, error) {
+	if err := checkMapKey(field); err != nil {
+		return nil, err
+	}
 	// m.Range ranges over the entries in unspecified order.
-	// To aid in testing, the keys are sorted. This is similar
-	// to what json.Marshal does for maps.
 	keys := make([]protoreflect.MapKey, 0, m.Len())
 	m.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
 		keys = append(keys, key)
 		return true
 	})
-	sort.Slice(keys, func(i, j int) bool {
-		// key.String will return a string for any key type (not just strings)
-		// for example 1 would be "1"
-		return keys[i].String() < keys[j].String()
-	})
+	o.sortMapKeys(field.MapKey(), keys)
 
 	entries := make([]interface{}, 0, m.Len())
+	keyValues := make([]interface{}, 0, m.Len())
+	valueValues := make([]interface{}, 0, m.Len())
 	valueField := field.MapValue()
 	keyField := field.MapKey()
 	for _, key := range keys {
@@ -51,23 +56,204 @@ func (o *SchemaOptions) encodeMap(
 		if err != nil {
 			return nil, err
 		}
+		if o.isRequiredField(keyField) {
+			keyValue = unionMember(keyValue)
+		}
+		if o.isRequiredField(valueField) {
+			valueValue = unionMember(valueValue)
+		}
+		if o.MapEncoding == MapAsParallelArrays {
+			keyValues = append(keyValues, keyValue)
+			valueValues = append(valueValues, valueValue)
+			continue
+		}
 		entries = append(entries, map[string]interface{}{
 			"key":   keyValue,
 			"value": valueValue,
 		})
 	}
+	if o.MapEncoding == MapAsParallelArrays {
+		return o.unionValue(o.recordFullName(field.Message()), map[string]interface{}{
+			parallelKeysField:   keyValues,
+			parallelValuesField: valueValues,
+		}), nil
+	}
 	return o.unionValue("array", entries), nil
 }
 
-func (o SchemaOptions) decodeMap(data interface{}, f protoreflect.FieldDescriptor, mp protoreflect.Map) error {
-	list, err := decodeListLike(data, "array")
+// NullMapValuePolicy determines how null values of map entries are decoded into map fields,
+// which can not hold null values.
+type NullMapValuePolicy int
+
+const (
+	// NullMapValueZero decodes null values as the zero value of the map value,
+	// such as 0 for numbers and empty messages for messages.
+	NullMapValueZero NullMapValuePolicy = iota
+	// NullMapValueDrop leaves entries with null values out of the map.
+	NullMapValueDrop
+	// NullMapValueError rejects null values.
+	NullMapValueError
+)
+
+// setNullMapValue handles a null value of the entry with key of mp according to NullMapValuePolicy.
+func (d *decoder) setNullMapValue(mp protoreflect.Map, key protoreflect.MapKey) error {
+	switch d.opts.NullMapValuePolicy {
+	case NullMapValueDrop:
+		return nil
+	case NullMapValueError:
+		return fmt.Errorf("%s: null map value", d.fieldPath())
+	default:
+		mp.Set(key, mp.NewValue())
+		return nil
+	}
+}
+
+// MapEncoding determines how protobuf maps are represented in Avro.
+type MapEncoding int
+
+const (
+	// MapAsEntryArray represents maps as Avro arrays of records of a key and a value,
+	// [{"key": k1, "value": v1}, {"key": k2, "value": v2}], which supports keys of any kind.
+	MapAsEntryArray MapEncoding = iota
+	// MapAsParallelArrays represents maps as Avro records of parallel arrays of keys and values,
+	// {"keys": [k1, k2], "values": [v1, v2]}, as emitted by some producers. The records are named by
+	// the full name of the map entry message. Decoding also accepts arrays of entries.
+	MapAsParallelArrays
+)
+
+// Field names of the records of maps with MapAsParallelArrays.
+const (
+	parallelKeysField   = "keys"
+	parallelValuesField = "values"
+)
+
+// parallelArraysSchema returns the schema of map field with MapAsParallelArrays,
+// given the record schema of its map entries.
+func parallelArraysSchema(field protoreflect.FieldDescriptor, entrySchema avro.Schema) (avro.Schema, error) {
+	entry, ok := entrySchema.(avro.Record)
+	if !ok {
+		return nil, fmt.Errorf("field %s: expected map entry record schema, got %T", field.Name(), entrySchema)
+	}
+	record := avro.Record{
+		Type:      avro.RecordType,
+		Name:      entry.Name,
+		Namespace: entry.Namespace,
+		Doc:       entry.Doc,
+	}
+	for _, entryField := range entry.Fields {
+		name := parallelKeysField
+		if entryField.Name == "value" {
+			name = parallelValuesField
+		}
+		record.Fields = append(record.Fields, avro.Field{
+			Name: name,
+			Type: avro.Array{Type: avro.ArrayType, Items: entryField.Type},
+		})
+	}
+	return avro.Nullable(record), nil
+}
+
+// parallelArraysEntries returns the map entries of data in the MapAsParallelArrays representation
+// of map field f, by zipping the arrays of keys and values.
+func (d *decoder) parallelArraysEntries(data interface{}, f protoreflect.FieldDescriptor) ([]interface{}, error) {
+	record, ok := data.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("field %s: expected parallel arrays encoded as map[string]interface{}, got %T", f.Name(), data)
+	}
+	if unwrapped, ok := record[d.opts.recordFullName(f.Message())].(map[string]interface{}); ok && len(record) == 1 {
+		record = unwrapped
+	}
+	keys, err := decodeListLike(record[parallelKeysField], "array")
+	if err != nil {
+		return nil, fmt.Errorf("field %s: keys: %w", f.Name(), err)
+	}
+	values, err := decodeListLike(record[parallelValuesField], "array")
 	if err != nil {
+		return nil, fmt.Errorf("field %s: values: %w", f.Name(), err)
+	}
+	if len(keys) != len(values) {
+		return nil, fmt.Errorf("field %s: %d keys and %d values in parallel arrays", f.Name(), len(keys), len(values))
+	}
+	entries := make([]interface{}, 0, len(keys))
+	for i := range keys {
+		entries = append(entries, map[string]interface{}{
+			"key":   keys[i],
+			"value": values[i],
+		})
+	}
+	return entries, nil
+}
+
+// MapOrder determines the order of the entries of encoded protobuf maps. Protobuf maps are unordered,
+// and do not retain insertion order, so decoding accepts entries in any order.
+type MapOrder int
+
+const (
+	// MapOrderKeyString sorts entries by the string form of their keys, similar to what json.Marshal
+	// does for maps, so that the key 10 sorts before the key 9.
+	MapOrderKeyString MapOrder = iota
+	// MapOrderSortedKey sorts entries by their keys in the natural order of the key kind:
+	// numerically for integer keys, false before true for bool keys, and bytewise for string keys.
+	MapOrderSortedKey
+	// MapOrderUnordered leaves entries in the unspecified order that protobuf ranges over them in,
+	// which avoids sorting large maps when the order does not matter.
+	MapOrderUnordered
+)
+
+// sortMapKeys sorts the keys of a map with the key field keyField by MapOrder.
+func (o *MarshalOptions) sortMapKeys(keyField protoreflect.FieldDescriptor, keys []protoreflect.MapKey) {
+	switch o.MapOrder {
+	case MapOrderUnordered:
+	case MapOrderSortedKey:
+		sort.Slice(keys, func(i, j int) bool {
+			switch keyField.Kind() {
+			case protoreflect.BoolKind:
+				return !keys[i].Bool() && keys[j].Bool()
+			case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+				protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+				return keys[i].Int() < keys[j].Int()
+			case protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+				protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+				return keys[i].Uint() < keys[j].Uint()
+			}
+			return keys[i].String() < keys[j].String()
+		})
+	default:
+		sort.Slice(keys, func(i, j int) bool {
+			// key.String will return a string for any key type (not just strings)
+			// for example 1 would be "1"
+			return keys[i].String() < keys[j].String()
+		})
+	}
+}
+
+func (d *decoder) decodeMap(data interface{}, f protoreflect.FieldDescriptor, mp protoreflect.Map) error {
+	if err := checkMapKey(f); err != nil {
 		return err
 	}
-	return o.decodeMapEntries(list, f, mp)
+	list, err := decodeListLike(data, "array")
+	if err != nil {
+		if d.opts.MapEncoding != MapAsParallelArrays {
+			return err
+		}
+		if list, err = d.parallelArraysEntries(data, f); err != nil {
+			return err
+		}
+	}
+	return d.decodeMapEntries(list, f, mp)
 }
 
-func (o SchemaOptions) decodeMapEntries(data []interface{}, f protoreflect.FieldDescriptor, mp protoreflect.Map) error {
+func (d *decoder) decodeMapEntries(
+	data []interface{},
+	f protoreflect.FieldDescriptor,
+	mp protoreflect.Map,
+) error {
+	if err := d.checkElements(f, len(data)); err != nil {
+		return err
+	}
+	if d.opts.MaxMapEntries > 0 && len(data) > d.opts.MaxMapEntries {
+		return fmt.Errorf("field %s: %d entries exceed max map entries %d", f.Name(), len(data), d.opts.MaxMapEntries)
+	}
 	for _, el := range data {
 		entry, ok := el.(map[string]interface{})
 		if !ok {
@@ -81,11 +267,35 @@ func (o SchemaOptions) decodeMapEntries(data []interface{}, f protoreflect.Field
 		if !ok {
 			return fmt.Errorf("missing 'value' in map entry for '%s'", f.Name())
 		}
-		keyValue, err := o.decodeFieldKind(keyData, protoreflect.Value{}, f.MapKey())
+		keyValue, err := d.decodeFieldKind(keyData, protoreflect.Value{}, f.MapKey())
 		if err != nil {
 			return err
 		}
-		valueValue, err := o.decodeFieldKind(valueData, mp.NewValue(), f.MapValue())
+		if d.opts.MapKeyNormalizer != nil && f.MapKey().Kind() == protoreflect.StringKind {
+			keyValue = protoreflect.ValueOfString(d.opts.MapKeyNormalizer(keyValue.String()))
+			if mp.Has(keyValue.MapKey()) {
+				if d.opts.RejectMapKeyCollisions {
+					return fmt.Errorf("field %s: duplicate key '%s' after normalization", f.Name(), keyValue.String())
+				}
+				d.warn("duplicate key '%s' after normalization replaced", keyValue.String())
+			}
+		}
+		d.pushPath(fmt.Sprintf("[%v]", keyValue.Interface()))
+		if valueData == nil {
+			err := d.setNullMapValue(mp, keyValue.MapKey())
+			d.popPath()
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		if d.isUnknownUnionBranch(valueData, f, true) {
+			d.popPath()
+			mp.Set(keyValue.MapKey(), mp.NewValue())
+			continue
+		}
+		valueValue, err := d.decodeFieldKind(valueData, mp.NewValue(), f.MapValue())
+		d.popPath()
 		if err != nil {
 			return err
 		}
@@ -93,3 +303,24 @@ func (o SchemaOptions) decodeMapEntries(data []interface{}, f protoreflect.Field
 	}
 	return nil
 }
+
+// checkMapKey returns an error if the key of map field f is of a kind that protobuf does not allow as map key,
+// which is only possible for descriptors that have not been validated, such as dynamic ones.
+func checkMapKey(f protoreflect.FieldDescriptor) error {
+	switch f.MapKey().Kind() {
+	case protoreflect.BoolKind,
+		protoreflect.Int32Kind,
+		protoreflect.Sint32Kind,
+		protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind,
+		protoreflect.Fixed32Kind,
+		protoreflect.Int64Kind,
+		protoreflect.Sint64Kind,
+		protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind,
+		protoreflect.Fixed64Kind,
+		protoreflect.StringKind:
+		return nil
+	}
+	return fmt.Errorf("field %s: unsupported map key kind %s", f.FullName(), f.MapKey().Kind())
+}
@@ -1,6 +1,8 @@
 package protoavro
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
 	"go.einride.tech/protobuf-avro/avro"
@@ -8,6 +10,7 @@ import (
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"gotest.tools/v3/assert"
 )
 
@@ -189,7 +192,7 @@ func Test_MapSchema(t *testing.T) {
 func Test_MapEncode(t *testing.T) {
 	for _, tt := range []struct {
 		name      string
-		opts      SchemaOptions
+		opts      MarshalOptions
 		msg       proto.Message
 		fieldName protoreflect.Name
 		expected  interface{}
@@ -264,7 +267,7 @@ func Test_MapDecode(t *testing.T) {
 	for _, tt := range []struct {
 		name      string
 		msg       proto.Message
-		opts      SchemaOptions
+		opts      UnmarshalOptions
 		fieldName protoreflect.Name
 		data      interface{}
 		expected  proto.Message
@@ -287,6 +290,35 @@ func Test_MapDecode(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:      "string to enum",
+			msg:       &examplev1.ExampleMap{},
+			fieldName: "string_to_enum",
+			data: []interface{}{
+				map[string]interface{}{"key": "a", "value": "ENUM_VALUE1"},
+				map[string]interface{}{
+					"key":   "b",
+					"value": map[string]interface{}{"einride.avro.example.v1.ExampleMap.Enum": "ENUM_VALUE2"},
+				},
+				map[string]interface{}{"key": "c", "value": "ENUM_VALUE3"},
+			},
+			expected: &examplev1.ExampleMap{
+				StringToEnum: map[string]examplev1.ExampleMap_Enum{
+					"a": examplev1.ExampleMap_ENUM_VALUE1,
+					"b": examplev1.ExampleMap_ENUM_VALUE2,
+					"c": examplev1.ExampleMap_ENUM_UNSPECIFIED,
+				},
+			},
+		},
+		{
+			name:      "string to enum number",
+			msg:       &examplev1.ExampleMap{},
+			fieldName: "string_to_enum",
+			data: []interface{}{
+				map[string]interface{}{"key": "a", "value": int32(1)},
+			},
+			expectErr: "field value: expected string-like",
+		},
 		{
 			name:      "invalid type",
 			msg:       &examplev1.ExampleMap{},
@@ -321,12 +353,70 @@ func Test_MapDecode(t *testing.T) {
 			},
 			expectErr: "missing 'value' in map entry for 'string_to_string'",
 		},
+		{
+			name:      "int32 to string with JSON number keys",
+			msg:       &examplev1.ExampleMap{},
+			fieldName: "int32_to_string",
+			data: []interface{}{
+				map[string]interface{}{"key": json.Number("1"), "value": "a"},
+				map[string]interface{}{"key": float64(-2), "value": "b"},
+				map[string]interface{}{"key": map[string]interface{}{"int": json.Number("3")}, "value": "c"},
+			},
+			expected: &examplev1.ExampleMap{
+				Int32ToString: map[int32]string{1: "a", -2: "b", 3: "c"},
+			},
+		},
+		{
+			name:      "int64 to string with JSON number keys",
+			msg:       &examplev1.ExampleMap{},
+			fieldName: "int64_to_string",
+			data: []interface{}{
+				map[string]interface{}{"key": json.Number("9007199254740993"), "value": "a"},
+			},
+			expected: &examplev1.ExampleMap{
+				Int64ToString: map[int64]string{9007199254740993: "a"},
+			},
+		},
+		{
+			name:      "int32 to string with non-integer key",
+			msg:       &examplev1.ExampleMap{},
+			fieldName: "int32_to_string",
+			data: []interface{}{
+				map[string]interface{}{"key": json.Number("1.5"), "value": "a"},
+			},
+			expectErr: "expected int-like, got non-integer 1.5",
+		},
+		{
+			name:      "at max map entries",
+			opts:      UnmarshalOptions{MaxMapEntries: 2},
+			msg:       &examplev1.ExampleMap{},
+			fieldName: "string_to_string",
+			data: []interface{}{
+				map[string]interface{}{"key": "a", "value": "1"},
+				map[string]interface{}{"key": "b", "value": "2"},
+			},
+			expected: &examplev1.ExampleMap{
+				StringToString: map[string]string{"a": "1", "b": "2"},
+			},
+		},
+		{
+			name:      "over max map entries",
+			opts:      UnmarshalOptions{MaxMapEntries: 2},
+			msg:       &examplev1.ExampleMap{},
+			fieldName: "string_to_string",
+			data: []interface{}{
+				map[string]interface{}{"key": "a", "value": "1"},
+				map[string]interface{}{"key": "b", "value": "2"},
+				map[string]interface{}{"key": "c", "value": "3"},
+			},
+			expectErr: "field string_to_string: 3 entries exceed max map entries 2",
+		},
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
 			desc := tt.msg.ProtoReflect().Descriptor().Fields().ByName(tt.fieldName)
 			val := tt.msg.ProtoReflect().Mutable(desc)
-			err := tt.opts.decodeMap(tt.data, desc, val.Map())
+			err := tt.opts.newDecoder().decodeMap(tt.data, desc, val.Map())
 			if tt.expectErr != "" {
 				assert.ErrorContains(t, err, tt.expectErr)
 				return
@@ -336,3 +426,383 @@ func Test_MapDecode(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalOptions_MapKeyNormalizer(t *testing.T) {
+	data := map[string]interface{}{
+		"string_to_string": []interface{}{
+			map[string]interface{}{"key": "a", "value": "1"},
+			map[string]interface{}{"key": " a ", "value": "2"},
+			map[string]interface{}{"key": "b\t", "value": "3"},
+		},
+	}
+	for _, tt := range []struct {
+		name        string
+		opts        UnmarshalOptions
+		expected    map[string]string
+		errContains string
+	}{
+		{
+			name:     "no normalizer",
+			expected: map[string]string{"a": "1", " a ": "2", "b\t": "3"},
+		},
+		{
+			name:     "last wins",
+			opts:     UnmarshalOptions{MapKeyNormalizer: strings.TrimSpace},
+			expected: map[string]string{"a": "2", "b": "3"},
+		},
+		{
+			name: "reject collisions",
+			opts: UnmarshalOptions{
+				MapKeyNormalizer:       strings.TrimSpace,
+				RejectMapKeyCollisions: true,
+			},
+			errContains: "field string_to_string: duplicate key 'a' after normalization",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleMap
+			err := tt.opts.Unmarshal(data, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got.GetStringToString())
+		})
+	}
+}
+
+// invalidMapKeyField is a map field with a key of a kind that protobuf does not allow,
+// as could be presented by unvalidated dynamic descriptors.
+type invalidMapKeyField struct {
+	protoreflect.FieldDescriptor
+	key protoreflect.FieldDescriptor
+}
+
+func (f invalidMapKeyField) MapKey() protoreflect.FieldDescriptor {
+	return f.key
+}
+
+func Test_MapInvalidKeyKind(t *testing.T) {
+	msg := &examplev1.ExampleMap{StringToString: map[string]string{"a": "b"}}
+	fields := msg.ProtoReflect().Descriptor().Fields()
+	field := invalidMapKeyField{
+		FieldDescriptor: fields.ByName("string_to_string"),
+		key:             fields.ByName("string_to_nested").MapValue(),
+	}
+	const expected = "field einride.avro.example.v1.ExampleMap.string_to_string: unsupported map key kind message"
+
+	t.Run("schema", func(t *testing.T) {
+		_, err := SchemaOptions{}.newSchemaInferrer().inferMapSchema(field, 0)
+		assert.Error(t, err, expected)
+	})
+
+	t.Run("encode", func(t *testing.T) {
+		_, err := (&MarshalOptions{}).encodeMap(field, msg.ProtoReflect().Get(field.FieldDescriptor).Map(), 0)
+		assert.Error(t, err, expected)
+	})
+
+	t.Run("decode", func(t *testing.T) {
+		data := []interface{}{
+			map[string]interface{}{
+				"key":   map[string]interface{}{"einride.avro.example.v1.ExampleMap.Nested": map[string]interface{}{}},
+				"value": "b",
+			},
+		}
+		mp := msg.ProtoReflect().NewField(field.FieldDescriptor).Map()
+		err := UnmarshalOptions{}.newDecoder().decodeMap(data, field, mp)
+		assert.Error(t, err, expected)
+	})
+}
+
+func Test_MapRecursive(t *testing.T) {
+	msg := &examplev1.ExampleRecursiveMap{
+		Value: "root",
+		Children: map[string]*examplev1.ExampleRecursiveMap{
+			"a": {
+				Value: "a",
+				Children: map[string]*examplev1.ExampleRecursiveMap{
+					"b": {Value: "b"},
+				},
+			},
+			"c": {Value: "c"},
+		},
+	}
+	schema, err := InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Nullable(avro.Record{
+		Type:      avro.RecordType,
+		Name:      "ExampleRecursiveMap",
+		Namespace: "einride.avro.example.v1",
+		Fields: []avro.Field{
+			{Name: "value", Type: avro.Nullable(avro.String())},
+			{
+				Name: "children",
+				Type: avro.Nullable(avro.Nullable(avro.Array{
+					Type: avro.ArrayType,
+					Items: avro.Record{
+						Type:      avro.RecordType,
+						Name:      "ChildrenEntry",
+						Namespace: "einride.avro.example.v1.ExampleRecursiveMap",
+						Fields: []avro.Field{
+							{Name: "key", Type: avro.Nullable(avro.String())},
+							{
+								Name: "value",
+								Type: avro.Nullable(avro.Nullable(avro.Reference("einride.avro.example.v1.ExampleRecursiveMap"))),
+							},
+						},
+					},
+				})),
+			},
+		},
+	}), schema)
+	native := assertRoundTrip(t, SchemaOptions{}, schema, msg)
+	record := native.(map[string]interface{})["einride.avro.example.v1.ExampleRecursiveMap"].(map[string]interface{})
+	children := record["children"].(map[string]interface{})["array"].([]interface{})
+	assert.Equal(t, 2, len(children))
+}
+
+func TestMarshalOptions_MapOrder(t *testing.T) {
+	msg := &examplev1.ExampleMap{
+		StringToString: map[string]string{"b": "", "a": "", "B": "", "aa": ""},
+		Int32ToString:  map[int32]string{10: "", 9: "", -1: "", 0: ""},
+		Int64ToString:  map[int64]string{100: "", -20: "", 3: ""},
+		Uint32ToString: map[uint32]string{10: "", 2: "", 4294967295: ""},
+		BoolToString:   map[bool]string{true: "", false: ""},
+	}
+	for _, tt := range []struct {
+		fieldName protoreflect.Name
+		keyString []interface{}
+		sortedKey []interface{}
+	}{
+		{
+			fieldName: "string_to_string",
+			keyString: []interface{}{"B", "a", "aa", "b"},
+			sortedKey: []interface{}{"B", "a", "aa", "b"},
+		},
+		{
+			fieldName: "int32_to_string",
+			keyString: []interface{}{int32(-1), int32(0), int32(10), int32(9)},
+			sortedKey: []interface{}{int32(-1), int32(0), int32(9), int32(10)},
+		},
+		{
+			fieldName: "int64_to_string",
+			keyString: []interface{}{int64(-20), int64(100), int64(3)},
+			sortedKey: []interface{}{int64(-20), int64(3), int64(100)},
+		},
+		{
+			fieldName: "uint32_to_string",
+			keyString: []interface{}{int32(10), int32(2), int32(-1)},
+			sortedKey: []interface{}{int32(2), int32(10), int32(-1)},
+		},
+		{
+			fieldName: "bool_to_string",
+			keyString: []interface{}{false, true},
+			sortedKey: []interface{}{false, true},
+		},
+	} {
+		tt := tt
+		t.Run(string(tt.fieldName), func(t *testing.T) {
+			desc := msg.ProtoReflect().Descriptor().Fields().ByName(tt.fieldName)
+			mp := msg.ProtoReflect().Get(desc).Map()
+			encodedKeys := func(opts MarshalOptions) []interface{} {
+				got, err := opts.encodeMap(desc, mp, 0)
+				assert.NilError(t, err)
+				entries := got.(map[string]interface{})["array"].([]interface{})
+				keys := make([]interface{}, 0, len(entries))
+				for _, entry := range entries {
+					for _, key := range entry.(map[string]interface{})["key"].(map[string]interface{}) {
+						keys = append(keys, key)
+					}
+				}
+				return keys
+			}
+			assert.DeepEqual(t, tt.keyString, encodedKeys(MarshalOptions{}))
+			assert.DeepEqual(t, tt.sortedKey, encodedKeys(MarshalOptions{MapOrder: MapOrderSortedKey}))
+			unordered := encodedKeys(MarshalOptions{MapOrder: MapOrderUnordered})
+			assert.Equal(t, len(tt.sortedKey), len(unordered))
+			for _, key := range tt.sortedKey {
+				assert.Assert(t, containsValue(unordered, key), "missing key %v", key)
+			}
+		})
+	}
+	t.Run("decode in any order", func(t *testing.T) {
+		desc := msg.ProtoReflect().Descriptor().Fields().ByName("int32_to_string")
+		opts := MarshalOptions{MapOrder: MapOrderSortedKey}
+		encoded, err := opts.encodeMap(desc, msg.ProtoReflect().Get(desc).Map(), 0)
+		assert.NilError(t, err)
+		entries := encoded.(map[string]interface{})["array"].([]interface{})
+		reversed := make([]interface{}, 0, len(entries))
+		for i := len(entries) - 1; i >= 0; i-- {
+			reversed = append(reversed, entries[i])
+		}
+		var got examplev1.ExampleMap
+		assert.NilError(t, UnmarshalOptions{}.Unmarshal(map[string]interface{}{
+			"int32_to_string": map[string]interface{}{"array": reversed},
+		}, &got))
+		assert.DeepEqual(t, msg.Int32ToString, got.Int32ToString)
+	})
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSchemaOptions_MapAsParallelArrays(t *testing.T) {
+	opts := SchemaOptions{MapEncoding: MapAsParallelArrays}
+	msg := &examplev1.ExampleMap{
+		StringToString: map[string]string{"a": "1", "b": "2"},
+		StringToNested: map[string]*examplev1.ExampleMap_Nested{
+			"c": {StringToString: map[string]string{"d": "3"}},
+		},
+		Int64ToString: map[int64]string{10: "x", 9: "y"},
+		BoolToString:  map[bool]string{true: "t"},
+	}
+	schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.DeepEqual(t, avro.Field{
+		Name: "string_to_string",
+		Type: avro.Nullable(avro.Record{
+			Type:      avro.RecordType,
+			Name:      "StringToStringEntry",
+			Namespace: "einride.avro.example.v1.ExampleMap",
+			Fields: []avro.Field{
+				{Name: "keys", Type: avro.Array{Type: avro.ArrayType, Items: avro.Nullable(avro.String())}},
+				{Name: "values", Type: avro.Array{Type: avro.ArrayType, Items: avro.Nullable(avro.String())}},
+			},
+		}),
+	}, schema.(avro.Union)[1].(avro.Record).Fields[0])
+	native := assertRoundTrip(t, opts, schema, msg)
+	record := native.(map[string]interface{})["einride.avro.example.v1.ExampleMap"].(map[string]interface{})
+	assert.DeepEqual(t, map[string]interface{}{
+		"einride.avro.example.v1.ExampleMap.StringToStringEntry": map[string]interface{}{
+			"keys":   []interface{}{map[string]interface{}{"string": "a"}, map[string]interface{}{"string": "b"}},
+			"values": []interface{}{map[string]interface{}{"string": "1"}, map[string]interface{}{"string": "2"}},
+		},
+	}, record["string_to_string"])
+
+	for _, tt := range []struct {
+		name        string
+		data        map[string]interface{}
+		expected    *examplev1.ExampleMap
+		errContains string
+	}{
+		{
+			name: "bare record",
+			data: map[string]interface{}{
+				"int64_to_string": map[string]interface{}{
+					"keys":   []interface{}{int64(1), int64(2)},
+					"values": []interface{}{"a", "b"},
+				},
+			},
+			expected: &examplev1.ExampleMap{Int64ToString: map[int64]string{1: "a", 2: "b"}},
+		},
+		{
+			name: "entry array",
+			data: map[string]interface{}{
+				"string_to_string": []interface{}{map[string]interface{}{"key": "a", "value": "b"}},
+			},
+			expected: &examplev1.ExampleMap{StringToString: map[string]string{"a": "b"}},
+		},
+		{
+			name: "mismatched lengths",
+			data: map[string]interface{}{
+				"string_to_string": map[string]interface{}{
+					"keys":   []interface{}{"a", "b"},
+					"values": []interface{}{"c"},
+				},
+			},
+			errContains: "field string_to_string: 2 keys and 1 values in parallel arrays",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleMap
+			err := UnmarshalOptions{SchemaOptions: opts}.Unmarshal(tt.data, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, &got, protocmp.Transform())
+		})
+	}
+}
+
+func TestSchemaOptions_NonNullableMapValues(t *testing.T) {
+	msg := &examplev1.ExampleMap{
+		StringToString:     map[string]string{"a": "b"},
+		StringToNested:     map[string]*examplev1.ExampleMap_Nested{"c": {StringToString: map[string]string{"d": "e"}}},
+		StringToEnum:       map[string]examplev1.ExampleMap_Enum{"f": examplev1.ExampleMap_ENUM_VALUE1},
+		StringToFloatValue: map[string]*wrapperspb.FloatValue{"g": wrapperspb.Float(1)},
+	}
+	for _, tt := range []struct {
+		name          string
+		opts          SchemaOptions
+		expectedValue avro.Schema
+	}{
+		{
+			name:          "nullable",
+			expectedValue: avro.Nullable(avro.String()),
+		},
+		{
+			name:          "non-nullable",
+			opts:          SchemaOptions{NonNullableMapValues: true},
+			expectedValue: avro.String(),
+		},
+		{
+			name:          "non-nullable with scalar presence",
+			opts:          SchemaOptions{NonNullableMapValues: true, ScalarPresence: ScalarPresenceOptionalAsUnion},
+			expectedValue: avro.String(),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := tt.opts.InferSchema(msg.ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			record := schema.(avro.Union)[1].(avro.Record)
+			entry := record.Fields[0].Type.(avro.Union)[1].(avro.Array).Items.(avro.Record)
+			assert.DeepEqual(t, tt.expectedValue, entry.Fields[1].Type)
+			floatEntry := record.Fields[7].Type.(avro.Union)[1].(avro.Array).Items.(avro.Record)
+			assert.DeepEqual(t, avro.Nullable(avro.Float()), floatEntry.Fields[1].Type)
+			assertRoundTrip(t, tt.opts, schema, msg)
+		})
+	}
+}
+
+func TestUnmarshalOptions_NullMapValuePolicy(t *testing.T) {
+	data := map[string]interface{}{
+		"string_to_string": []interface{}{
+			map[string]interface{}{"key": "a", "value": "b"},
+			map[string]interface{}{"key": "c", "value": nil},
+		},
+	}
+	for _, tt := range []struct {
+		name        string
+		policy      NullMapValuePolicy
+		expected    map[string]string
+		errContains string
+	}{
+		{name: "zero", policy: NullMapValueZero, expected: map[string]string{"a": "b", "c": ""}},
+		{name: "drop", policy: NullMapValueDrop, expected: map[string]string{"a": "b"}},
+		{name: "error", policy: NullMapValueError, errContains: "string_to_string[c]: null map value"},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleMap
+			err := UnmarshalOptions{NullMapValuePolicy: tt.policy}.Unmarshal(data, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got.StringToString)
+		})
+	}
+}
@@ -13,12 +13,18 @@ import (
 // NewMarshaler returns a new marshaler, with default SchemaOptions, that writes protobuf messages to writer in
 // Avro binary format.
 func NewMarshaler(descriptor protoreflect.MessageDescriptor, writer io.Writer) (*Marshaler, error) {
-	return SchemaOptions{}.NewMarshaler(descriptor, writer)
+	return MarshalOptions{}.NewMarshaler(descriptor, writer)
 }
 
 // NewMarshaler returns a new marshaler that writes protobuf messages to writer in
 // Avro binary format.
 func (o SchemaOptions) NewMarshaler(descriptor protoreflect.MessageDescriptor, writer io.Writer) (*Marshaler, error) {
+	return MarshalOptions{SchemaOptions: o}.NewMarshaler(descriptor, writer)
+}
+
+// NewMarshaler returns a new marshaler that writes protobuf messages to writer in
+// Avro binary format.
+func (o MarshalOptions) NewMarshaler(descriptor protoreflect.MessageDescriptor, writer io.Writer) (*Marshaler, error) {
 	schema, err := o.InferSchema(descriptor)
 	if err != nil {
 		return nil, fmt.Errorf("infer schema: %w", err)
@@ -39,7 +45,7 @@ func (o SchemaOptions) NewMarshaler(descriptor protoreflect.MessageDescriptor, w
 
 // Marshaler encodes and writes Avro binary encoded messages.
 type Marshaler struct {
-	opts SchemaOptions
+	opts MarshalOptions
 	desc protoreflect.MessageDescriptor
 	w    *goavro.OCFWriter
 }
@@ -67,6 +73,11 @@ func (m *Marshaler) Marshal(messages ...proto.Message) error {
 
 // Encode encodes the message.
 func (o SchemaOptions) Encode(message proto.Message) (interface{}, error) {
+	return MarshalOptions{SchemaOptions: o}.Encode(message)
+}
+
+// Encode encodes the message.
+func (o MarshalOptions) Encode(message proto.Message) (interface{}, error) {
 	encJSON, err := o.encodeJSON(message)
 	if err != nil {
 		return nil, fmt.Errorf("encode json: %w", err)
@@ -88,3 +99,44 @@ func (m *Marshaler) Append(messages interface{}) error {
 	}
 	return nil
 }
+
+// MarshalWithCanonicalSchema encodes the message, and returns the encoded datum together with the
+// Parsing Canonical Form of the schema inferred under the same options and its 64-bit Rabin fingerprint,
+// as used by schema registries.
+func MarshalWithCanonicalSchema(
+	message proto.Message,
+	opts MarshalOptions,
+) (datum interface{}, canonical json.RawMessage, fingerprint uint64, err error) {
+	codec, err := opts.newCodec(message.ProtoReflect().Descriptor())
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	datum, err = opts.Encode(message)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	return datum, json.RawMessage(codec.CanonicalSchema()), codec.Rabin, nil
+}
+
+// MarshalWithKey encodes the message in Avro binary format, without a container file,
+// and returns it together with the partition key of the message from PartitionKey,
+// for producers that write messages with keys. The key is empty when PartitionKey is not set.
+func (o MarshalOptions) MarshalWithKey(message proto.Message) (key string, data []byte, err error) {
+	if o.PartitionKey != nil {
+		if key, err = o.PartitionKey(message); err != nil {
+			return "", nil, fmt.Errorf("partition key: %w", err)
+		}
+	}
+	codec, err := o.newCodec(message.ProtoReflect().Descriptor())
+	if err != nil {
+		return "", nil, err
+	}
+	datum, err := o.Encode(message)
+	if err != nil {
+		return "", nil, err
+	}
+	if data, err = codec.BinaryFromNative(nil, datum); err != nil {
+		return "", nil, fmt.Errorf("binary from native: %w", err)
+	}
+	return key, data, nil
+}
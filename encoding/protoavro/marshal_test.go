@@ -2,11 +2,18 @@ package protoavro_test
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/linkedin/goavro/v2"
 	"go.einride.tech/protobuf-avro/encoding/protoavro"
 	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	publicv1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/bigquery/public/v1"
 	"google.golang.org/genproto/googleapis/example/library/v1"
 	"google.golang.org/genproto/googleapis/type/date"
 	"google.golang.org/genproto/googleapis/type/timeofday"
@@ -14,6 +21,7 @@ import (
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	"gotest.tools/v3/assert"
 )
 
@@ -79,6 +87,30 @@ func Test_MarshalSymmetric(t *testing.T) {
 				Duration: durationpb.New(time.Hour),
 			},
 		},
+		{
+			name: "examplev1.ExampleWrappers: special floats",
+			msg: &examplev1.ExampleWrappers{
+				FloatValue:  wrapperspb.Float(float32(math.NaN())),
+				DoubleValue: wrapperspb.Double(math.Inf(1)),
+			},
+		},
+		{
+			name: "examplev1.ExampleIntegers",
+			msg: &examplev1.ExampleIntegers{
+				Int32Value:      math.MinInt32,
+				Sint32Value:     math.MinInt32,
+				Uint32Value:     math.MaxUint32,
+				Fixed32Value:    math.MaxUint32,
+				Sfixed32Value:   math.MinInt32,
+				Int64Value:      math.MinInt64,
+				Sint64Value:     math.MinInt64,
+				Uint64Value:     math.MaxUint64,
+				Fixed64Value:    math.MaxUint64,
+				Sfixed64Value:   math.MinInt64,
+				Sint64List:      []int64{math.MaxInt64},
+				Sint32ToFixed64: map[int32]uint64{math.MaxInt32: math.MaxUint64},
+			},
+		},
 		{
 			name: "examplev1.TimeOfDay",
 			msg: &examplev1.ExampleTimeOfDay{
@@ -107,7 +139,309 @@ func Test_MarshalSymmetric(t *testing.T) {
 				got = append(got, msg)
 			}
 			assert.Equal(t, len(got), 1)
-			assert.DeepEqual(t, tt.msg, got[0], protocmp.Transform())
+			assert.DeepEqual(t, tt.msg, got[0], protocmp.Transform(), cmpopts.EquateNaNs())
+		})
+	}
+}
+
+func TestMarshalOptions_FloatPrecision(t *testing.T) {
+	msg := &publicv1.LondonBicycleStation{
+		Latitude:  51.529163,
+		Longitude: -0.10997,
+	}
+	schema, err := protoavro.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	codec, err := goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+
+	t.Run("fixed precision", func(t *testing.T) {
+		opts := protoavro.MarshalOptions{FloatPrecision: 4}
+		datum, err := opts.Encode(msg)
+		assert.NilError(t, err)
+		text, err := codec.TextualFromNative(nil, datum)
+		assert.NilError(t, err)
+		assert.Assert(t, strings.Contains(string(text), `"latitude":{"double":51.53}`), string(text))
+		assert.Assert(t, strings.Contains(string(text), `"longitude":{"double":-0.11}`), string(text))
+	})
+
+	t.Run("fixed precision wrappers", func(t *testing.T) {
+		opts := protoavro.MarshalOptions{FloatPrecision: 3}
+		datum, err := opts.Encode(&examplev1.ExampleWrappers{
+			FloatValue:  wrapperspb.Float(1.23456),
+			DoubleValue: wrapperspb.Double(98765.4321),
+		})
+		assert.NilError(t, err)
+		var got examplev1.ExampleWrappers
+		assert.NilError(t, protoavro.UnmarshalOptions{}.Unmarshal(datum, &got))
+		assert.Equal(t, got.GetFloatValue().GetValue(), float32(1.23))
+		assert.Equal(t, got.GetDoubleValue().GetValue(), 98800.0)
+	})
+
+	t.Run("default round-trips", func(t *testing.T) {
+		msg := &publicv1.LondonBicycleStation{
+			Latitude:  0.1 + 0.2,
+			Longitude: math.SmallestNonzeroFloat64,
+		}
+		datum, err := protoavro.MarshalOptions{}.Encode(msg)
+		assert.NilError(t, err)
+		text, err := codec.TextualFromNative(nil, datum)
+		assert.NilError(t, err)
+		native, _, err := codec.NativeFromTextual(text)
+		assert.NilError(t, err)
+		var got publicv1.LondonBicycleStation
+		assert.NilError(t, protoavro.UnmarshalOptions{}.Unmarshal(native, &got))
+		assert.DeepEqual(t, msg, &got, protocmp.Transform())
+	})
+}
+
+func TestMarshalOptions_OmitEmptyMessages(t *testing.T) {
+	const nestedName = "einride.avro.example.v1.ExampleOptional.Nested"
+	emptyNested := map[string]interface{}{
+		nestedName: map[string]interface{}{"value": map[string]interface{}{"string": ""}},
+	}
+	for _, tt := range []struct {
+		name           string
+		msg            *examplev1.ExampleOptional
+		nested         interface{}
+		optionalNested interface{}
+	}{
+		{
+			name:           "absent",
+			msg:            &examplev1.ExampleOptional{},
+			nested:         nil,
+			optionalNested: nil,
+		},
+		{
+			name: "empty but present",
+			msg: &examplev1.ExampleOptional{
+				Nested:         &examplev1.ExampleOptional_Nested{},
+				OptionalNested: &examplev1.ExampleOptional_Nested{},
+			},
+			nested:         nil,
+			optionalNested: emptyNested,
+		},
+		{
+			name: "not empty",
+			msg: &examplev1.ExampleOptional{
+				Nested: &examplev1.ExampleOptional_Nested{Value: "a"},
+			},
+			nested: map[string]interface{}{
+				nestedName: map[string]interface{}{"value": map[string]interface{}{"string": "a"}},
+			},
+			optionalNested: nil,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := protoavro.MarshalOptions{
+				SchemaOptions:     protoavro.SchemaOptions{OmitRootElement: true},
+				OmitEmptyMessages: true,
+			}
+			datum, err := opts.Encode(tt.msg)
+			assert.NilError(t, err)
+			record := datum.(map[string]interface{})
+			assert.DeepEqual(t, tt.nested, record["nested"])
+			assert.DeepEqual(t, tt.optionalNested, record["optional_nested"])
 		})
 	}
+
+	t.Run("disabled", func(t *testing.T) {
+		opts := protoavro.MarshalOptions{SchemaOptions: protoavro.SchemaOptions{OmitRootElement: true}}
+		datum, err := opts.Encode(&examplev1.ExampleOptional{Nested: &examplev1.ExampleOptional_Nested{}})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, emptyNested, datum.(map[string]interface{})["nested"])
+	})
+}
+
+func TestMarshalOptions_PostProcess(t *testing.T) {
+	msg := &library.Book{Name: "shelves/1/books/1", Title: "Harry Potter"}
+	t.Run("add and mutate keys", func(t *testing.T) {
+		opts := protoavro.MarshalOptions{
+			PostProcess: func(datum map[string]interface{}) error {
+				title := datum["title"].(map[string]interface{})["string"].(string)
+				datum["title"] = map[string]interface{}{"string": strings.ToUpper(title)}
+				datum["title_length"] = map[string]interface{}{"long": int64(len(title))}
+				return nil
+			},
+		}
+		datum, err := opts.Encode(msg)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, map[string]interface{}{
+			"google.example.library.v1.Book": map[string]interface{}{
+				"name":         map[string]interface{}{"string": "shelves/1/books/1"},
+				"author":       map[string]interface{}{"string": ""},
+				"title":        map[string]interface{}{"string": "HARRY POTTER"},
+				"read":         map[string]interface{}{"boolean": false},
+				"title_length": map[string]interface{}{"long": int64(12)},
+			},
+		}, datum)
+	})
+
+	t.Run("only top-level record", func(t *testing.T) {
+		var calls int
+		opts := protoavro.MarshalOptions{
+			SchemaOptions: protoavro.SchemaOptions{OmitRootElement: true},
+			PostProcess: func(datum map[string]interface{}) error {
+				calls++
+				_, ok := datum["book"]
+				assert.Assert(t, ok)
+				return nil
+			},
+		}
+		_, err := opts.Encode(&library.UpdateBookRequest{Book: msg})
+		assert.NilError(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		opts := protoavro.MarshalOptions{
+			PostProcess: func(datum map[string]interface{}) error {
+				return fmt.Errorf("missing title")
+			},
+		}
+		_, err := opts.Encode(msg)
+		assert.ErrorContains(t, err, "post process: missing title")
+	})
+}
+
+func BenchmarkUnmarshaler_ReadBufferSize(b *testing.B) {
+	var file bytes.Buffer
+	marshaler, err := protoavro.NewMarshaler((&library.Book{}).ProtoReflect().Descriptor(), &file)
+	assert.NilError(b, err)
+	for i := 0; i < 10000; i++ {
+		assert.NilError(b, marshaler.Marshal(&library.Book{
+			Name:   "shelves/1/books/1",
+			Title:  "Harry Potter",
+			Author: "J. K. Rowling",
+		}))
+	}
+	for _, size := range []int{4 << 10, 64 << 10, 1 << 20} {
+		size := size
+		b.Run(fmt.Sprintf("%dKiB", size>>10), func(b *testing.B) {
+			b.SetBytes(int64(file.Len()))
+			for i := 0; i < b.N; i++ {
+				opts := protoavro.UnmarshalOptions{ReadBufferSize: size}
+				unmarshaler, err := opts.NewUnmarshaler(bytes.NewReader(file.Bytes()))
+				assert.NilError(b, err)
+				for unmarshaler.Scan() {
+					var book library.Book
+					assert.NilError(b, unmarshaler.Unmarshal(&book))
+				}
+			}
+		})
+	}
+}
+
+func Test_MarshalWithCanonicalSchema(t *testing.T) {
+	msg := &library.Book{
+		Name:   "shelves/1/books/1",
+		Title:  "Harry Potter",
+		Author: "J. K. Rowling",
+	}
+	opts := protoavro.MarshalOptions{
+		SchemaOptions: protoavro.SchemaOptions{OmitRootElement: true},
+	}
+	datum, canonical, fingerprint, err := protoavro.MarshalWithCanonicalSchema(msg, opts)
+	assert.NilError(t, err)
+	codec, err := goavro.NewCodec(string(canonical))
+	assert.NilError(t, err)
+	assert.Equal(t, codec.CanonicalSchema(), string(canonical))
+	assert.Equal(t, codec.Rabin, fingerprint)
+	// the datum is encoded with the same options as the schema.
+	binary, err := codec.BinaryFromNative(nil, datum)
+	assert.NilError(t, err)
+	decoded, _, err := codec.NativeFromBinary(binary)
+	assert.NilError(t, err)
+	got := &library.Book{}
+	assert.NilError(t, protoavro.UnmarshalOptions{SchemaOptions: opts.SchemaOptions}.Unmarshal(decoded, got))
+	assert.DeepEqual(t, msg, got, protocmp.Transform())
+}
+
+func TestMarshalOptions_MarshalWithKey(t *testing.T) {
+	msg := &library.ListBooksResponse{
+		Books: []*library.Book{
+			{Name: "shelves/1/books/1", Title: "Harry Potter"},
+		},
+	}
+	t.Run("key from nested field", func(t *testing.T) {
+		opts := protoavro.MarshalOptions{
+			PartitionKey: func(message proto.Message) (string, error) {
+				return message.(*library.ListBooksResponse).GetBooks()[0].GetName(), nil
+			},
+		}
+		key, data, err := opts.MarshalWithKey(msg)
+		assert.NilError(t, err)
+		assert.Equal(t, "shelves/1/books/1", key)
+		schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		codec, err := goavro.NewCodec(string(schemaBytes))
+		assert.NilError(t, err)
+		native, _, err := codec.NativeFromBinary(data)
+		assert.NilError(t, err)
+		got := &library.ListBooksResponse{}
+		assert.NilError(t, protoavro.UnmarshalOptions{}.Unmarshal(native, got))
+		assert.DeepEqual(t, msg, got, protocmp.Transform())
+	})
+	t.Run("key extraction error", func(t *testing.T) {
+		opts := protoavro.MarshalOptions{
+			PartitionKey: func(proto.Message) (string, error) {
+				return "", fmt.Errorf("no books")
+			},
+		}
+		_, _, err := opts.MarshalWithKey(msg)
+		assert.ErrorContains(t, err, "partition key: no books")
+	})
+}
+
+func TestMarshalOptions_SpecialFloatAsNull(t *testing.T) {
+	opts := protoavro.MarshalOptions{SpecialFloatAsNull: true}
+
+	t.Run("double fields", func(t *testing.T) {
+		msg := &publicv1.LondonBicycleStation{
+			Latitude:  math.NaN(),
+			Longitude: math.Inf(-1),
+		}
+		schema, err := protoavro.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		codec, err := goavro.NewCodec(string(schemaBytes))
+		assert.NilError(t, err)
+		datum, err := opts.Encode(msg)
+		assert.NilError(t, err)
+		text, err := codec.TextualFromNative(nil, datum)
+		assert.NilError(t, err)
+		assert.Assert(t, strings.Contains(string(text), `"latitude":null`), string(text))
+		assert.Assert(t, strings.Contains(string(text), `"longitude":null`), string(text))
+		// null is decoded as zero, so the special values are lost.
+		var got publicv1.LondonBicycleStation
+		assert.NilError(t, protoavro.UnmarshalOptions{}.Unmarshal(datum, &got))
+		assert.Equal(t, got.GetLatitude(), 0.0)
+		assert.Equal(t, got.GetLongitude(), 0.0)
+	})
+
+	t.Run("wrappers", func(t *testing.T) {
+		datum, err := opts.Encode(&examplev1.ExampleWrappers{
+			FloatValue:  wrapperspb.Float(float32(math.Inf(1))),
+			DoubleValue: wrapperspb.Double(math.NaN()),
+		})
+		assert.NilError(t, err)
+		var got examplev1.ExampleWrappers
+		assert.NilError(t, protoavro.UnmarshalOptions{}.Unmarshal(datum, &got))
+		assert.Assert(t, got.GetFloatValue() == nil)
+		assert.Assert(t, got.GetDoubleValue() == nil)
+	})
+
+	t.Run("finite values", func(t *testing.T) {
+		msg := &publicv1.LondonBicycleStation{Latitude: 51.529163, Longitude: -0.10997}
+		datum, err := opts.Encode(msg)
+		assert.NilError(t, err)
+		var got publicv1.LondonBicycleStation
+		assert.NilError(t, protoavro.UnmarshalOptions{}.Unmarshal(datum, &got))
+		assert.DeepEqual(t, msg, &got, protocmp.Transform())
+	})
 }
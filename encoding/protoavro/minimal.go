@@ -0,0 +1,45 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MinimalSchema returns the JSON encoded Avro schema for desc, like SchemaOptions.InferSchema, with only the
+// fields of the top-level record that are set to a non-default value in at least one of samples, for pruning
+// wide messages down to the columns that are actually used.
+// It is meant for analysis of representative data, not for strict typing: data with fields that were not set
+// in any sample can be encoded with the schema, but the values of those fields are lost.
+func MinimalSchema(
+	desc protoreflect.MessageDescriptor,
+	samples []proto.Message,
+	opts SchemaOptions,
+) (json.RawMessage, error) {
+	used := make(map[protoreflect.FullName]struct{}, desc.Fields().Len())
+	for _, sample := range samples {
+		msg := sample.ProtoReflect()
+		if msg.Descriptor().FullName() != desc.FullName() {
+			return nil, fmt.Errorf("minimal schema: sample of %s for %s", msg.Descriptor().FullName(), desc.FullName())
+		}
+		msg.Range(func(field protoreflect.FieldDescriptor, _ protoreflect.Value) bool {
+			used[field.FullName()] = struct{}{}
+			return true
+		})
+	}
+	s := opts.newSchemaInferrer()
+	s.omitFields = make(map[protoreflect.FullName]struct{}, desc.Fields().Len()-len(used))
+	for i := 0; i < desc.Fields().Len(); i++ {
+		field := desc.Fields().Get(i)
+		if _, ok := used[field.FullName()]; !ok {
+			s.omitFields[field.FullName()] = struct{}{}
+		}
+	}
+	schema, err := s.inferSchema(desc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(schema)
+}
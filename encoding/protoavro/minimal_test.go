@@ -0,0 +1,72 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestMinimalSchema(t *testing.T) {
+	desc := (&library.Book{}).ProtoReflect().Descriptor()
+	t.Run("fields set in samples", func(t *testing.T) {
+		samples := []proto.Message{
+			&library.Book{Name: "books/1", Title: "Harry Potter"},
+			&library.Book{Title: "The Hobbit"},
+		}
+		got, err := MinimalSchema(desc, samples, SchemaOptions{})
+		assert.NilError(t, err)
+		expected, err := json.Marshal(avro.Nullable(avro.Record{
+			Type:      avro.RecordType,
+			Name:      "Book",
+			Namespace: "google.example.library.v1",
+			Fields: []avro.Field{
+				{Name: "name", Type: avro.Nullable(avro.String())},
+				{Name: "title", Type: avro.Nullable(avro.String())},
+			},
+		}))
+		assert.NilError(t, err)
+		assert.Equal(t, string(expected), string(got))
+
+		codec, err := goavro.NewCodec(string(got))
+		assert.NilError(t, err)
+		datum, err := SchemaOptions{}.Encode(&library.Book{Name: "books/2", Title: "Dune", Read: true})
+		assert.NilError(t, err)
+		binary, err := codec.BinaryFromNative(nil, datum)
+		assert.NilError(t, err)
+		native, _, err := codec.NativeFromBinary(binary)
+		assert.NilError(t, err)
+		var decoded library.Book
+		assert.NilError(t, UnmarshalOptions{}.Unmarshal(native, &decoded))
+		assert.DeepEqual(t, &library.Book{Name: "books/2", Title: "Dune"}, &decoded, protocmp.Transform())
+	})
+	t.Run("no samples", func(t *testing.T) {
+		got, err := MinimalSchema(desc, nil, SchemaOptions{})
+		assert.NilError(t, err)
+		_, err = goavro.NewCodec(string(got))
+		assert.NilError(t, err)
+		assert.Equal(t, `[{"type":"null"},{"type":"record","namespace":"google.example.library.v1","name":"Book",`+
+			`"fields":[]}]`, string(got))
+	})
+	t.Run("named types of omitted fields", func(t *testing.T) {
+		msg := &examplev1.ExampleOneof{
+			OneofFields_2: &examplev1.ExampleOneof_OneofEmptyMessage_2{
+				OneofEmptyMessage_2: &examplev1.ExampleOneof_EmptyMessage{},
+			},
+		}
+		got, err := MinimalSchema(msg.ProtoReflect().Descriptor(), []proto.Message{msg}, SchemaOptions{})
+		assert.NilError(t, err)
+		_, err = goavro.NewCodec(string(got))
+		assert.NilError(t, err)
+	})
+	t.Run("sample of other message", func(t *testing.T) {
+		_, err := MinimalSchema(desc, []proto.Message{&library.Shelf{}}, SchemaOptions{})
+		assert.ErrorContains(t, err, "sample of google.example.library.v1.Shelf for google.example.library.v1.Book")
+	})
+}
@@ -0,0 +1,333 @@
+package protoavro
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// NameSource is a name of protobuf fields that record field names are matched against on decode.
+type NameSource int
+
+const (
+	// NameSourceJSON matches the JSON name of fields.
+	NameSourceJSON NameSource = iota
+	// NameSourceText matches the text name of fields, which is the field name for non-group fields.
+	NameSourceText
+)
+
+// String returns a human readable name of the name source.
+func (n NameSource) String() string {
+	switch n {
+	case NameSourceJSON:
+		return "JSON name"
+	case NameSourceText:
+		return "text name"
+	}
+	return fmt.Sprintf("NameSource(%d)", int(n))
+}
+
+// FieldNameCase is a transformation of protobuf field names that record field names are matched against
+// on decode, for data from producers with other naming conventions.
+type FieldNameCase int
+
+const (
+	// FieldNameAsIs matches field names without transformation.
+	FieldNameAsIs FieldNameCase = iota
+	// FieldNameCamelToPascal matches the JSON names of fields in PascalCase, such as "DisplayName",
+	// as emitted by .NET producers.
+	FieldNameCamelToPascal
+	// FieldNameSnakeToCamel matches the text names of fields in camelCase, such as "displayName",
+	// regardless of custom JSON names.
+	FieldNameSnakeToCamel
+	// FieldNameSnakeToPascal matches the text names of fields in PascalCase, such as "DisplayName",
+	// regardless of custom JSON names.
+	FieldNameSnakeToPascal
+	// FieldNameCamelToSnake matches the names of fields in camelCase, such as "displayName",
+	// in snake_case, such as "display_name", for messages that do not follow the protobuf style guide.
+	FieldNameCamelToSnake
+)
+
+// String returns a human readable name of the field name case.
+func (c FieldNameCase) String() string {
+	switch c {
+	case FieldNameAsIs:
+		return "as is"
+	case FieldNameCamelToPascal:
+		return "camel to Pascal"
+	case FieldNameSnakeToCamel:
+		return "snake to camel"
+	case FieldNameSnakeToPascal:
+		return "snake to Pascal"
+	case FieldNameCamelToSnake:
+		return "camel to snake"
+	}
+	return fmt.Sprintf("FieldNameCase(%d)", int(c))
+}
+
+// transform returns the name that field is matched against.
+func (c FieldNameCase) transform(field protoreflect.FieldDescriptor) string {
+	switch c {
+	case FieldNameCamelToPascal:
+		name := field.JSONName()
+		return strings.ToUpper(name[:1]) + name[1:]
+	case FieldNameSnakeToCamel:
+		return snakeToCamel(field.TextName(), false)
+	case FieldNameSnakeToPascal:
+		return snakeToCamel(field.TextName(), true)
+	case FieldNameCamelToSnake:
+		var b strings.Builder
+		for _, r := range string(field.Name()) {
+			if unicode.IsUpper(r) {
+				if b.Len() > 0 {
+					b.WriteByte('_')
+				}
+				r = unicode.ToLower(r)
+			}
+			b.WriteRune(r)
+		}
+		return b.String()
+	}
+	return string(field.Name())
+}
+
+// snakeToCamel returns name in camelCase, or in PascalCase if upper is true.
+func snakeToCamel(name string, upper bool) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r == '_':
+			upper = b.Len() > 0
+		case upper:
+			b.WriteRune(unicode.ToUpper(r))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// findFieldCase returns the field of desc whose name transformed by c is name, or nil if no field matches.
+func findFieldCase(desc protoreflect.MessageDescriptor, name string, c FieldNameCase) protoreflect.FieldDescriptor {
+	for i := 0; i < desc.Fields().Len(); i++ {
+		if fd := desc.Fields().Get(i); c.transform(fd) == name {
+			return fd
+		}
+	}
+	return nil
+}
+
+// defaultNameMatchPriority is used when UnmarshalOptions.NameMatchPriority is empty.
+var defaultNameMatchPriority = []NameSource{NameSourceJSON, NameSourceText}
+
+// findField returns the field of desc matching name, or nil if no field matches.
+// Name sources are tried in the order of o.NameMatchPriority.
+func (o *UnmarshalOptions) findField(
+	desc protoreflect.MessageDescriptor,
+	name string,
+) (protoreflect.FieldDescriptor, error) {
+	priority := o.NameMatchPriority
+	if len(priority) == 0 {
+		priority = defaultNameMatchPriority
+	}
+	var match protoreflect.FieldDescriptor
+	var matchSource NameSource
+	for _, source := range priority {
+		var fd protoreflect.FieldDescriptor
+		switch source {
+		case NameSourceJSON:
+			fd = desc.Fields().ByJSONName(name)
+		case NameSourceText:
+			fd = desc.Fields().ByTextName(name)
+		default:
+			return nil, fmt.Errorf("unknown name source %s", source)
+		}
+		switch {
+		case fd == nil:
+			continue
+		case match == nil:
+			if !o.RejectAmbiguousFieldNames {
+				return fd, nil
+			}
+			match, matchSource = fd, source
+		case match.Number() != fd.Number():
+			return nil, fmt.Errorf(
+				"ambiguous field name %s: %s of field %s and %s of field %s",
+				name, matchSource, match.Name(), source, fd.Name(),
+			)
+		}
+	}
+	if match == nil && len(o.AliasTable) > 0 {
+		fd, err := o.findFieldAlias(desc, name)
+		if fd != nil || err != nil {
+			return fd, err
+		}
+	}
+	if match == nil && len(o.NameNormalization) > 0 {
+		fd, err := o.findFieldNormalized(desc, name)
+		if fd != nil || err != nil {
+			return fd, err
+		}
+	}
+	if match == nil && o.FieldNameCase != FieldNameAsIs {
+		if fd := findFieldCase(desc, name, o.FieldNameCase); fd != nil {
+			return fd, nil
+		}
+	}
+	if match == nil && o.CaseInsensitiveFieldNames {
+		fd, err := findFieldFold(desc, name)
+		if fd != nil || err != nil {
+			return fd, err
+		}
+	}
+	if match == nil && o.ExtensionResolver != nil {
+		return o.findExtension(desc, name)
+	}
+	return match, nil
+}
+
+// findFieldNormalized returns the field of desc whose name transformed by one of NameNormalization is name,
+// or nil if no field matches. Transforms are tried in order, and a name that is matched by different fields
+// under different transforms is rejected.
+func (o *UnmarshalOptions) findFieldNormalized(
+	desc protoreflect.MessageDescriptor,
+	name string,
+) (protoreflect.FieldDescriptor, error) {
+	var match protoreflect.FieldDescriptor
+	var matchCase FieldNameCase
+	for _, c := range o.NameNormalization {
+		fd := findFieldCase(desc, name, c)
+		switch {
+		case fd == nil:
+			continue
+		case match == nil:
+			match, matchCase = fd, c
+		case match.Number() != fd.Number():
+			return nil, fmt.Errorf(
+				"ambiguous field name %s: %s name of field %s and %s name of field %s",
+				name, matchCase, match.Name(), c, fd.Name(),
+			)
+		}
+	}
+	return match, nil
+}
+
+// findFieldAlias returns the field of desc that has name as an alias in AliasTable, or nil if no field does.
+func (o *UnmarshalOptions) findFieldAlias(
+	desc protoreflect.MessageDescriptor,
+	name string,
+) (protoreflect.FieldDescriptor, error) {
+	var match protoreflect.FieldDescriptor
+	for i := 0; i < desc.Fields().Len(); i++ {
+		fd := desc.Fields().Get(i)
+		for _, alias := range o.AliasTable[fd.FullName()] {
+			if alias != name {
+				continue
+			}
+			if match != nil {
+				return nil, fmt.Errorf("ambiguous field alias %s: alias of fields %s and %s", name, match.Name(), fd.Name())
+			}
+			match = fd
+		}
+	}
+	return match, nil
+}
+
+// isExtraTopLevelKey returns true if key is one of IgnoreExtraTopLevelKeys.
+func (o *UnmarshalOptions) isExtraTopLevelKey(key string) bool {
+	for _, extra := range o.IgnoreExtraTopLevelKeys {
+		if extra == key {
+			return true
+		}
+	}
+	return false
+}
+
+// recordFullName returns the Avro full name of the record for message.
+func (o SchemaOptions) recordFullName(message protoreflect.MessageDescriptor) string {
+	return o.recordName(message.FullName())
+}
+
+func (o SchemaOptions) recordName(message protoreflect.FullName) string {
+	name, ok := o.RecordNames[message]
+	switch {
+	case !ok:
+		name = o.RecordNamePrefix + string(message.Name()) + o.RecordNameSuffix
+		if message.Parent() == "" {
+			return name
+		}
+	case strings.Contains(name, "."), message.Parent() == "":
+		return name
+	}
+	return string(message.Parent()) + "." + name
+}
+
+// messageName returns the full name of the message that is encoded as the record with the Avro full name.
+func (o SchemaOptions) messageName(recordFullName string) protoreflect.FullName {
+	for message := range o.RecordNames {
+		if o.recordName(message) == recordFullName {
+			return message
+		}
+	}
+	namespace := avro.NamespaceOf(recordFullName)
+	name := strings.TrimPrefix(recordFullName, namespace+".")
+	if !strings.HasPrefix(name, o.RecordNamePrefix) || !strings.HasSuffix(name, o.RecordNameSuffix) ||
+		len(name) <= len(o.RecordNamePrefix)+len(o.RecordNameSuffix) {
+		return protoreflect.FullName(recordFullName)
+	}
+	message := protoreflect.FullName(name[len(o.RecordNamePrefix) : len(name)-len(o.RecordNameSuffix)])
+	if namespace == "" {
+		return message
+	}
+	return protoreflect.FullName(namespace).Append(protoreflect.Name(message))
+}
+
+// isFrozenEnumSymbol returns true if symbol is one of the FreezeEnumSymbols of enum,
+// or if the symbols of enum are not frozen.
+func (o SchemaOptions) isFrozenEnumSymbol(enum protoreflect.EnumDescriptor, symbol string) bool {
+	frozen, ok := o.FreezeEnumSymbols[enum.FullName()]
+	if !ok {
+		return true
+	}
+	for _, frozenSymbol := range frozen {
+		if frozenSymbol == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// registerRecord returns an error if fullName is not a valid Avro full name,
+// or if it is already the name of the record of another message.
+func (s schemaInferrer) registerRecord(fullName string, message protoreflect.MessageDescriptor) error {
+	for _, part := range strings.Split(fullName, ".") {
+		if !isAvroName(part) {
+			return fmt.Errorf("message %s: invalid Avro record name '%s'", message.FullName(), fullName)
+		}
+	}
+	if other, ok := s.records[fullName]; ok && other != message.FullName() {
+		return fmt.Errorf("message %s: record name '%s' is already used by %s", message.FullName(), fullName, other)
+	}
+	s.records[fullName] = message.FullName()
+	return nil
+}
+
+// isAvroName returns true if name matches [A-Za-z_][A-Za-z0-9_]*.
+func isAvroName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r == '_', 'a' <= r && r <= 'z', 'A' <= r && r <= 'Z':
+		case '0' <= r && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
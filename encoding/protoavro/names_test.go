@@ -0,0 +1,456 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnmarshalOptions_NameMatchPriority(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		opts        UnmarshalOptions
+		data        map[string]interface{}
+		expected    *examplev1.ExampleNameCollision
+		errContains string
+	}{
+		{
+			name:     "default prefers JSON name",
+			data:     map[string]interface{}{"title": "a"},
+			expected: &examplev1.ExampleNameCollision{DisplayName: "a"},
+		},
+		{
+			name:     "text name first",
+			opts:     UnmarshalOptions{NameMatchPriority: []NameSource{NameSourceText, NameSourceJSON}},
+			data:     map[string]interface{}{"title": "a"},
+			expected: &examplev1.ExampleNameCollision{Title: "a"},
+		},
+		{
+			name:     "JSON name only",
+			opts:     UnmarshalOptions{NameMatchPriority: []NameSource{NameSourceJSON}},
+			data:     map[string]interface{}{"heading": "a"},
+			expected: &examplev1.ExampleNameCollision{Title: "a"},
+		},
+		{
+			name:        "text name only",
+			opts:        UnmarshalOptions{NameMatchPriority: []NameSource{NameSourceText}},
+			data:        map[string]interface{}{"heading": "a"},
+			errContains: "unexpected field heading",
+		},
+		{
+			name:        "reject ambiguous",
+			opts:        UnmarshalOptions{RejectAmbiguousFieldNames: true},
+			data:        map[string]interface{}{"title": "a"},
+			errContains: "ambiguous field name title: JSON name of field display_name and text name of field title",
+		},
+		{
+			name: "reject ambiguous with unambiguous names",
+			opts: UnmarshalOptions{RejectAmbiguousFieldNames: true},
+			data: map[string]interface{}{"display_name": "a", "heading": "b"},
+			expected: &examplev1.ExampleNameCollision{
+				DisplayName: "a",
+				Title:       "b",
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleNameCollision
+			err := tt.opts.Unmarshal(tt.data, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, &got, protocmp.Transform())
+		})
+	}
+}
+
+func TestUnmarshalOptions_AliasTable(t *testing.T) {
+	aliases := map[protoreflect.FullName][]string{
+		"google.example.library.v1.Book.title":  {"headline", "heading", "name"},
+		"google.example.library.v1.Book.author": {"writer"},
+	}
+	for _, tt := range []struct {
+		name        string
+		aliases     map[protoreflect.FullName][]string
+		data        map[string]interface{}
+		expected    *library.Book
+		errContains string
+	}{
+		{
+			name:    "renamed field",
+			aliases: aliases,
+			data: map[string]interface{}{
+				"headline": map[string]interface{}{"string": "Harry Potter"},
+				"writer":   map[string]interface{}{"string": "J. K. Rowling"},
+			},
+			expected: &library.Book{Title: "Harry Potter", Author: "J. K. Rowling"},
+		},
+		{
+			name:     "field name precedes alias",
+			aliases:  aliases,
+			data:     map[string]interface{}{"name": map[string]interface{}{"string": "shelves/1/books/1"}},
+			expected: &library.Book{Name: "shelves/1/books/1"},
+		},
+		{
+			name: "conflicting aliases",
+			aliases: map[protoreflect.FullName][]string{
+				"google.example.library.v1.Book.title":  {"label"},
+				"google.example.library.v1.Book.author": {"label"},
+			},
+			data:        map[string]interface{}{"label": "a"},
+			errContains: "ambiguous field alias label: alias of fields author and title",
+		},
+		{
+			name:        "without aliases",
+			data:        map[string]interface{}{"headline": "a"},
+			errContains: "unexpected field headline",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got library.Book
+			err := UnmarshalOptions{AliasTable: tt.aliases}.Unmarshal(tt.data, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, &got, protocmp.Transform())
+		})
+	}
+}
+
+func TestUnmarshalOptions_FieldNameCase(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		nameCase    FieldNameCase
+		msg         proto.Message
+		data        map[string]interface{}
+		expected    proto.Message
+		errContains string
+	}{
+		{
+			name:     "camel to Pascal",
+			nameCase: FieldNameCamelToPascal,
+			msg:      &examplev1.ExampleList{},
+			data: map[string]interface{}{
+				"Int64List":  []interface{}{int64(1)},
+				"StringList": []interface{}{"a"},
+			},
+			expected: &examplev1.ExampleList{Int64List: []int64{1}, StringList: []string{"a"}},
+		},
+		{
+			name:     "camel to Pascal uses JSON names",
+			nameCase: FieldNameCamelToPascal,
+			msg:      &examplev1.ExampleNameCollision{},
+			data:     map[string]interface{}{"Heading": "a"},
+			expected: &examplev1.ExampleNameCollision{Title: "a"},
+		},
+		{
+			name:     "snake to camel uses text names",
+			nameCase: FieldNameSnakeToCamel,
+			msg:      &examplev1.ExampleNameCollision{},
+			data:     map[string]interface{}{"displayName": "a"},
+			expected: &examplev1.ExampleNameCollision{DisplayName: "a"},
+		},
+		{
+			name:     "exact names precede",
+			nameCase: FieldNameCamelToPascal,
+			msg:      &examplev1.ExampleList{},
+			data:     map[string]interface{}{"int64_list": []interface{}{int64(1)}},
+			expected: &examplev1.ExampleList{Int64List: []int64{1}},
+		},
+		{
+			name:        "as is",
+			msg:         &examplev1.ExampleList{},
+			data:        map[string]interface{}{"Int64List": []interface{}{int64(1)}},
+			errContains: "unexpected field Int64List",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.msg.ProtoReflect().New().Interface()
+			err := UnmarshalOptions{FieldNameCase: tt.nameCase}.Unmarshal(tt.data, got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got, protocmp.Transform())
+		})
+	}
+}
+
+func TestUnmarshalOptions_NameNormalization(t *testing.T) {
+	opts := UnmarshalOptions{
+		NameMatchPriority: []NameSource{NameSourceText, NameSourceJSON},
+		NameNormalization: []FieldNameCase{
+			FieldNameAsIs,
+			FieldNameSnakeToCamel,
+			FieldNameSnakeToPascal,
+			FieldNameCamelToPascal,
+		},
+	}
+	for _, tt := range []struct {
+		name        string
+		data        map[string]interface{}
+		expected    proto.Message
+		errContains string
+	}{
+		{
+			name:     "snake case",
+			data:     map[string]interface{}{"display_name": "a", "title": "b"},
+			expected: &examplev1.ExampleNameCollision{DisplayName: "a", Title: "b"},
+		},
+		{
+			name:     "camel case",
+			data:     map[string]interface{}{"displayName": "a", "heading": "b"},
+			expected: &examplev1.ExampleNameCollision{DisplayName: "a", Title: "b"},
+		},
+		{
+			name:     "Pascal case",
+			data:     map[string]interface{}{"DisplayName": "a", "Heading": "b"},
+			expected: &examplev1.ExampleNameCollision{DisplayName: "a", Title: "b"},
+		},
+		{
+			name: "ambiguous",
+			data: map[string]interface{}{"Title": "a"},
+			errContains: "ambiguous field name Title: " +
+				"snake to Pascal name of field title and camel to Pascal name of field display_name",
+		},
+		{
+			name:        "unknown",
+			data:        map[string]interface{}{"display-name": "a"},
+			errContains: "display-name",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleNameCollision
+			err := opts.Unmarshal(tt.data, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, &got, protocmp.Transform())
+		})
+	}
+}
+
+func TestSchemaOptions_RecordNames(t *testing.T) {
+	t.Run("recursive reference", func(t *testing.T) {
+		opts := SchemaOptions{
+			RecordNames: map[protoreflect.FullName]string{
+				"einride.avro.example.v1.ExampleRecursive": "com.example.Node",
+			},
+		}
+		msg := &examplev1.ExampleRecursive{Recursive: &examplev1.ExampleRecursive{}}
+		schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, avro.Nullable(avro.Record{
+			Type:      avro.RecordType,
+			Name:      "Node",
+			Namespace: "com.example",
+			Fields: []avro.Field{
+				{Name: "recursive", Type: avro.Nullable(avro.Reference("com.example.Node"))},
+			},
+		}), schema)
+		assertRoundTrip(t, opts, schema, msg)
+	})
+
+	t.Run("nested record", func(t *testing.T) {
+		opts := SchemaOptions{
+			RecordNames: map[protoreflect.FullName]string{
+				"einride.avro.example.v1.ExampleMap.Nested": "NestedRecord",
+			},
+		}
+		msg := &examplev1.ExampleMap{
+			StringToNested: map[string]*examplev1.ExampleMap_Nested{
+				"a": {StringToString: map[string]string{"b": "c"}},
+			},
+		}
+		schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		assert.Assert(t, strings.Contains(
+			string(schemaBytes),
+			`"namespace":"einride.avro.example.v1.ExampleMap","name":"NestedRecord"`,
+		))
+		assert.Assert(t, !strings.Contains(string(schemaBytes), `"name":"Nested"`))
+		assertRoundTrip(t, opts, schema, msg)
+	})
+
+	t.Run("union", func(t *testing.T) {
+		opts := SchemaOptions{
+			RecordNames: map[protoreflect.FullName]string{
+				"einride.avro.example.v1.ExampleEnum": "com.example.Enum",
+			},
+		}
+		msg := &examplev1.ExampleEnum{EnumValue: examplev1.ExampleEnum_ENUM_VALUE1}
+		schema, err := opts.UnionSchema([]protoreflect.MessageDescriptor{msg.ProtoReflect().Descriptor()})
+		assert.NilError(t, err)
+		native := assertRoundTrip(t, opts, schema, msg)
+		got, err := UnmarshalOptions{SchemaOptions: opts}.UnmarshalUnion(native, protoregistry.GlobalTypes)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, msg, got, protocmp.Transform())
+	})
+
+	for _, tt := range []struct {
+		name        string
+		names       map[protoreflect.FullName]string
+		errContains string
+	}{
+		{
+			name:        "invalid name",
+			names:       map[protoreflect.FullName]string{"einride.avro.example.v1.ExampleMap.Nested": "com.1example.Nested"},
+			errContains: "invalid Avro record name 'com.1example.Nested'",
+		},
+		{
+			name:        "duplicate name",
+			names:       map[protoreflect.FullName]string{"einride.avro.example.v1.ExampleMap.Nested": "StringToStringEntry"},
+			errContains: "record name 'einride.avro.example.v1.ExampleMap.StringToStringEntry' is already used",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := SchemaOptions{RecordNames: tt.names}.InferSchema((&examplev1.ExampleMap{}).ProtoReflect().Descriptor())
+			assert.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
+
+// assertRoundTrip asserts that msg encodes to data conforming to schema that decodes to msg,
+// and returns the decoded native data.
+func assertRoundTrip(t *testing.T, opts SchemaOptions, schema avro.Schema, msg proto.Message) interface{} {
+	t.Helper()
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	codec, err := goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+	datum, err := opts.Encode(msg)
+	assert.NilError(t, err)
+	binary, err := codec.BinaryFromNative(nil, datum)
+	assert.NilError(t, err)
+	native, _, err := codec.NativeFromBinary(binary)
+	assert.NilError(t, err)
+	got := msg.ProtoReflect().New().Interface()
+	assert.NilError(t, UnmarshalOptions{SchemaOptions: opts}.Unmarshal(native, got))
+	assert.DeepEqual(t, msg, got, protocmp.Transform())
+	return native
+}
+
+func TestSchemaOptions_RecordNamePrefixSuffix(t *testing.T) {
+	t.Run("nested records", func(t *testing.T) {
+		opts := SchemaOptions{RecordNameSuffix: "Value"}
+		msg := &examplev1.ExampleMap{
+			StringToNested: map[string]*examplev1.ExampleMap_Nested{
+				"a": {StringToString: map[string]string{"b": "c"}},
+			},
+		}
+		schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		for _, expected := range []string{
+			`"namespace":"einride.avro.example.v1","name":"ExampleMapValue"`,
+			`"namespace":"einride.avro.example.v1.ExampleMap","name":"NestedValue"`,
+			`"namespace":"einride.avro.example.v1.ExampleMap.Nested","name":"StringToStringEntryValue"`,
+		} {
+			assert.Assert(t, strings.Contains(string(schemaBytes), expected), expected)
+		}
+		native := assertRoundTrip(t, opts, schema, msg)
+		_, ok := native.(map[string]interface{})["einride.avro.example.v1.ExampleMapValue"]
+		assert.Assert(t, ok)
+	})
+
+	t.Run("recursive reference", func(t *testing.T) {
+		opts := SchemaOptions{RecordNamePrefix: "Avro", RecordNameSuffix: "Value"}
+		msg := &examplev1.ExampleRecursive{Recursive: &examplev1.ExampleRecursive{}}
+		schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, avro.Nullable(avro.Record{
+			Type:      avro.RecordType,
+			Name:      "AvroExampleRecursiveValue",
+			Namespace: "einride.avro.example.v1",
+			Fields: []avro.Field{
+				{
+					Name: "recursive",
+					Type: avro.Nullable(avro.Reference("einride.avro.example.v1.AvroExampleRecursiveValue")),
+				},
+			},
+		}), schema)
+		assertRoundTrip(t, opts, schema, msg)
+	})
+
+	t.Run("overridden name", func(t *testing.T) {
+		opts := SchemaOptions{
+			RecordNameSuffix: "Value",
+			RecordNames: map[protoreflect.FullName]string{
+				"einride.avro.example.v1.ExampleEnum": "com.example.Enum",
+			},
+		}
+		assert.Equal(t, "com.example.Enum", opts.recordName("einride.avro.example.v1.ExampleEnum"))
+		assert.Equal(t, protoreflect.FullName("einride.avro.example.v1.ExampleEnum"), opts.messageName("com.example.Enum"))
+	})
+
+	t.Run("union", func(t *testing.T) {
+		opts := SchemaOptions{RecordNamePrefix: "Avro"}
+		msg := &examplev1.ExampleEnum{EnumValue: examplev1.ExampleEnum_ENUM_VALUE1}
+		schema, err := opts.UnionSchema([]protoreflect.MessageDescriptor{msg.ProtoReflect().Descriptor()})
+		assert.NilError(t, err)
+		native := assertRoundTrip(t, opts, schema, msg)
+		got, err := UnmarshalOptions{SchemaOptions: opts}.UnmarshalUnion(native, protoregistry.GlobalTypes)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, msg, got, protocmp.Transform())
+	})
+
+	t.Run("invalid prefix", func(t *testing.T) {
+		_, err := SchemaOptions{RecordNamePrefix: "1"}.InferSchema((&examplev1.ExampleEnum{}).ProtoReflect().Descriptor())
+		assert.ErrorContains(t, err, "invalid Avro record name 'einride.avro.example.v1.1ExampleEnum'")
+	})
+}
+
+func TestSchemaOptions_AliasBothNames(t *testing.T) {
+	opts := SchemaOptions{AliasBothNames: true}
+	schema, err := opts.InferSchema((&examplev1.ExampleList{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	aliases := make(map[string][]string)
+	for _, field := range schema.(avro.Union)[1].(avro.Record).Fields {
+		aliases[field.Name] = field.Aliases
+	}
+	assert.DeepEqual(t, map[string][]string{
+		"int64_list":       {"int64List"},
+		"string_list":      {"stringList"},
+		"enum_list":        {"enumList"},
+		"nested_list":      {"nestedList"},
+		"float_value_list": {"floatValueList"},
+	}, aliases)
+	nested, err := opts.InferSchema((&examplev1.ExampleNested{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assert.Assert(t, nested.(avro.Union)[1].(avro.Record).Fields[0].Aliases == nil)
+	msg := &examplev1.ExampleList{Int64List: []int64{1}, StringList: []string{"a"}}
+	assertRoundTrip(t, opts, schema, msg)
+	t.Run("cross-convention data", func(t *testing.T) {
+		var got examplev1.ExampleList
+		assert.NilError(t, UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{
+			"int64List":   map[string]interface{}{"array": []interface{}{int64(1)}},
+			"string_list": map[string]interface{}{"array": []interface{}{"a"}},
+		}, &got))
+		assert.DeepEqual(t, msg, &got, protocmp.Transform())
+	})
+}
@@ -0,0 +1,82 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// jsonMessageProp is the custom field property that holds the message full name of fields that are
+// encoded as protojson strings, beyond SchemaOptions.MaxNestDepth.
+const jsonMessageProp = "proto.jsonMessage"
+
+// isJSONMessageField returns true if field is a message field that is encoded as a protojson string,
+// because the record of the message would be nested at depth, beyond MaxNestDepth.
+func (o SchemaOptions) isJSONMessageField(field protoreflect.FieldDescriptor, depth int) bool {
+	if o.MaxNestDepth <= 0 || depth <= o.MaxNestDepth {
+		return false
+	}
+	if field.IsMap() || field.Message() == nil || o.isExpandedWKT(field.Message().FullName()) {
+		return false
+	}
+	if protoBytes, _ := o.isProtoBytesField(field); protoBytes {
+		return false
+	}
+	return !o.isEmptyAsNullField(field)
+}
+
+// withNestDepths returns a copy of o that holds the depths of the records in the schema of desc.
+// Named records are defined once, so whether a message field is encoded as a protojson string
+// depends on the depth of the record that defines it, not the depth of the value being encoded.
+func (o MarshalOptions) withNestDepths(desc protoreflect.MessageDescriptor) (MarshalOptions, error) {
+	if o.MaxNestDepth <= 0 {
+		return o, nil
+	}
+	depths, err := o.recordDepths(desc)
+	if err != nil {
+		return o, err
+	}
+	o.nestDepths = depths
+	return o, nil
+}
+
+// recordDepths returns the depths of the records in the schema of desc by message full name.
+func (o SchemaOptions) recordDepths(desc protoreflect.MessageDescriptor) (map[protoreflect.FullName]int, error) {
+	s := o.newSchemaInferrer()
+	if _, err := s.inferSchema(desc); err != nil {
+		return nil, err
+	}
+	return s.depths, nil
+}
+
+// nestDepth returns the depth that the record of the message field would be defined at.
+func (o MarshalOptions) nestDepth(field protoreflect.FieldDescriptor, recursiveIndex int) int {
+	if depth, ok := o.nestDepths[field.ContainingMessage().FullName()]; ok {
+		return depth + 1
+	}
+	return recursiveIndex
+}
+
+// messageProtoJSON encodes the message value of field as a protojson string.
+func (o MarshalOptions) messageProtoJSON(field protoreflect.FieldDescriptor, message protoreflect.Message) (
+	interface{},
+	error,
+) {
+	if !message.IsValid() {
+		return nil, nil
+	}
+	data, err := protojson.Marshal(message.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("field %s: protojson marshal: %w", field.Name(), err)
+	}
+	return o.unionValue("string", string(data)), nil
+}
+
+// decodeProtoJSON decodes a protojson string into the message value of field.
+func decodeProtoJSON(field protoreflect.FieldDescriptor, str string, message protoreflect.Message) error {
+	if err := protojson.Unmarshal([]byte(str), message.Interface()); err != nil {
+		return fmt.Errorf("field %s: protojson unmarshal: %w", field.Name(), err)
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package protoavro
+
+import (
+	"testing"
+
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_MaxNestDepth(t *testing.T) {
+	opts := SchemaOptions{MaxNestDepth: 2}
+	schema, err := opts.InferSchema((&examplev1.ExampleNested{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	level1 := schema.(avro.Union)[1].(avro.Record).Fields[0].Type.(avro.Union)[1].(avro.Record)
+	level2 := level1.Fields[0].Type.(avro.Union)[1].(avro.Record)
+	assert.Equal(t, "Level2", level2.Name)
+	assert.DeepEqual(t, avro.Field{
+		Name:  "level3",
+		Type:  avro.Nullable(avro.String()),
+		Props: map[string]interface{}{jsonMessageProp: "einride.avro.example.v1.ExampleNested.Level3"},
+	}, level2.Fields[0])
+	entry := level2.Fields[1].Type.(avro.Union)[1].(avro.Array).Items.(avro.Record)
+	assert.DeepEqual(t, avro.Nullable(avro.String()), entry.Fields[1].Type)
+	msg := &examplev1.ExampleNested{
+		Name: "a",
+		Level1: &examplev1.ExampleNested_Level1{
+			Level2: &examplev1.ExampleNested_Level2{
+				Level3:    &examplev1.ExampleNested_Level3{Value: "b"},
+				Level3Map: map[string]*examplev1.ExampleNested_Level3{"c": {Value: "d"}},
+			},
+			Level2List: []*examplev1.ExampleNested_Level2{
+				{Level3: &examplev1.ExampleNested_Level3{Value: "e"}},
+			},
+		},
+	}
+	t.Run("round trip", func(t *testing.T) {
+		native := assertRoundTrip(t, opts, schema, msg)
+		record := native.(map[string]interface{})["einride.avro.example.v1.ExampleNested"].(map[string]interface{})
+		level1 := record["level1"].(map[string]interface{})["einride.avro.example.v1.ExampleNested.Level1"]
+		level2 := level1.(map[string]interface{})["level2"].(map[string]interface{})
+		level3 := level2["einride.avro.example.v1.ExampleNested.Level2"].(map[string]interface{})["level3"]
+		var got examplev1.ExampleNested_Level3
+		assert.NilError(t, protojson.Unmarshal([]byte(level3.(map[string]interface{})["string"].(string)), &got))
+		assert.Equal(t, "b", got.Value)
+	})
+	t.Run("unset", func(t *testing.T) {
+		assertRoundTrip(t, opts, schema, &examplev1.ExampleNested{
+			Level1: &examplev1.ExampleNested_Level1{Level2: &examplev1.ExampleNested_Level2{}},
+		})
+	})
+	t.Run("invalid json", func(t *testing.T) {
+		var got examplev1.ExampleNested_Level2
+		err := UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{
+			"level3": map[string]interface{}{"string": "{"},
+		}, &got)
+		assert.ErrorContains(t, err, "field level3: protojson unmarshal")
+	})
+	t.Run("recursive", func(t *testing.T) {
+		// records are flattened by the depth they are defined at, so references to recursive
+		// records stay records at any depth.
+		opts := SchemaOptions{MaxNestDepth: 1}
+		schema, err := opts.InferSchema((&examplev1.ExampleRecursive{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		assertRoundTrip(t, opts, schema, &examplev1.ExampleRecursive{
+			Recursive: &examplev1.ExampleRecursive{
+				Recursive: &examplev1.ExampleRecursive{Recursive: &examplev1.ExampleRecursive{}},
+			},
+		})
+	})
+	t.Run("no limit", func(t *testing.T) {
+		schema, err := SchemaOptions{}.InferSchema((&examplev1.ExampleNested{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		assertRoundTrip(t, SchemaOptions{}, schema, msg)
+	})
+}
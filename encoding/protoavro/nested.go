@@ -0,0 +1,40 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// decodeNestedPayload decodes the bytes of UnmarshalOptions.NestedPayloadField into message field f of val.
+func (d *decoder) decodeNestedPayload(
+	data interface{},
+	val protoreflect.Message,
+	f protoreflect.FieldDescriptor,
+) error {
+	if f.Message() == nil || f.IsList() || f.IsMap() {
+		return fmt.Errorf("field %s: nested payload field is not a singular message field", f.Name())
+	}
+	payload, err := decodeBytesLike(data, string(avro.BytesType))
+	if err != nil {
+		return fmt.Errorf("field %s: nested payload: %w", f.Name(), err)
+	}
+	if d.opts.NestedPayloadCodec != nil {
+		if payload, err = d.opts.NestedPayloadCodec(payload); err != nil {
+			return fmt.Errorf("field %s: nested payload codec: %w", f.Name(), err)
+		}
+	}
+	codec, err := d.opts.newCodec(f.Message())
+	if err != nil {
+		return fmt.Errorf("field %s: nested payload: %w", f.Name(), err)
+	}
+	native, rest, err := codec.NativeFromBinary(payload)
+	if err != nil {
+		return fmt.Errorf("field %s: decode nested payload: %w", f.Name(), err)
+	}
+	if len(rest) > 0 {
+		return fmt.Errorf("field %s: decode nested payload: %d bytes of unexpected data", f.Name(), len(rest))
+	}
+	return d.decodeMessage(native, val.Mutable(f).Message())
+}
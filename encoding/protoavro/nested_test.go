@@ -0,0 +1,68 @@
+package protoavro
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnmarshalOptions_NestedPayloadField(t *testing.T) {
+	book := &library.Book{Name: "shelves/1/books/1", Title: "Nested"}
+	codec, err := SchemaOptions{}.newCodec(book.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	datum, err := MarshalOptions{}.Encode(book)
+	assert.NilError(t, err)
+	inner, err := codec.BinaryFromNative(nil, datum)
+	assert.NilError(t, err)
+	var compressed bytes.Buffer
+	w := gzip.NewWriter(&compressed)
+	_, err = w.Write(inner)
+	assert.NilError(t, err)
+	assert.NilError(t, w.Close())
+
+	gunzip := func(payload []byte) ([]byte, error) {
+		r, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		return io.ReadAll(r)
+	}
+	opts := UnmarshalOptions{
+		NestedPayloadField: "google.example.library.v1.UpdateBookRequest.book",
+		NestedPayloadCodec: gunzip,
+	}
+
+	t.Run("gzipped payload", func(t *testing.T) {
+		var got library.UpdateBookRequest
+		assert.NilError(t, opts.Unmarshal(map[string]interface{}{
+			"book": map[string]interface{}{"bytes": compressed.Bytes()},
+		}, &got))
+		assert.DeepEqual(t, &library.UpdateBookRequest{Book: book}, &got, protocmp.Transform())
+	})
+
+	t.Run("codec error", func(t *testing.T) {
+		err := opts.Unmarshal(map[string]interface{}{
+			"book": map[string]interface{}{"bytes": inner},
+		}, &library.UpdateBookRequest{})
+		assert.ErrorContains(t, err, "field book: nested payload codec: gzip: invalid header")
+	})
+
+	t.Run("invalid payload", func(t *testing.T) {
+		opts := UnmarshalOptions{NestedPayloadField: opts.NestedPayloadField}
+		err := opts.Unmarshal(map[string]interface{}{
+			"book": map[string]interface{}{"bytes": []byte{0x04}},
+		}, &library.UpdateBookRequest{})
+		assert.ErrorContains(t, err, "field book: decode nested payload")
+	})
+
+	t.Run("not a message field", func(t *testing.T) {
+		opts := UnmarshalOptions{NestedPayloadField: "google.example.library.v1.Book.name"}
+		err := opts.Unmarshal(map[string]interface{}{"name": []byte{}}, &library.Book{})
+		assert.ErrorContains(t, err, "field name: nested payload field is not a singular message field")
+	})
+}
@@ -0,0 +1,162 @@
+package protoavro
+
+import (
+	"fmt"
+	"io"
+)
+
+// ocfLimitState is the part of an Avro object container file that ocfLimitReader is reading.
+type ocfLimitState int
+
+const (
+	ocfMagic ocfLimitState = iota
+	ocfMetadataCount
+	ocfMetadataSize
+	ocfMetadataKeyLength
+	ocfMetadataKey
+	ocfMetadataValueLength
+	ocfMetadataValue
+	ocfHeaderSync
+	ocfBlockCount
+	ocfBlockSize
+	ocfBlockData
+	ocfBlockSync
+)
+
+// ocfSyncSize is the size of the sync marker of object container files.
+const ocfSyncSize = 16
+
+// ocfLimitReader tracks the structure of an Avro object container file read through it, and fails
+// before the size of a block that exceeds the limits is passed on, so that readers do not allocate it.
+type ocfLimitReader struct {
+	r             io.Reader
+	maxBlockBytes int64
+	maxBlockCount int64
+	state         ocfLimitState
+	// skip is the number of bytes left of the magic, a string, a block or a sync marker.
+	skip int64
+	// entries is the number of entries left of the current metadata block.
+	entries int64
+	// varint and shift hold the bytes read of the current zig-zag encoded long.
+	varint uint64
+	shift  uint
+	err    error
+}
+
+func newOCFLimitReader(r io.Reader, maxBlockBytes, maxBlockCount int64) *ocfLimitReader {
+	return &ocfLimitReader{
+		r:             r,
+		maxBlockBytes: maxBlockBytes,
+		maxBlockCount: maxBlockCount,
+		state:         ocfMagic,
+		skip:          4,
+	}
+}
+
+func (l *ocfLimitReader) Read(p []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+	n, err := l.r.Read(p)
+	for i := 0; i < n; i++ {
+		if l.err = l.next(p[i]); l.err != nil {
+			// withhold the byte that completes the offending value.
+			if i == 0 {
+				return 0, l.err
+			}
+			return i, nil
+		}
+	}
+	return n, err
+}
+
+// next advances the state with the next byte b of the file.
+func (l *ocfLimitReader) next(b byte) error {
+	switch l.state {
+	case ocfMagic, ocfMetadataKey, ocfMetadataValue, ocfHeaderSync, ocfBlockData, ocfBlockSync:
+		l.skip--
+		if l.skip <= 0 {
+			l.endSkip()
+		}
+		return nil
+	}
+	value, done, err := l.nextLong(b)
+	if err != nil || !done {
+		return err
+	}
+	switch l.state {
+	case ocfMetadataCount:
+		switch {
+		case value == 0:
+			l.startSkip(ocfHeaderSync, ocfSyncSize)
+		case value < 0:
+			// negative counts are followed by the size of the block.
+			l.entries = -value
+			l.state = ocfMetadataSize
+		default:
+			l.entries = value
+			l.state = ocfMetadataKeyLength
+		}
+	case ocfMetadataSize:
+		l.state = ocfMetadataKeyLength
+	case ocfMetadataKeyLength:
+		l.startSkip(ocfMetadataKey, value)
+	case ocfMetadataValueLength:
+		l.startSkip(ocfMetadataValue, value)
+	case ocfBlockCount:
+		if l.maxBlockCount > 0 && value > l.maxBlockCount {
+			return fmt.Errorf("ocf: block count %d exceeds max block count %d", value, l.maxBlockCount)
+		}
+		l.state = ocfBlockSize
+	case ocfBlockSize:
+		if l.maxBlockBytes > 0 && value > l.maxBlockBytes {
+			return fmt.Errorf("ocf: block size %d exceeds max block bytes %d", value, l.maxBlockBytes)
+		}
+		l.startSkip(ocfBlockData, value)
+	}
+	return nil
+}
+
+// nextLong adds b to the current zig-zag encoded long, and returns its value when b is its last byte.
+func (l *ocfLimitReader) nextLong(b byte) (int64, bool, error) {
+	if l.shift >= 64 {
+		return 0, false, fmt.Errorf("ocf: long overflows 64 bits")
+	}
+	l.varint |= uint64(b&0x7f) << l.shift
+	l.shift += 7
+	if b&0x80 != 0 {
+		return 0, false, nil
+	}
+	value := int64(l.varint>>1) ^ -int64(l.varint&1)
+	l.varint, l.shift = 0, 0
+	return value, true, nil
+}
+
+// startSkip skips n bytes in state, or moves past state if n is not positive.
+func (l *ocfLimitReader) startSkip(state ocfLimitState, n int64) {
+	l.state, l.skip = state, n
+	if n <= 0 {
+		l.endSkip()
+	}
+}
+
+// endSkip moves to the state that follows the skipped bytes.
+func (l *ocfLimitReader) endSkip() {
+	switch l.state {
+	case ocfMagic:
+		l.state = ocfMetadataCount
+	case ocfMetadataKey:
+		l.state = ocfMetadataValueLength
+	case ocfMetadataValue:
+		l.entries--
+		if l.entries > 0 {
+			l.state = ocfMetadataKeyLength
+		} else {
+			l.state = ocfMetadataCount
+		}
+	case ocfHeaderSync, ocfBlockSync:
+		l.state = ocfBlockCount
+	case ocfBlockData:
+		l.startSkip(ocfBlockSync, ocfSyncSize)
+	}
+}
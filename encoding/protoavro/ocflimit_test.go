@@ -0,0 +1,63 @@
+package protoavro
+
+import (
+	"bytes"
+	"testing"
+
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnmarshalOptions_MaxBlockBytes(t *testing.T) {
+	msgs := []*library.Book{
+		{Name: "shelves/1/books/1", Title: "Harry Potter"},
+		{Name: "shelves/1/books/2", Title: "Lord of the Rings"},
+	}
+	var file bytes.Buffer
+	marshaler, err := NewMarshaler((&library.Book{}).ProtoReflect().Descriptor(), &file)
+	assert.NilError(t, err)
+	headerSize := file.Len()
+	assert.NilError(t, marshaler.Marshal(msgs[0], msgs[1]))
+
+	t.Run("within limits", func(t *testing.T) {
+		opts := UnmarshalOptions{MaxBlockBytes: 1 << 10, MaxBlockCount: 2}
+		unmarshaler, err := opts.NewUnmarshaler(bytes.NewReader(file.Bytes()))
+		assert.NilError(t, err)
+		var got []*library.Book
+		for unmarshaler.Scan() {
+			var msg library.Book
+			assert.NilError(t, unmarshaler.Unmarshal(&msg))
+			got = append(got, &msg)
+		}
+		assert.NilError(t, unmarshaler.Err())
+		assert.DeepEqual(t, msgs, got, protocmp.Transform())
+	})
+
+	t.Run("block too large", func(t *testing.T) {
+		opts := UnmarshalOptions{MaxBlockBytes: 8}
+		unmarshaler, err := opts.NewUnmarshaler(bytes.NewReader(file.Bytes()))
+		assert.NilError(t, err)
+		assert.Assert(t, !unmarshaler.Scan())
+		assert.ErrorContains(t, unmarshaler.Err(), "ocf: block size")
+	})
+
+	t.Run("too many messages in block", func(t *testing.T) {
+		opts := UnmarshalOptions{MaxBlockCount: 1}
+		unmarshaler, err := opts.NewUnmarshaler(bytes.NewReader(file.Bytes()))
+		assert.NilError(t, err)
+		assert.Assert(t, !unmarshaler.Scan())
+		assert.ErrorContains(t, unmarshaler.Err(), "ocf: block count 2 exceeds max block count 1")
+	})
+
+	t.Run("crafted block size", func(t *testing.T) {
+		crafted := append([]byte(nil), file.Bytes()[:headerSize]...)
+		// a block of one message that declares a size of 1 GiB, as zig-zag encoded longs.
+		crafted = append(crafted, 0x02, 0x80, 0x80, 0x80, 0x80, 0x08)
+		opts := UnmarshalOptions{MaxBlockBytes: 1 << 20}
+		unmarshaler, err := opts.NewUnmarshaler(bytes.NewReader(crafted))
+		assert.NilError(t, err)
+		assert.Assert(t, !unmarshaler.Scan())
+		assert.ErrorContains(t, unmarshaler.Err(), "ocf: block size 1073741824 exceeds max block bytes 1048576")
+	})
+}
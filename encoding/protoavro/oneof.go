@@ -0,0 +1,246 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Field names of the records of oneofs with OneofAsTaggedRecord.
+const (
+	oneofCaseField  = "case"
+	oneofValueField = "value"
+)
+
+// taggedOneofs returns the oneofs of message that are represented as tagged records,
+// which are all oneofs except the synthetic oneofs of proto3 optional fields.
+func (o SchemaOptions) taggedOneofs(message protoreflect.MessageDescriptor) []protoreflect.OneofDescriptor {
+	if !o.OneofAsTaggedRecord || message.IsMapEntry() {
+		return nil
+	}
+	var oneofs []protoreflect.OneofDescriptor
+	for i := 0; i < message.Oneofs().Len(); i++ {
+		if oneof := message.Oneofs().Get(i); !oneof.IsSynthetic() {
+			oneofs = append(oneofs, oneof)
+		}
+	}
+	return oneofs
+}
+
+// taggedOneofRecordName returns the name of the tagged record of oneof, such as KindOneof for the oneof kind.
+// The name of the enum of its cases has the suffix Case.
+func taggedOneofRecordName(oneof protoreflect.OneofDescriptor) string {
+	return snakeToCamel(string(oneof.Name()), true) + "Oneof"
+}
+
+// taggedOneofRecordFullName returns the full name of the tagged record of oneof, in the namespace of the message.
+func taggedOneofRecordFullName(oneof protoreflect.OneofDescriptor) string {
+	return string(oneof.Parent().FullName()) + "." + taggedOneofRecordName(oneof)
+}
+
+// tagOneofFieldSchemas returns the schemas of the fields of message with the fields of each oneof replaced by
+// a tagged record, which takes the place of the first field of the oneof.
+func (o SchemaOptions) tagOneofFieldSchemas(
+	message protoreflect.MessageDescriptor,
+	fields []avro.Field,
+) ([]avro.Field, error) {
+	oneofs := o.taggedOneofs(message)
+	if len(oneofs) == 0 {
+		return fields, nil
+	}
+	records := make(map[protoreflect.FullName]int, len(oneofs))
+	result := make([]avro.Field, 0, len(fields))
+	for _, field := range fields {
+		fd := message.Fields().ByName(protoreflect.Name(field.Name))
+		if fd == nil || fd.ContainingOneof() == nil || fd.ContainingOneof().IsSynthetic() {
+			result = append(result, field)
+			continue
+		}
+		oneof := fd.ContainingOneof()
+		i, ok := records[oneof.FullName()]
+		if !ok {
+			i = len(result)
+			records[oneof.FullName()] = i
+			symbols := make([]string, 0, oneof.Fields().Len())
+			for j := 0; j < oneof.Fields().Len(); j++ {
+				symbols = append(symbols, string(oneof.Fields().Get(j).Name()))
+			}
+			result = append(result, avro.Field{
+				Name: string(oneof.Name()),
+				Doc:  oneof.ParentFile().SourceLocations().ByDescriptor(oneof).LeadingComments,
+				Type: avro.Record{
+					Type:      avro.RecordType,
+					Name:      taggedOneofRecordName(oneof),
+					Namespace: string(message.FullName()),
+					Fields: []avro.Field{
+						{
+							Name: oneofCaseField,
+							Type: avro.Enum{
+								Type:      avro.EnumType,
+								Name:      taggedOneofRecordName(oneof) + "Case",
+								Namespace: string(message.FullName()),
+								Symbols:   symbols,
+							},
+						},
+						{Name: oneofValueField, Type: avro.Union{}},
+					},
+				},
+			})
+		}
+		record := result[i].Type.(avro.Record)
+		value := record.Fields[1].Type.(avro.Union)
+		memberType := nonNullable(field.Type)
+		if union, ok := memberType.(avro.Union); ok {
+			for _, member := range union {
+				value = appendUnionMember(value, member)
+			}
+		} else {
+			value = appendUnionMember(value, memberType)
+		}
+		record.Fields[1].Type = value
+		result[i].Type = record
+	}
+	for name, i := range records {
+		record := result[i].Type.(avro.Record)
+		oneof := message.Oneofs().ByName(name.Name())
+		value, err := o.withDefaultMemberFirst(oneof, fields, record.Fields[1].Type.(avro.Union))
+		if err != nil {
+			return nil, err
+		}
+		record.Fields[1].Type = value
+		result[i].Type = avro.Nullable(record)
+	}
+	return result, nil
+}
+
+// withDefaultMemberFirst returns the value union of the tagged record of oneof with the member type of the
+// OneofDefaultMember of oneof moved first, since Avro defaults of unions must match their first member.
+func (o SchemaOptions) withDefaultMemberFirst(
+	oneof protoreflect.OneofDescriptor,
+	fields []avro.Field,
+	value avro.Union,
+) (avro.Union, error) {
+	member, ok := o.OneofDefaultMember[oneof.FullName()]
+	if !ok {
+		return value, nil
+	}
+	if oneof.Fields().ByName(protoreflect.Name(member)) == nil {
+		return nil, fmt.Errorf("oneof %s: default member %s is not a member of the oneof", oneof.Name(), member)
+	}
+	var memberType avro.Schema
+	for _, field := range fields {
+		if field.Name == member {
+			memberType = nonNullable(field.Type)
+		}
+	}
+	if union, ok := memberType.(avro.Union); ok && len(union) > 0 {
+		memberType = union[0]
+	}
+	key := unionMemberKey(memberType)
+	result := make(avro.Union, 0, len(value))
+	for _, existing := range value {
+		if unionMemberKey(existing) == key {
+			result = append(avro.Union{existing}, result...)
+		} else {
+			result = append(result, existing)
+		}
+	}
+	return result, nil
+}
+
+// appendUnionMember returns union with member appended, unless union already has a member of the same type.
+// Oneofs may have several members of the same type, which the case of the tagged record tells apart.
+func appendUnionMember(union avro.Union, member avro.Schema) avro.Union {
+	key := unionMemberKey(member)
+	for _, existing := range union {
+		if unionMemberKey(existing) == key {
+			return union
+		}
+	}
+	return append(union, member)
+}
+
+// unionMemberKey returns the name that unions tell members apart by: the full name of named types,
+// and the schema itself for other types, so that logical types are distinct from their underlying types
+// as with goavro.
+func unionMemberKey(schema avro.Schema) string {
+	switch schema := schema.(type) {
+	case avro.Record:
+		return schema.Namespace + "." + schema.Name
+	case avro.Enum:
+		return schema.Namespace + "." + schema.Name
+	case avro.Fixed:
+		return schema.Namespace + "." + schema.Name
+	case avro.Reference:
+		return string(schema)
+	}
+	data, _ := json.Marshal(schema)
+	return string(data)
+}
+
+// tagOneofRecord replaces the values of the fields of each oneof in the encoded record of message
+// with a tagged record of the case and value of the field that is set.
+func (o MarshalOptions) tagOneofRecord(message protoreflect.Message, record map[string]interface{}) {
+	for _, oneof := range o.taggedOneofs(message.Descriptor()) {
+		var value interface{}
+		set := message.WhichOneof(oneof)
+		if set != nil {
+			value = record[string(set.Name())]
+		}
+		for i := 0; i < oneof.Fields().Len(); i++ {
+			delete(record, string(oneof.Fields().Get(i).Name()))
+		}
+		if set == nil || value == nil {
+			record[string(oneof.Name())] = nil
+			continue
+		}
+		record[string(oneof.Name())] = o.unionValue(taggedOneofRecordFullName(oneof), map[string]interface{}{
+			oneofCaseField:  string(set.Name()),
+			oneofValueField: value,
+		})
+	}
+}
+
+// untagOneofs returns record with the values of tagged oneof records moved back to the fields of their case.
+func (o *UnmarshalOptions) untagOneofs(
+	message protoreflect.MessageDescriptor,
+	record map[string]interface{},
+) (map[string]interface{}, error) {
+	oneofs := o.taggedOneofs(message)
+	if len(oneofs) == 0 {
+		return record, nil
+	}
+	untagged := make(map[string]interface{}, len(record))
+	for name, value := range record {
+		untagged[name] = value
+	}
+	for _, oneof := range oneofs {
+		data, ok := untagged[string(oneof.Name())]
+		if !ok {
+			continue
+		}
+		delete(untagged, string(oneof.Name()))
+		if data == nil {
+			continue
+		}
+		tagged, ok := data.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("field %s: expected record encoded as map[string]interface{}, got %T", oneof.Name(), data)
+		}
+		fullName := taggedOneofRecordFullName(oneof)
+		if unwrapped, ok := tagged[fullName].(map[string]interface{}); ok && len(tagged) == 1 {
+			tagged = unwrapped
+		}
+		oneofCase, err := decodeStringLike(tagged[oneofCaseField], fullName+"Case")
+		if err != nil {
+			return nil, fmt.Errorf("field %s: case: %w", oneof.Name(), err)
+		}
+		if oneof.Fields().ByName(protoreflect.Name(oneofCase)) == nil {
+			return nil, fmt.Errorf("field %s: unknown case %s", oneof.Name(), oneofCase)
+		}
+		untagged[oneofCase] = tagged[oneofValueField]
+	}
+	return untagged, nil
+}
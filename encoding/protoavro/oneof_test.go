@@ -0,0 +1,236 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_OneofAsTaggedRecord(t *testing.T) {
+	opts := SchemaOptions{OneofAsTaggedRecord: true}
+	schema, err := opts.InferSchema((&examplev1.ExampleOneof{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	var expected interface{}
+	assert.NilError(t, json.Unmarshal([]byte(`[
+		{"type": "null"},
+		{
+			"type": "record",
+			"namespace": "einride.avro.example.v1",
+			"name": "ExampleOneof",
+			"fields": [
+				{
+					"name": "oneof_fields_1",
+					"type": [
+						{"type": "null"},
+						{
+							"type": "record",
+							"namespace": "einride.avro.example.v1.ExampleOneof",
+							"name": "OneofFields1Oneof",
+							"fields": [
+								{
+									"name": "case",
+									"type": {
+										"type": "enum",
+										"namespace": "einride.avro.example.v1.ExampleOneof",
+										"name": "OneofFields1OneofCase",
+										"symbols": ["oneof_empty_message_1", "oneof_bool_1"]
+									}
+								},
+								{
+									"name": "value",
+									"type": [
+										{
+											"type": "record",
+											"namespace": "einride.avro.example.v1.ExampleOneof",
+											"name": "EmptyMessage",
+											"fields": []
+										},
+										{"type": "boolean"}
+									]
+								}
+							]
+						}
+					]
+				},
+				{
+					"name": "oneof_fields_2",
+					"type": [
+						{"type": "null"},
+						{
+							"type": "record",
+							"namespace": "einride.avro.example.v1.ExampleOneof",
+							"name": "OneofFields2Oneof",
+							"fields": [
+								{
+									"name": "case",
+									"type": {
+										"type": "enum",
+										"namespace": "einride.avro.example.v1.ExampleOneof",
+										"name": "OneofFields2OneofCase",
+										"symbols": ["oneof_empty_message_2", "oneof_message"]
+									}
+								},
+								{
+									"name": "value",
+									"type": [
+										"einride.avro.example.v1.ExampleOneof.EmptyMessage",
+										{
+											"type": "record",
+											"namespace": "einride.avro.example.v1.ExampleOneof",
+											"name": "Message",
+											"fields": [{"name": "string_value", "type": [{"type": "null"}, {"type": "string"}]}]
+										}
+									]
+								}
+							]
+						}
+					]
+				}
+			]
+		}
+	]`), &expected))
+	var got interface{}
+	assert.NilError(t, json.Unmarshal(schemaBytes, &got))
+	assert.DeepEqual(t, expected, got)
+	for _, tt := range []struct {
+		name         string
+		msg          *examplev1.ExampleOneof
+		expectedCase map[string]interface{}
+	}{
+		{
+			name: "empty message 1",
+			msg: &examplev1.ExampleOneof{
+				OneofFields_1: &examplev1.ExampleOneof_OneofEmptyMessage_1{
+					OneofEmptyMessage_1: &examplev1.ExampleOneof_EmptyMessage{},
+				},
+			},
+			expectedCase: map[string]interface{}{"oneof_fields_1": "oneof_empty_message_1"},
+		},
+		{
+			name: "bool and message",
+			msg: &examplev1.ExampleOneof{
+				OneofFields_1: &examplev1.ExampleOneof_OneofBool_1{OneofBool_1: true},
+				OneofFields_2: &examplev1.ExampleOneof_OneofMessage{
+					OneofMessage: &examplev1.ExampleOneof_Message{StringValue: "a"},
+				},
+			},
+			expectedCase: map[string]interface{}{
+				"oneof_fields_1": "oneof_bool_1",
+				"oneof_fields_2": "oneof_message",
+			},
+		},
+		{
+			name: "empty message 2",
+			msg: &examplev1.ExampleOneof{
+				OneofFields_2: &examplev1.ExampleOneof_OneofEmptyMessage_2{
+					OneofEmptyMessage_2: &examplev1.ExampleOneof_EmptyMessage{},
+				},
+			},
+			expectedCase: map[string]interface{}{"oneof_fields_2": "oneof_empty_message_2"},
+		},
+		{
+			name:         "unset",
+			msg:          &examplev1.ExampleOneof{},
+			expectedCase: map[string]interface{}{},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			native := assertRoundTrip(t, opts, schema, tt.msg)
+			record := native.(map[string]interface{})["einride.avro.example.v1.ExampleOneof"].(map[string]interface{})
+			cases := map[string]interface{}{}
+			for _, name := range []string{"oneof_fields_1", "oneof_fields_2"} {
+				tagged, ok := record[name].(map[string]interface{})
+				if !ok {
+					assert.Assert(t, record[name] == nil)
+					continue
+				}
+				for _, value := range tagged {
+					cases[name] = value.(map[string]interface{})["case"]
+				}
+			}
+			assert.DeepEqual(t, tt.expectedCase, cases)
+		})
+	}
+	t.Run("unknown case", func(t *testing.T) {
+		var got examplev1.ExampleOneof
+		err := UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{
+			"oneof_fields_1": map[string]interface{}{"case": "oneof_message", "value": nil},
+		}, &got)
+		assert.ErrorContains(t, err, "field oneof_fields_1: unknown case oneof_message")
+	})
+	t.Run("without option", func(t *testing.T) {
+		msg := &examplev1.ExampleOneof{OneofFields_1: &examplev1.ExampleOneof_OneofBool_1{OneofBool_1: true}}
+		datum, err := SchemaOptions{}.Encode(msg)
+		assert.NilError(t, err)
+		var got examplev1.ExampleOneof
+		assert.NilError(t, UnmarshalOptions{}.Unmarshal(datum, &got))
+		assert.Assert(t, proto.Equal(msg, &got))
+	})
+}
+
+func TestSchemaOptions_OneofDefaultMember(t *testing.T) {
+	valueField := func(t *testing.T, schema avro.Schema) *avro.Field {
+		t.Helper()
+		oneof := schema.(avro.Union)[1].(avro.Record).Fields[0]
+		assert.Equal(t, "oneof_fields_1", oneof.Name)
+		return &oneof.Type.(avro.Union)[1].(avro.Record).Fields[1]
+	}
+	opts := SchemaOptions{
+		OneofAsTaggedRecord: true,
+		OneofDefaultMember: map[protoreflect.FullName]string{
+			"einride.avro.example.v1.ExampleOneof.oneof_fields_1": "oneof_bool_1",
+		},
+	}
+	schema, err := opts.InferSchema((&examplev1.ExampleOneof{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	value := valueField(t, schema)
+	assert.Equal(t, avro.Boolean(), value.Type.(avro.Union)[0])
+	assert.Equal(t, "EmptyMessage", value.Type.(avro.Union)[1].(avro.Record).Name)
+	assertRoundTrip(t, opts, schema, &examplev1.ExampleOneof{
+		OneofFields_1: &examplev1.ExampleOneof_OneofBool_1{OneofBool_1: true},
+	})
+	assertRoundTrip(t, opts, schema, &examplev1.ExampleOneof{
+		OneofFields_1: &examplev1.ExampleOneof_OneofEmptyMessage_1{
+			OneofEmptyMessage_1: &examplev1.ExampleOneof_EmptyMessage{},
+		},
+	})
+
+	t.Run("default validity", func(t *testing.T) {
+		for _, tt := range []struct {
+			name  string
+			opts  SchemaOptions
+			valid bool
+		}{
+			{name: "default member first", opts: opts, valid: true},
+			{name: "field order", opts: SchemaOptions{OneofAsTaggedRecord: true}},
+		} {
+			schema, err := tt.opts.InferSchema((&examplev1.ExampleOneof{}).ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			valueField(t, schema).Props = map[string]interface{}{"default": false}
+			schemaBytes, err := json.Marshal(schema)
+			assert.NilError(t, err)
+			_, err = goavro.NewCodec(string(schemaBytes))
+			assert.Equal(t, tt.valid, err == nil, tt.name)
+		}
+	})
+
+	t.Run("unknown member", func(t *testing.T) {
+		opts := SchemaOptions{
+			OneofAsTaggedRecord: true,
+			OneofDefaultMember: map[protoreflect.FullName]string{
+				"einride.avro.example.v1.ExampleOneof.oneof_fields_1": "oneof_message",
+			},
+		}
+		_, err := opts.InferSchema((&examplev1.ExampleOneof{}).ProtoReflect().Descriptor())
+		assert.ErrorContains(t, err, "oneof oneof_fields_1: default member oneof_message is not a member of the oneof")
+	})
+}
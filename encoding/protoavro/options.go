@@ -1,7 +1,564 @@
 package protoavro
 
+import (
+	"encoding/json"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
 // SchemaOptions contains configuration options for Avro schema inference.
 // OmitRootElement is used to determine whether the root element of a message should be omitted, when writing to Avro.
 type SchemaOptions struct {
 	OmitRootElement bool
+	// BytesEncoding determines how bytes fields are represented in Avro.
+	// Defaults to BytesRaw.
+	BytesEncoding BytesEncoding
+	// RepeatedAsMap encodes repeated message fields as Avro maps instead of arrays.
+	// It maps the full name of a repeated message field to the name of a string field
+	// of the message type, whose value is used as the map key.
+	// Keys must be unique within a list, and decoded lists are ordered by key.
+	RepeatedAsMap map[string]string
+	// RecordNames overrides the Avro names of the records for messages, keyed by message full name.
+	// A name without a namespace keeps the namespace of the message.
+	// The names are used consistently for record definitions, references and union branches.
+	RecordNames map[protoreflect.FullName]string
+	// RecordNamePrefix and RecordNameSuffix are added to the names of records that are not
+	// overridden by RecordNames, for example to follow schema registry subject conventions
+	// such as "OrderValue". Namespaces, including those derived from enclosing messages, are unchanged.
+	RecordNamePrefix string
+	RecordNameSuffix string
+	// AnnotateOneofs adds a custom "proto.oneof" property with the name of the oneof to
+	// the fields of oneof members, so that consumers can reconstruct the grouping.
+	// Synthetic oneofs of proto3 optional fields are not annotated.
+	AnnotateOneofs bool
+	// UseFieldBehavior gives fields annotated with the google.api.field_behavior REQUIRED
+	// non-nullable schemas, and encodes them without union wrappers. Unset required messages
+	// are encoded as empty records. Other fields, including OPTIONAL ones, stay nullable,
+	// as do oneof members and well-known types.
+	UseFieldBehavior bool
+	// NonNullableProto2Required gives proto2 required fields non-nullable schemas, like UseFieldBehavior
+	// does for fields annotated as REQUIRED, since their values are always present. Proto2 optional fields
+	// stay nullable. Missing required fields are rejected on decode with UnmarshalOptions.EnforceProto2Required.
+	NonNullableProto2Required bool
+	// Int64AsString encodes 64-bit integer fields as decimal strings, so that they are not rounded
+	// by consumers that represent numbers as doubles, such as JavaScript.
+	// Decoding accepts both strings and longs for 64-bit integer fields.
+	Int64AsString bool
+	// BoolAsInt encodes bool fields as Avro ints, 1 for true and 0 for false, for warehouse schemas
+	// that store booleans as integers. Decoding accepts both ints and booleans for bool fields,
+	// and rejects ints other than 0 and 1.
+	BoolAsInt bool
+	// IncludeFieldNumbers adds a custom "proto.fieldNumber" property with the protobuf field number
+	// to every record field, so that consumers can map fields back to protobuf wire positions.
+	IncludeFieldNumbers bool
+	// AnnotateDeprecated adds a custom "proto.deprecated" property with the value true to fields
+	// with the deprecated option, so that downstream tooling can surface them. The fields are still
+	// included in the schema, and the property is ignored on decode.
+	AnnotateDeprecated bool
+	// AnnotateProtoTypes adds a custom "proto.type" property with the protobuf type, such as "sint32" or
+	// "fixed64", to scalar and enum fields, including repeated fields and the keys and values of maps,
+	// so that downstream systems can tell apart the protobuf types that share the Avro types int and long.
+	// The property is ignored on decode.
+	AnnotateProtoTypes bool
+	// SortEnumSymbols sorts the symbols of enums lexically instead of in declaration order, for registries
+	// that canonicalize enums by symbol order. Sorted enums have an explicit default symbol, the name of
+	// the zero enum value, since it is no longer the first symbol.
+	// Binary Avro encodes enums by symbol index, so sorting changes the encoding of existing enum values,
+	// and adding a value may change the encoding of others. Data written with sorted and unsorted schemas
+	// can only be read with schema resolution.
+	SortEnumSymbols bool
+	// FreezeEnumSymbols pins the symbols of enums, keyed by enum full name, to an exact list in order,
+	// regardless of the values that are added to or removed from the protobuf enum, so that schema
+	// fingerprints and binary encodings stay stable. Frozen enums are not sorted by SortEnumSymbols.
+	// Encoding an enum value that is not a frozen symbol fails, as does decoding such a symbol.
+	// Frozen symbols that are not values of the enum are decoded as the zero enum value.
+	FreezeEnumSymbols map[protoreflect.FullName][]string
+	// AllScalarsNullable keeps the schemas of all singular scalar and enum fields nullable, for sinks that
+	// require nullable columns. Fields are nullable by default, so this only affects fields that
+	// UseFieldBehavior would make non-nullable. Null values are decoded as unset fields, which for fields
+	// without presence is the zero value.
+	AllScalarsNullable bool
+	// ScalarPresence determines whether singular scalar and enum fields have nullable schemas based on
+	// their presence, for example to give only proto3 optional fields nullable schemas.
+	// Defaults to ScalarPresenceNullable.
+	ScalarPresence ScalarPresence
+	// FixedFields maps the full names of bytes fields to sizes in bytes, for fields with values of a
+	// fixed size, such as hashes and UUIDs. The fields are represented as Avro fixed types named by the
+	// field full name, and values of other sizes are rejected on encode and decode.
+	FixedFields map[string]int
+	// MapEncoding determines how map fields are represented in Avro.
+	// Defaults to MapAsEntryArray.
+	MapEncoding MapEncoding
+	// NonNullableMapValues gives the values of map entries non-nullable schemas, since protobuf maps can not
+	// hold absent values, instead of nullable ones. Values of wrapper types, such as google.protobuf.FloatValue,
+	// stay nullable. Null values in data from other schemas are decoded according to
+	// UnmarshalOptions.NullMapValuePolicy.
+	NonNullableMapValues bool
+	// BytesLogicalTypes maps the full names of bytes fields to custom Avro logical types, such as "protobuf"
+	// or "json", that mark the content type of the bytes for downstream catalogs. The logical types apply to
+	// fields represented as Avro bytes, and readers that do not know them read the fields as plain bytes.
+	// Values can be validated on decode with UnmarshalOptions.BytesLogicalTypeValidators.
+	BytesLogicalTypes map[string]string
+	// TimestampMillis encodes timestamps as milliseconds since the epoch, with the timestamp-millis
+	// logical type, instead of microseconds. Timestamps with sub-millisecond precision are rejected
+	// on encode, unless MarshalOptions.TruncateTimestampsToMillis is set.
+	TimestampMillis bool
+	// TimestampDual encodes timestamps as a record with both an ISO-8601 string and microseconds since
+	// the epoch, {"iso": string, "micros": long}, for consumers that differ in which representation they read.
+	// Decoding reads either field, preferring micros when both are set.
+	// Unlike the long encodings, the record does not sort in time order, since Avro compares records by their
+	// first field, and the fractional seconds of the ISO-8601 strings vary in length.
+	TimestampDual bool
+	// TimestampEpoch, when set, encodes timestamps as offsets from the epoch instead of the Unix epoch,
+	// for domains such as GPS time. Offsets are microseconds, or milliseconds with TimestampMillis,
+	// encoded as plain longs since the timestamp logical types are relative to the Unix epoch.
+	// The epoch is documented in the custom field property proto.timestampEpoch.
+	// It does not apply with TimestampDual.
+	TimestampEpoch time.Time
+	// LogicalTypeProps are custom properties of Avro logical types, keyed by logical type name such as
+	// "timestamp-micros", for Java tooling that expects properties such as "java-class" or "connect.name".
+	// The properties are emitted on every use of the logical type, and are not part of the Parsing
+	// Canonical Form of schemas.
+	LogicalTypeProps map[string]map[string]interface{}
+	// SizeHints are maximum lengths of string and bytes fields, keyed by field full name, for consumers
+	// that provision fixed-width columns. The lengths are emitted as the custom field property maxLength,
+	// and are enforced on decode with UnmarshalOptions.EnforceSizeHints.
+	SizeHints map[string]int
+	// FieldDocs are docs of fields, keyed by field full name, for descriptors without source comments,
+	// such as those of generated Go code. Leading comments of fields take precedence when present.
+	FieldDocs map[string]string
+	// FieldOrder lists names of fields of the top-level record in the order they are emitted in the schema,
+	// for example as captured by UnmarshalOptions.CaptureFieldOrder. Since Avro records are written in
+	// schema order, re-encoding with the schema preserves the field order of the source data.
+	// Fields that are not listed follow in declaration order, and names of unknown fields are ignored.
+	FieldOrder []string
+	// SubjectNameStrategy computes the schema registry subjects returned by Subject, for tooling that
+	// publishes inferred schemas. TopicNameStrategy is used when nil.
+	SubjectNameStrategy SubjectNameStrategy
+	// Envelope, when set, nests messages in an envelope record with metadata fields.
+	// Schemas are inferred for the envelope, messages are encoded as its payload field,
+	// and decoding unwraps the payload and skips the metadata fields.
+	Envelope *Envelope
+	// EncryptedFields are the full names of singular string and bytes fields with sensitive values,
+	// which are encrypted with MarshalOptions.FieldEncryptor and decrypted with
+	// UnmarshalOptions.FieldDecryptor. The fields are represented as nullable bytes that hold
+	// the ciphertext, and are marked with the custom property "proto.encrypted".
+	EncryptedFields []string
+	// GroupByPrefix nests fields of messages whose names share a prefix, such as "address_", in records
+	// named by the mapped record name, such as "Address", for cleaner schemas of wide flat messages.
+	// The record field that holds the group is named by the prefix without a trailing underscore,
+	// and its fields by the field names without the prefix. Fields are grouped by the longest matching
+	// prefix. Decoding moves the values of nested groups back to the grouped fields.
+	GroupByPrefix map[string]string
+	// DelimitedRepeatedFields maps the full names of repeated string fields to delimiters, for stores
+	// derived from flat files that hold lists as single joined strings. The fields are represented as
+	// strings with the elements joined by the delimiter, and decoding splits them. Backslashes and the first
+	// byte of the delimiter are escaped in elements with a backslash. Empty lists are joined to the empty
+	// string, as are lists of a single empty element, which decode as empty lists.
+	DelimitedRepeatedFields map[string]string
+	// NestedProtoBytesFields maps the full names of message fields to their message types, for data where
+	// the values of the fields are serialized protobuf messages in Avro bytes. The fields are represented as
+	// bytes holding the protobuf binary encoding of the message, marked with the custom field property
+	// proto.binaryMessage, and decoding unmarshals the bytes into the field.
+	NestedProtoBytesFields map[string]protoreflect.MessageType
+	// OpaqueWKTs are well-known types, such as google.protobuf.Timestamp, that are represented as records
+	// of their fields like other messages, instead of logical types or other special representations,
+	// for consumers that can not handle them.
+	OpaqueWKTs []protoreflect.FullName
+	// AliasBothNames adds the JSON name of fields as an alias to record fields, when it differs from the
+	// protobuf field name that record fields are named by, so that Avro readers resolve data written with
+	// either naming convention. Decoding matches both names regardless, as set by NameMatchPriority.
+	AliasBothNames bool
+	// OneofAsTaggedRecord represents each oneof, other than the synthetic oneofs of proto3 optional fields,
+	// as a single nullable field named by the oneof, instead of a nullable field per member. The field holds
+	// a record with the fields "case", an enum of the member names, and "value", a union of the member types,
+	// for consumers that prefer explicit discrimination. Members of the same type share a union member.
+	OneofAsTaggedRecord bool
+	// OneofDefaultMember maps the full names of oneofs to the names of their members whose types are placed
+	// first in the value unions of OneofAsTaggedRecord, since Avro defaults of unions must match their first
+	// member. By default, member types are in field order. Decoding does not depend on the order.
+	OneofDefaultMember map[protoreflect.FullName]string
+	// EmbedTypeName adds a non-nullable string field holding the full name of the protobuf message to every
+	// record, except map entries, so that consumers of unions of types can discriminate records without
+	// relying on record names. The field is named by TypeNameField. Decoding rejects records where the field
+	// holds the name of another message, and does not require it.
+	EmbedTypeName bool
+	// TypeNameField is the name of the field added by EmbedTypeName. Defaults to "_type".
+	TypeNameField string
+	// AppendChecksum adds a non-nullable int field __crc32 to every record, except map entries, holding the
+	// CRC-32 (IEEE) checksum of the deterministic protobuf binary encoding of the message, so that consumers
+	// can detect corrupted records. Checksums are verified on decode with UnmarshalOptions.VerifyChecksum.
+	AppendChecksum bool
+	// EmptyAsNull represents singular google.protobuf.Empty fields, which carry no data, as booleans that
+	// are true when the field is set, instead of empty records. The Avro null type itself can not
+	// tell set fields from unset ones. Decoding sets the field to an empty message when the value is true.
+	EmptyAsNull bool
+	// EnumIntFields maps the full names of integer fields that hold enum values by convention to the
+	// symbols of the enum. The fields are represented as Avro enums named by the field full name,
+	// and values are converted to and from symbols by their index. Values without a symbol are rejected.
+	EnumIntFields map[string][]string
+	// EnumDefaults overrides the default symbol of enums, keyed by enum full name, which Avro readers
+	// fall back to for unknown symbols, when the zero value is not the safest fallback.
+	// Unknown symbols are also decoded as the default symbol. The default must be one of the symbols.
+	EnumDefaults map[protoreflect.FullName]string
+	// MaxNestDepth limits the nesting of records, for sinks that reject deeply nested schemas.
+	// Message fields whose records would be nested deeper than MaxNestDepth below the root record are
+	// represented as strings holding the protojson encoding of the message, and documented in the custom
+	// field property proto.jsonMessage. Decoding parses the strings back into messages.
+	// Zero means no limit.
+	MaxNestDepth int
+	// PrintReferences prints named types that are used more than once as references to their first
+	// definition in the documents returned by Schema, instead of repeating their definitions, for compact
+	// documents. By default the documents are self-contained, for tools that do not resolve references.
+	PrintReferences bool
+}
+
+// MarshalOptions contains configuration options for encoding protobuf messages as Avro.
+// The embedded SchemaOptions determine the schema that encoded messages conform to.
+type MarshalOptions struct {
+	SchemaOptions
+	// FloatPrecision is the number of significant digits that float and double values are rounded to.
+	// Rounded values have compact and stable textual encodings, for example in test fixtures.
+	// Zero means no rounding, so that values round-trip exactly.
+	FloatPrecision int
+	// SpecialFloatAsNull encodes NaN and infinite float and double values as null, for sinks that reject
+	// non-finite numbers. This is lossy: null values decode as zero, or as unset wrappers.
+	// Fields that are required by SchemaOptions.UseFieldBehavior are not nullable, and are rejected instead.
+	SpecialFloatAsNull bool
+	// OmitEmptyMessages encodes message fields that are set but only contain default values as null.
+	// It does not apply to fields with explicit presence from a oneof or the proto3 optional keyword,
+	// or to well-known types.
+	OmitEmptyMessages bool
+	// RedactOption is a boolean field option extension that marks fields with sensitive values.
+	// Values of fields with the option set to true are not encoded: singular string fields
+	// are encoded as RedactedPlaceholder and other fields as null.
+	RedactOption protoreflect.ExtensionType
+	// ClampTimestampToRange encodes timestamps outside the valid range of google.protobuf.Timestamp,
+	// from year 1 to year 9999, as the closest valid timestamp. When false, such timestamps are rejected.
+	ClampTimestampToRange bool
+	// PostProcess, when set, is called with the top-level record of every encoded message before it is
+	// wrapped in a union and written, so that callers can add computed fields or validate values.
+	// Added fields must be part of the schema that the data is written with.
+	// An error fails the encoding of the message.
+	PostProcess func(datum map[string]interface{}) error
+	// TruncateTimestampsToMillis truncates timestamps with sub-millisecond precision when encoding with
+	// SchemaOptions.TimestampMillis, instead of rejecting them.
+	TruncateTimestampsToMillis bool
+	// EnvelopeValues are the values of the extra fields of SchemaOptions.Envelope, keyed by field name,
+	// in Avro native form. Extra fields without values are encoded as null.
+	EnvelopeValues map[string]interface{}
+	// FieldEncryptor encrypts the values of SchemaOptions.EncryptedFields, as UTF-8 for string fields.
+	// It is required when encoding messages with encrypted fields.
+	FieldEncryptor func(fd protoreflect.FieldDescriptor, plaintext []byte) ([]byte, error)
+	// PartitionKey, when set, returns the partition key of messages encoded with MarshalWithKey,
+	// typically derived from message fields. An error fails the encoding of the message.
+	PartitionKey func(message proto.Message) (string, error)
+	// KeyFields are the names of the fields of messages that MarshalKey encodes as key records,
+	// for change data capture and other producers that separate keys from values.
+	KeyFields []string
+	// MapOrder determines the order of the entries of encoded map fields.
+	// Defaults to MapOrderKeyString.
+	MapOrder MapOrder
+	// nestDepths holds the depths of the records in the schema of the encoded message, for MaxNestDepth.
+	nestDepths map[protoreflect.FullName]int
+}
+
+// UnmarshalOptions contains configuration options for decoding Avro data into protobuf messages.
+// The embedded SchemaOptions determine the schema that decoded data is expected to conform to.
+//
+// Null values leave fields unset. Empty bytes, including empty strings and data URLs, are decoded
+// as empty, non-nil byte slices, so that bytes fields with explicit presence are set.
+type UnmarshalOptions struct {
+	SchemaOptions
+	// WriterSchema is the Avro schema that the data was written with.
+	// When set, data is strictly validated against the schema before it is decoded,
+	// and data that does not conform is rejected even if it could be coerced into the message.
+	// Values must have the Go types that goavro decodes Avro values into, such as int32 for int and float32 for float,
+	// so that, for example, numbers decoded from JSON as float64 are rejected for int fields.
+	// The schema describes the data as written, including any record that is removed by UnwrapKey or Envelope.
+	WriterSchema json.RawMessage
+	// CaptureFieldOrder, when non-nil, receives the names of the fields of the top-level record in the
+	// order of the writer schema, so that re-encoding with SchemaOptions.FieldOrder preserves it.
+	// Decoded values do not record field order, so WriterSchema must be set, except when reading
+	// files with an Unmarshaler, where the schema of the file is used, and validated against.
+	CaptureFieldOrder *[]string
+	// NestedPayloadField is the full name of a singular message field, such as "example.v1.Envelope.payload",
+	// that is written as an Avro bytes value holding the Avro binary encoding of the message, with the schema
+	// inferred for the message type, optionally compressed or otherwise encoded.
+	// The bytes are decoded with NestedPayloadCodec before they are decoded into the field.
+	NestedPayloadField string
+	// NestedPayloadCodec decodes the bytes of NestedPayloadField into Avro binary data, for example by
+	// decompressing them. When nil, the bytes are used as is.
+	NestedPayloadCodec func(payload []byte) ([]byte, error)
+	// MaxStringLength is the maximum length in bytes of decoded string values, including map keys and strings
+	// that encode other types, such as enum symbols, timestamps and Int64AsString values.
+	// Longer strings are rejected. Zero means unlimited.
+	MaxStringLength int
+	// MaxBytesLength is the maximum length in bytes of decoded bytes values, after decoding from
+	// representations such as data URLs. Longer values are rejected. Zero means unlimited.
+	MaxBytesLength int
+	// BytesLogicalTypeValidators validate decoded values of bytes fields with SchemaOptions.BytesLogicalTypes,
+	// keyed by logical type name. Values are passed through unchanged, and errors are returned for the field.
+	BytesLogicalTypeValidators map[string]func([]byte) error
+	// EnforceSizeHints rejects string and bytes values longer than their SchemaOptions.SizeHints,
+	// in bytes, like MaxStringLength and MaxBytesLength do for all fields.
+	EnforceSizeHints bool
+	// Discriminator selects the message type of records decoded with UnmarshalDiscriminated.
+	Discriminator Discriminator
+	// AutoDetectTimestampPrecision interprets numeric timestamps as seconds, milliseconds,
+	// microseconds or nanoseconds since the Unix epoch based on their order of magnitude,
+	// for data from producers that disagree on timestamp precision.
+	// Absolute values below 1e11 are seconds, below 1e14 milliseconds, below 1e17 microseconds,
+	// and nanoseconds otherwise.
+	// The detection is a heuristic: for example, millisecond timestamps within a few years
+	// of the epoch are interpreted as seconds. When false, timestamps are microseconds.
+	AutoDetectTimestampPrecision bool
+	// CaptureEnumSymbols, when non-nil, is populated with the symbol of every decoded enum value,
+	// keyed by the path of the field it was decoded into, for example "enum_list[1]".
+	// The symbol is kept as written, even when it is unknown and decoded as the zero enum value.
+	CaptureEnumSymbols map[string]string
+	// NameMatchPriority is the order in which protobuf field names are matched against
+	// record field names. Defaults to the JSON name, then the text name.
+	NameMatchPriority []NameSource
+	// RejectAmbiguousFieldNames rejects record field names that match different fields
+	// through different name sources, instead of picking the first match by NameMatchPriority.
+	RejectAmbiguousFieldNames bool
+	// RequiredFields are the full names of fields that must be present and not null in decoded records,
+	// regardless of protobuf presence rules. Fields of nested messages are only required when the
+	// nested message is present.
+	RequiredFields []string
+	// ReadBufferSize is the size in bytes of the buffer that Unmarshalers read Avro files through.
+	// Larger buffers may improve throughput for large files. Defaults to 64 KiB.
+	ReadBufferSize int
+	// UnknownUnionBranchAsNull treats union values with a branch that the field is never encoded as
+	// like null values, instead of returning an error. This allows decoding data from producers
+	// that have added union branches.
+	UnknownUnionBranchAsNull bool
+	// IgnoreExtraTopLevelKeys are keys of the top-level record that are skipped on decode,
+	// such as metadata added by envelopes alongside the message fields.
+	// Keys of nested records are not affected.
+	IgnoreExtraTopLevelKeys []string
+	// StripEnumNamespace strips a leading enum full name from enum symbols before they are resolved,
+	// for example "einride.avro.example.v1.ExampleEnum.Enum.ENUM_VALUE1" is decoded as "ENUM_VALUE1".
+	StripEnumNamespace bool
+	// Warnings, when non-nil, collects non-fatal observations about lenient decoding decisions,
+	// such as unknown enum symbols decoded as the zero value, integers truncated to 32 bits,
+	// and skipped keys. Each warning is prefixed with the path of the value it concerns.
+	// Warnings do not affect whether decoding succeeds.
+	Warnings *[]string
+	// RawExtras, when non-nil, receives the raw values that could not be decoded into the message,
+	// keyed by their path, such as "book.extra" or "tags[1]", so that callers retain the original data.
+	// Fields that match no field of the message are stored and skipped instead of rejected, and values
+	// of unknown union branches decoded as null by UnknownUnionBranchAsNull are stored as well.
+	RawExtras *map[string]interface{}
+	// RedactOption is a boolean field option extension that marks fields with sensitive values.
+	// Fields with the option set to true that have the value RedactedPlaceholder are decoded as absent.
+	RedactOption protoreflect.ExtensionType
+	// ValidatePatterns rejects values of string fields that do not match the regular expression of their
+	// PatternOption, naming the field. Patterns are compiled once per field descriptor.
+	ValidatePatterns bool
+	// PatternOption is a string field option extension that holds a regular expression, in the syntax
+	// of the regexp package, that values of the field must match when ValidatePatterns is set.
+	PatternOption protoreflect.ExtensionType
+	// UseEnumDefaultOnAbsence decodes singular enum fields that are absent from a record, as when the data was
+	// written with a schema without the field, as the symbol of SchemaOptions.EnumDefaults for the enum,
+	// like Avro readers do, instead of the zero value. Null values still leave fields unset.
+	UseEnumDefaultOnAbsence bool
+	// AbsentEnumMember maps the full names of enums to the names of the members that singular enum fields of
+	// the enum decode as when they are null or absent from a record, instead of the zero value, for schemas
+	// where the zero value is not the unknown value. It takes precedence over UseEnumDefaultOnAbsence.
+	// Members that are not values of the enum are rejected.
+	AbsentEnumMember map[protoreflect.FullName]string
+	// NumericStrings decodes strings such as "42" or "1.5" into numeric fields.
+	NumericStrings bool
+	// LenientBools decodes the strings of BoolStrings, such as "true" and "0",
+	// and the integers 0 and 1 into bool fields. Other strings are rejected.
+	LenientBools bool
+	// BoolStrings are the strings that LenientBools decodes as true and false, for legacy data with forms
+	// such as "Y" and "N". When empty, the strings accepted by strconv.ParseBool and the forms
+	// "yes", "no", "y" and "n" are decoded, ignoring case.
+	BoolStrings BoolStrings
+	// ScalarAsList decodes a single value of a repeated field, written without a surrounding array,
+	// as a list with one element.
+	ScalarAsList bool
+	// DiscardUnknownFields skips record fields that do not match any field of the message,
+	// instead of returning an error.
+	DiscardUnknownFields bool
+	// VerifyChecksum rejects records whose checksum, added by SchemaOptions.AppendChecksum, does not match
+	// the checksum of the decoded message, and records without a checksum. Checksums only match when the
+	// data decodes to the message it was encoded from, so options that drop or change values make them fail.
+	VerifyChecksum bool
+	// CaseInsensitiveFieldNames matches record field names that match no field exactly
+	// against the field names, ignoring case.
+	CaseInsensitiveFieldNames bool
+	// RejectUnknownEnums rejects enum symbols that are not values of the enum,
+	// instead of decoding them as the zero enum value.
+	RejectUnknownEnums bool
+	// EnforceProto2Required rejects records where proto2 required fields are missing or null,
+	// like RequiredFields.
+	EnforceProto2Required bool
+	// MaxDepth is the maximum number of nested records. Deeper records are rejected. Zero means unlimited.
+	MaxDepth int
+	// MaxElements is the maximum number of elements of a repeated or map field.
+	// Larger lists and maps are rejected. Zero means unlimited.
+	MaxElements int
+	// MaxMapEntries is the maximum number of entries of a map field, to bound memory from untrusted input
+	// independently of MaxElements. Larger maps are rejected. Zero means unlimited.
+	MaxMapEntries int
+	// MaxDuration is the maximum time that decoding a single message may take, to bound the time spent on
+	// each record of batch jobs without a context. It is checked before each value is decoded, and decoding
+	// that exceeds it fails with an error that wraps context.DeadlineExceeded. Zero means unlimited.
+	MaxDuration time.Duration
+	// AutoParseStringifiedMessages decodes string values of message fields, from producers that
+	// encode nested records as JSON strings, by parsing them as JSON records first.
+	// Numbers in the parsed records are float64, which decode into integer and double fields.
+	// Well-known types are not affected.
+	AutoParseStringifiedMessages bool
+	// AutoParseStringifiedScalars decodes string values of bool and numeric fields, from producers that
+	// encode scalars as JSON strings, by parsing them as JSON first, so that "123" decodes into int fields
+	// and "true" into bool fields. Double-encoded values such as "\"123\"" are unquoted.
+	// It takes precedence over NumericStrings and LenientBools, which still apply to values that do not
+	// parse as JSON of the field kind. String fields are not affected.
+	AutoParseStringifiedScalars bool
+	// CoercionTable registers conversions of values of scalar fields from the Avro type of the data to the
+	// Avro type of the field, such as from "string" to "long", so that the accepted loose typing of producers
+	// is declared in one place. A conversion applies before the value is decoded as usual, including by
+	// lenient options such as NumericStrings, and errors it returns are returned for the field.
+	CoercionTable map[Coercion]func(interface{}) (interface{}, error)
+	// ValueTransforms are applied in order to the Avro native value of each field, element and map key and
+	// value before it is decoded, so that normalizations such as trimming or unit conversions can be chained.
+	// Values are passed as decoded by goavro, including union wrappers such as {"string": "a"}, and
+	// transforms return them unchanged when they do not apply. Errors are returned for the field.
+	ValueTransforms []func(fd protoreflect.FieldDescriptor, raw interface{}) (interface{}, error)
+	// MapKeyNormalizer, when set, is applied to the keys of map fields with string keys before they are stored,
+	// for example to trim whitespace added by producers.
+	// When normalized keys collide, the last entry wins, unless RejectMapKeyCollisions is set.
+	MapKeyNormalizer func(string) string
+	// RejectMapKeyCollisions rejects map fields where keys collide after MapKeyNormalizer is applied.
+	RejectMapKeyCollisions bool
+	// DataURLMediaTypeFields maps the full names of singular bytes fields to the names of string fields
+	// of the same message, which are set to the media type of data URLs decoded with BytesDataURL.
+	DataURLMediaTypeFields map[string]string
+	// AliasTable lists former names of fields, keyed by field full name, that record fields are also
+	// matched against, so that data written before fields were renamed can be decoded without alias
+	// metadata in the writer schema. Names of fields take precedence over aliases, and an alias of
+	// more than one field of a message is rejected.
+	AliasTable map[protoreflect.FullName][]string
+	// MapToRepeated decodes Avro maps into repeated message fields, like RepeatedAsMap, for data from
+	// producers that encode the fields as maps when the schema options of the consumer do not.
+	// It maps the full name of a repeated message field to the name of a string field of the
+	// message type, which is set to the map key of each element. Arrays are decoded as usual.
+	MapToRepeated map[string]string
+	// ExtensionResolver, when set, matches record field names that match no field of messages with extension
+	// ranges against the names of their extensions, such as "note" for an extension
+	// einride.avro.example.v1.note, so that the values are decoded into the extensions instead of being
+	// unknown fields. Use protoregistry.GlobalTypes to resolve the extensions of generated code.
+	ExtensionResolver ExtensionResolver
+	// FieldNameCase matches record field names that match no field by NameMatchPriority against
+	// field names transformed to another naming convention, such as PascalCase.
+	FieldNameCase FieldNameCase
+	// NameNormalization matches record field names that match no field by NameMatchPriority or AliasTable
+	// against field names transformed by each of the listed naming conventions in order, so that data
+	// decodes regardless of the casing convention of the producer, for example with
+	// []FieldNameCase{FieldNameAsIs, FieldNameSnakeToCamel, FieldNameSnakeToPascal, FieldNameCamelToSnake}.
+	// Names that match different fields under different conventions are rejected as ambiguous.
+	// It takes precedence over FieldNameCase.
+	NameNormalization []FieldNameCase
+	// TimestampInput determines which additional representations of timestamps are accepted.
+	TimestampInput TimestampInput
+	// FailOnPrecisionLoss rejects values that lose precision when they are converted to the type of numeric
+	// fields, for data where silent rounding is not acceptable, such as financial or scientific data:
+	// doubles rounded to float fields, integers truncated to 32-bit or unsigned fields, and doubles
+	// decoded into integer fields that are too large to be exact integers, for example 64-bit integers
+	// that were parsed from JSON. Without it, truncated integers are reported as warnings.
+	// Values that exceed the range of float are handled by FloatOverflowPolicy.
+	FailOnPrecisionLoss bool
+	// FloatOverflowPolicy determines how values that exceed the range of float32 are decoded into float fields,
+	// for example double values written by producers with double fields. Defaults to FloatOverflowAllow.
+	FloatOverflowPolicy FloatOverflowPolicy
+	// NullListElementPolicy determines how null elements of arrays are decoded into repeated fields, for
+	// producers with nullable array items. Defaults to NullListElementZero.
+	NullListElementPolicy NullListElementPolicy
+	// FlattenNestedArrays decodes arrays of arrays into repeated fields by flattening the nested arrays,
+	// for producers that split repeated values into chunks, such as [[1, 2], [3]] for [1, 2, 3].
+	// Arrays nested more than one level deep are rejected.
+	FlattenNestedArrays bool
+	// NullMapValuePolicy determines how null values of map entries are decoded. Defaults to NullMapValueZero.
+	// Null values of maps with scalar values were rejected before the option was added; NullMapValueError,
+	// which Strict sets, keeps rejecting them.
+	NullMapValuePolicy NullMapValuePolicy
+	// LengthPrefixedJSON reads the messages of NewLengthPrefixedDecoder as Avro JSON instead of Avro binary.
+	LengthPrefixedJSON bool
+	// DefaultTimeZone is the time zone of ISO-8601 timestamp strings without a time zone,
+	// such as 2024-01-02T03:04:05, which some producers emit. Such strings are ambiguous, since the
+	// zone of the producer is not known, and are interpreted in UTC when not set.
+	DefaultTimeZone *time.Location
+	// TimestampLayouts are time.Parse layouts of timestamp strings that are not ISO-8601, such as
+	// "2006-01-02 15:04:05" from database exports. They are tried in order after ISO-8601 and the first
+	// that matches wins. Layouts without a time zone are interpreted in DefaultTimeZone, or UTC when not set.
+	TimestampLayouts []string
+	// FieldDecryptor decrypts the values of SchemaOptions.EncryptedFields encrypted by
+	// MarshalOptions.FieldEncryptor. It is required when decoding data with encrypted fields.
+	FieldDecryptor func(fd protoreflect.FieldDescriptor, ciphertext []byte) ([]byte, error)
+	// AcceptEnumObjects also accepts enum values encoded as objects with a symbol and a numeric value,
+	// such as {"symbol": "RED", "value": 2}, as emitted by some producers. The symbol is preferred and
+	// the value is used when the symbol is absent. Objects where they refer to different enum values
+	// are rejected.
+	AcceptEnumObjects bool
+	// ExpandAny decodes google.protobuf.Any values written as records {"type_url": string, "value": record},
+	// where the value is the Avro record of the message type named by the type URL, for pipelines that embed
+	// the Avro of the concrete type instead of protobuf binary. Types are resolved in protoregistry.GlobalTypes.
+	// Values written as JSON strings are decoded as usual.
+	ExpandAny bool
+	// MaxUnionResolutions limits the number of message types that a single decode resolves by name,
+	// such as the types of google.protobuf.Any values, to protect against untrusted data that forces
+	// many expensive resolver lookups. Zero means no limit.
+	MaxUnionResolutions int
+	// StructNumberAsString decodes integral numbers in google.protobuf.Struct values, written without
+	// a fraction or exponent such as 1, as string values with the literal number instead of as number
+	// values, which are always doubles. This preserves the distinction between 1 and 1.0, and large
+	// integers, for systems that the values are passed on to. Other numbers are decoded as number values.
+	StructNumberAsString bool
+	// OnUnknownField, when set, is called with every record field that does not match any field of
+	// the message, so that callers can log, collect or selectively reject them. Returning nil skips the
+	// field and returning an error fails the decoding. It takes precedence over DiscardUnknownFields.
+	OnUnknownField func(messageName protoreflect.FullName, fieldName string, value interface{}) error
+	// BeforeMessage, when set, is called with the record of every decoded message, including nested ones,
+	// before its fields are decoded, so that callers can inject validation or metrics at the message level.
+	// Well-known types, which are not decoded from records, are excluded. Returning an error fails the decoding.
+	BeforeMessage func(desc protoreflect.MessageDescriptor, record map[string]interface{}) error
+	// AfterMessage, when set, is called with every decoded message, including nested ones, after its fields
+	// are decoded, so that callers can validate or default it. Nested messages are passed before the messages
+	// that contain them. Well-known types are excluded. Returning an error fails the decoding.
+	AfterMessage func(msg proto.Message) error
+	// AcceptEnumNumbers also accepts enum values encoded as numbers, such as 2 or {"int": 2}, as emitted
+	// by some producers, which are resolved by enum value number. Elements of repeated enum fields are
+	// resolved independently, so lists may mix symbols and numbers. Unknown numbers are handled like
+	// unknown symbols.
+	AcceptEnumNumbers bool
+	// MaxBlockBytes limits the size in bytes of the blocks of Avro files read by Unmarshalers, so that
+	// untrusted files that declare huge blocks fail before the blocks are allocated. Zero means no limit.
+	MaxBlockBytes int64
+	// MaxBlockCount limits the number of messages in the blocks of Avro files read by Unmarshalers.
+	// Zero means no limit.
+	MaxBlockCount int64
+	// UnwrapKey is the key of a wrapper record that some frameworks nest top-level records in,
+	// such as "value". Data where it is the only top-level key is decoded from its value, unless it is
+	// also the name of a field of the message. Data without the wrapper is decoded as usual.
+	UnwrapKey string
+	// DecimalFields maps the full names of string fields to the precision and scale of Avro decimal
+	// values that they are decoded from, as decimal strings such as "-12.34" with Scale digits after
+	// the decimal point. Values with more digits than Precision are rejected.
+	DecimalFields map[string]Decimal
+	// RejectMultipleOneofFields rejects records where more than one field of a oneof is not null.
+	// Unless SchemaOptions.OneofAsTaggedRecord is set, inferred schemas represent each member of a oneof as a
+	// separate nullable field, the form expected by consumers that can not model unions, such as BigQuery,
+	// so there is no separate schema option for it. Writers are not prevented from setting several members,
+	// and by default the last one decoded is kept.
+	RejectMultipleOneofFields bool
+	// TrimEnumSymbols trims whitespace around enum symbols before they are resolved, for producers
+	// that pad values. Trimmed symbols are recorded in Warnings.
+	TrimEnumSymbols bool
 }
@@ -0,0 +1,75 @@
+package protoavro
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// patternKey identifies the pattern of a field by a pattern option.
+type patternKey struct {
+	field         protoreflect.FieldDescriptor
+	patternOption protoreflect.ExtensionType
+}
+
+// compiledPattern is a compiled pattern, or the error of compiling it.
+type compiledPattern struct {
+	re  *regexp.Regexp
+	err error
+}
+
+// patterns caches compiled patterns by patternKey, so that each pattern is compiled once per descriptor.
+var patterns sync.Map
+
+// fieldPattern returns the compiled regular expression of the string extension patternOption of field,
+// or nil if the option is not set.
+func fieldPattern(
+	field protoreflect.FieldDescriptor,
+	patternOption protoreflect.ExtensionType,
+) (*regexp.Regexp, error) {
+	key := patternKey{field: field, patternOption: patternOption}
+	if cached, ok := patterns.Load(key); ok {
+		return cached.(compiledPattern).re, cached.(compiledPattern).err
+	}
+	var compiled compiledPattern
+	compiled.re, compiled.err = compilePattern(field, patternOption)
+	patterns.Store(key, compiled)
+	return compiled.re, compiled.err
+}
+
+func compilePattern(
+	field protoreflect.FieldDescriptor,
+	patternOption protoreflect.ExtensionType,
+) (*regexp.Regexp, error) {
+	options, ok := field.Options().(proto.Message)
+	if !ok || !proto.HasExtension(options, patternOption) {
+		return nil, nil
+	}
+	pattern, ok := proto.GetExtension(options, patternOption).(string)
+	if !ok {
+		return nil, fmt.Errorf("pattern option %s is not a string", patternOption.TypeDescriptor().FullName())
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+	return re, nil
+}
+
+// validatePattern returns an error if ValidatePatterns is set and str does not match the pattern of field f.
+func (d *decoder) validatePattern(f protoreflect.FieldDescriptor, str string) error {
+	if !d.opts.ValidatePatterns || d.opts.PatternOption == nil {
+		return nil
+	}
+	re, err := fieldPattern(f, d.opts.PatternOption)
+	if err != nil {
+		return fmt.Errorf("field %s: %w", f.Name(), err)
+	}
+	if re != nil && !re.MatchString(str) {
+		return fmt.Errorf("field %s: value %q does not match pattern %s", f.Name(), str, re)
+	}
+	return nil
+}
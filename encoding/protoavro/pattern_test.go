@@ -0,0 +1,61 @@
+package protoavro
+
+import (
+	"testing"
+
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnmarshalOptions_ValidatePatterns(t *testing.T) {
+	opts := UnmarshalOptions{ValidatePatterns: true, PatternOption: examplev1.E_Pattern}
+	for _, tt := range []struct {
+		name        string
+		opts        UnmarshalOptions
+		data        map[string]interface{}
+		expected    *examplev1.ExamplePattern
+		errContains string
+	}{
+		{
+			name: "matching",
+			opts: opts,
+			data: map[string]interface{}{
+				"code":        map[string]interface{}{"string": "ABC-123"},
+				"description": map[string]interface{}{"string": "not validated"},
+				"tags":        map[string]interface{}{"array": []interface{}{"a", "bc"}},
+			},
+			expected: &examplev1.ExamplePattern{Code: "ABC-123", Description: "not validated", Tags: []string{"a", "bc"}},
+		},
+		{
+			name:        "not matching",
+			opts:        opts,
+			data:        map[string]interface{}{"code": map[string]interface{}{"string": "abc-123"}},
+			errContains: `field code: value "abc-123" does not match pattern ^[A-Z]{3}-[0-9]+$`,
+		},
+		{
+			name:        "repeated element not matching",
+			opts:        opts,
+			data:        map[string]interface{}{"tags": map[string]interface{}{"array": []interface{}{"a", "B"}}},
+			errContains: `field tags: value "B" does not match pattern ^[a-z]+$`,
+		},
+		{
+			name:     "not validated",
+			opts:     UnmarshalOptions{PatternOption: examplev1.E_Pattern},
+			data:     map[string]interface{}{"code": map[string]interface{}{"string": "abc-123"}},
+			expected: &examplev1.ExamplePattern{Code: "abc-123"},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got := &examplev1.ExamplePattern{}
+			err := tt.opts.Unmarshal(tt.data, got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got, protocmp.Transform())
+		})
+	}
+}
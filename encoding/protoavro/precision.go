@@ -0,0 +1,56 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxExactFloat is the largest magnitude up to which all integers are exactly representable as float64.
+const maxExactFloat = 1 << 53
+
+// lossyConversion returns an error for a numeric conversion of a value of field f that loses precision
+// if UnmarshalOptions.FailOnPrecisionLoss is set, and otherwise records it as a warning.
+func (d *decoder) lossyConversion(f protoreflect.FieldDescriptor, format string, args ...interface{}) error {
+	if d.opts.FailOnPrecisionLoss {
+		return fmt.Errorf("field %s: precision loss: %s", f.Name(), fmt.Sprintf(format, args...))
+	}
+	d.warn(format, args...)
+	return nil
+}
+
+// checkIntegralFloat returns an error if FailOnPrecisionLoss is set and data, to be decoded into integer
+// field f, is a floating point number too large to be an exact integer, such as 64-bit integers that
+// were parsed from JSON as doubles.
+func (d *decoder) checkIntegralFloat(f protoreflect.FieldDescriptor, data interface{}) error {
+	if !d.opts.FailOnPrecisionLoss {
+		return nil
+	}
+	if m, ok := data.(map[string]interface{}); ok && len(m) == 1 {
+		for _, value := range m {
+			data = value
+		}
+	}
+	var flt float64
+	switch v := data.(type) {
+	case float64:
+		flt = v
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return nil
+		}
+		parsed, err := v.Float64()
+		if err != nil {
+			return nil
+		}
+		flt = parsed
+	default:
+		return nil
+	}
+	if math.Abs(flt) > maxExactFloat {
+		return fmt.Errorf("field %s: precision loss: %v exceeds the range of exact integers in doubles", f.Name(), flt)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// protoBytesProp is the custom field property that holds the message full name of fields that are
+// encoded as protobuf binary, by SchemaOptions.NestedProtoBytesFields.
+const protoBytesProp = "proto.binaryMessage"
+
+// isProtoBytesField returns true if field is one of the NestedProtoBytesFields.
+func (o SchemaOptions) isProtoBytesField(field protoreflect.FieldDescriptor) (bool, error) {
+	messageType, ok := o.NestedProtoBytesFields[string(field.FullName())]
+	if !ok {
+		return false, nil
+	}
+	if field.Message() == nil || field.IsMap() {
+		return false, fmt.Errorf("field %s: only message fields can be nested proto bytes", field.Name())
+	}
+	if messageType == nil || messageType.Descriptor().FullName() != field.Message().FullName() {
+		return false, fmt.Errorf(
+			"field %s: nested proto bytes type does not match %s", field.Name(), field.Message().FullName(),
+		)
+	}
+	return true, nil
+}
+
+// protoBytesJSON encodes the message as protobuf binary.
+func (o MarshalOptions) protoBytesJSON(field protoreflect.FieldDescriptor, message protoreflect.Message) (
+	interface{},
+	error,
+) {
+	if !message.IsValid() {
+		return nil, nil
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(message.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("field %s: proto marshal: %w", field.Name(), err)
+	}
+	return o.unionValue(string(avro.BytesType), data), nil
+}
+
+// decodeProtoBytes decodes the protobuf binary in data into the message value of field.
+func decodeProtoBytes(data interface{}, field protoreflect.FieldDescriptor, message protoreflect.Message) error {
+	b, err := decodeBytesLike(data, string(avro.BytesType))
+	if err != nil {
+		return fmt.Errorf("field %s: %w", field.Name(), err)
+	}
+	if err := proto.Unmarshal(b, message.Interface()); err != nil {
+		return fmt.Errorf("field %s: proto unmarshal: %w", field.Name(), err)
+	}
+	return nil
+}
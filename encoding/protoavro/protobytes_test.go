@@ -0,0 +1,68 @@
+package protoavro
+
+import (
+	"testing"
+
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_NestedProtoBytesFields(t *testing.T) {
+	t.Run("singular", func(t *testing.T) {
+		opts := SchemaOptions{
+			NestedProtoBytesFields: map[string]protoreflect.MessageType{
+				"einride.avro.example.v1.ExampleNested.level1": (&examplev1.ExampleNested_Level1{}).ProtoReflect().Type(),
+			},
+		}
+		schema, err := opts.InferSchema((&examplev1.ExampleNested{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		assert.DeepEqual(t, avro.Field{
+			Name:  "level1",
+			Type:  avro.Nullable(avro.Bytes()),
+			Props: map[string]interface{}{protoBytesProp: "einride.avro.example.v1.ExampleNested.Level1"},
+		}, schema.(avro.Union)[1].(avro.Record).Fields[0])
+		level1 := &examplev1.ExampleNested_Level1{
+			Level2: &examplev1.ExampleNested_Level2{Level3: &examplev1.ExampleNested_Level3{Value: "a"}},
+		}
+		native := assertRoundTrip(t, opts, schema, &examplev1.ExampleNested{Level1: level1, Name: "b"})
+		record := native.(map[string]interface{})["einride.avro.example.v1.ExampleNested"].(map[string]interface{})
+		var got examplev1.ExampleNested_Level1
+		assert.NilError(t, proto.Unmarshal(record["level1"].(map[string]interface{})["bytes"].([]byte), &got))
+		assert.DeepEqual(t, level1, &got, protocmp.Transform())
+		t.Run("unset", func(t *testing.T) {
+			assertRoundTrip(t, opts, schema, &examplev1.ExampleNested{Name: "b"})
+		})
+		t.Run("invalid", func(t *testing.T) {
+			var got examplev1.ExampleNested
+			err := UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{
+				"level1": map[string]interface{}{"bytes": []byte{0xff}},
+			}, &got)
+			assert.ErrorContains(t, err, "field level1: proto unmarshal")
+		})
+	})
+	t.Run("repeated", func(t *testing.T) {
+		opts := SchemaOptions{
+			NestedProtoBytesFields: map[string]protoreflect.MessageType{
+				"einride.avro.example.v1.ExampleList.nested_list": (&examplev1.ExampleList_Nested{}).ProtoReflect().Type(),
+			},
+		}
+		schema, err := opts.InferSchema((&examplev1.ExampleList{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		assertRoundTrip(t, opts, schema, &examplev1.ExampleList{
+			NestedList: []*examplev1.ExampleList_Nested{{StringList: []string{"a"}}, {}},
+		})
+	})
+	t.Run("mismatched type", func(t *testing.T) {
+		opts := SchemaOptions{
+			NestedProtoBytesFields: map[string]protoreflect.MessageType{
+				"einride.avro.example.v1.ExampleNested.level1": (&examplev1.ExampleNested_Level2{}).ProtoReflect().Type(),
+			},
+		}
+		_, err := opts.InferSchema((&examplev1.ExampleNested{}).ProtoReflect().Descriptor())
+		assert.ErrorContains(t, err, "field level1: nested proto bytes type does not match")
+	})
+}
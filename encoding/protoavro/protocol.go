@@ -0,0 +1,161 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// AvroProtocol returns an Avro protocol declaration with the records and enums of the messages and enums
+// in files as types, and the unary methods of the services in files as messages.
+// The name is the full name of the protocol, for example "einride.avro.example.v1.ExampleProtocol".
+// Each type is declared once, and later uses of it, also from other files, refer to it by name.
+// See: https://avro.apache.org/docs/current/spec.html#Protocol+Declaration
+func (o SchemaOptions) AvroProtocol(name string, files []protoreflect.FileDescriptor) (json.RawMessage, error) {
+	simpleName := name[strings.LastIndex(name, ".")+1:]
+	if !isAvroName(simpleName) {
+		return nil, fmt.Errorf("invalid Avro protocol name '%s'", name)
+	}
+	p := protocolInferrer{
+		s: o.newSchemaInferrer(),
+		protocol: protocol{
+			Protocol:  simpleName,
+			Namespace: avro.NamespaceOf(name),
+			Types:     []avro.Schema{},
+		},
+	}
+	for _, file := range files {
+		if err := p.inferFile(file); err != nil {
+			return nil, fmt.Errorf("%s: %w", file.Path(), err)
+		}
+	}
+	return json.Marshal(p.protocol)
+}
+
+type protocol struct {
+	Protocol  string                     `json:"protocol"`
+	Namespace string                     `json:"namespace,omitempty"`
+	Types     []avro.Schema              `json:"types"`
+	Messages  map[string]protocolMessage `json:"messages,omitempty"`
+}
+
+type protocolMessage struct {
+	Request  []avro.Field `json:"request"`
+	Response avro.Schema  `json:"response"`
+}
+
+type protocolInferrer struct {
+	s        schemaInferrer
+	protocol protocol
+}
+
+func (p *protocolInferrer) inferFile(file protoreflect.FileDescriptor) error {
+	for i := 0; i < file.Enums().Len(); i++ {
+		if err := p.inferEnum(file.Enums().Get(i)); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < file.Messages().Len(); i++ {
+		if err := p.inferMessages(file.Messages().Get(i)); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < file.Services().Len(); i++ {
+		service := file.Services().Get(i)
+		for j := 0; j < service.Methods().Len(); j++ {
+			if err := p.inferMethod(service.Methods().Get(j)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// inferMessages declares the types of message and of its nested messages and enums.
+func (p *protocolInferrer) inferMessages(message protoreflect.MessageDescriptor) error {
+	if message.IsMapEntry() {
+		return nil
+	}
+	if _, err := p.inferType(message); err != nil {
+		return err
+	}
+	for i := 0; i < message.Enums().Len(); i++ {
+		if err := p.inferEnum(message.Enums().Get(i)); err != nil {
+			return err
+		}
+	}
+	for i := 0; i < message.Messages().Len(); i++ {
+		if err := p.inferMessages(message.Messages().Get(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *protocolInferrer) inferEnum(enum protoreflect.EnumDescriptor) error {
+	schema, err := p.s.inferEnumSchema(enum)
+	if err != nil {
+		return err
+	}
+	if !isReference(schema) {
+		p.protocol.Types = append(p.protocol.Types, schema)
+	}
+	return nil
+}
+
+// inferType declares the record of message, unless already declared, and returns a reference to it.
+// Well-known types are not declared, and their schema is returned instead.
+func (p *protocolInferrer) inferType(message protoreflect.MessageDescriptor) (avro.Schema, error) {
+	schema, err := p.s.inferMessageSchema(message, 0)
+	if err != nil {
+		return nil, err
+	}
+	if p.s.opts.isExpandedWKT(message.FullName()) {
+		return schema, nil
+	}
+	if union, ok := schema.(avro.Union); ok {
+		for _, member := range union {
+			if member != avro.Null() {
+				schema = member
+			}
+		}
+	}
+	if !isReference(schema) {
+		p.protocol.Types = append(p.protocol.Types, schema)
+	}
+	return avro.Reference(p.s.opts.recordFullName(message)), nil
+}
+
+func (p *protocolInferrer) inferMethod(method protoreflect.MethodDescriptor) error {
+	if method.IsStreamingClient() || method.IsStreamingServer() {
+		return fmt.Errorf("method %s: streaming methods are not supported in Avro protocols", method.FullName())
+	}
+	name := string(method.Name())
+	if _, ok := p.protocol.Messages[name]; ok {
+		return fmt.Errorf("method %s: duplicate Avro protocol message %s", method.FullName(), name)
+	}
+	request, err := p.inferType(method.Input())
+	if err != nil {
+		return err
+	}
+	response, err := p.inferType(method.Output())
+	if err != nil {
+		return err
+	}
+	if p.protocol.Messages == nil {
+		p.protocol.Messages = make(map[string]protocolMessage)
+	}
+	p.protocol.Messages[name] = protocolMessage{
+		Request:  []avro.Field{{Name: "request", Type: request}},
+		Response: response,
+	}
+	return nil
+}
+
+func isReference(schema avro.Schema) bool {
+	_, ok := schema.(avro.Reference)
+	return ok
+}
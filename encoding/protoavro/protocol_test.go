@@ -0,0 +1,88 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_AvroProtocol(t *testing.T) {
+	protocolFile := examplev1.File_einride_avro_example_v1_example_protocol_proto
+	sharedFile := examplev1.File_einride_avro_example_v1_example_protocol_shared_proto
+	for _, tt := range []struct {
+		name          string
+		files         []protoreflect.FileDescriptor
+		expectedTypes []string
+	}{
+		{
+			name:  "shared type first used from other file",
+			files: []protoreflect.FileDescriptor{protocolFile, sharedFile},
+			expectedTypes: []string{
+				"einride.avro.example.v1.GetExampleRequest",
+				"einride.avro.example.v1.ListExamplesRequest",
+				"einride.avro.example.v1.ListExamplesResponse",
+			},
+		},
+		{
+			name:  "shared file first",
+			files: []protoreflect.FileDescriptor{sharedFile, protocolFile},
+			expectedTypes: []string{
+				"einride.avro.example.v1.ExampleProtocolShared",
+				"einride.avro.example.v1.GetExampleRequest",
+				"einride.avro.example.v1.ListExamplesRequest",
+				"einride.avro.example.v1.ListExamplesResponse",
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := SchemaOptions{}.AvroProtocol("einride.avro.example.v1.ExampleProtocol", tt.files)
+			assert.NilError(t, err)
+			assert.Equal(t, 1, strings.Count(string(got), `"name":"ExampleProtocolShared"`))
+			var decoded struct {
+				Protocol  string
+				Namespace string
+				Types     []json.RawMessage
+				Messages  map[string]struct {
+					Request []struct {
+						Name string
+						Type string
+					}
+					Response string
+				}
+			}
+			assert.NilError(t, json.Unmarshal(got, &decoded))
+			assert.Equal(t, "ExampleProtocol", decoded.Protocol)
+			assert.Equal(t, "einride.avro.example.v1", decoded.Namespace)
+			gotTypes := make([]string, 0, len(decoded.Types))
+			for _, schema := range decoded.Types {
+				var named struct{ Name, Namespace string }
+				assert.NilError(t, json.Unmarshal(schema, &named))
+				gotTypes = append(gotTypes, named.Namespace+"."+named.Name)
+			}
+			assert.DeepEqual(t, tt.expectedTypes, gotTypes)
+			assert.Equal(t, 2, len(decoded.Messages))
+			getExample := decoded.Messages["GetExample"]
+			assert.Equal(t, "einride.avro.example.v1.GetExampleRequest", getExample.Request[0].Type)
+			assert.Equal(t, "einride.avro.example.v1.ExampleProtocolShared", getExample.Response)
+			listExamples := decoded.Messages["ListExamples"]
+			assert.Equal(t, "einride.avro.example.v1.ListExamplesRequest", listExamples.Request[0].Type)
+			assert.Equal(t, "einride.avro.example.v1.ListExamplesResponse", listExamples.Response)
+			// The declared types resolve all references as a union schema.
+			types, err := json.Marshal(decoded.Types)
+			assert.NilError(t, err)
+			_, err = goavro.NewCodec(string(types))
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestSchemaOptions_AvroProtocol_InvalidName(t *testing.T) {
+	_, err := SchemaOptions{}.AvroProtocol("einride.avro.example.v1.Example-Protocol", nil)
+	assert.ErrorContains(t, err, "invalid Avro protocol name 'einride.avro.example.v1.Example-Protocol'")
+}
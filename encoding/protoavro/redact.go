@@ -0,0 +1,38 @@
+package protoavro
+
+import (
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RedactedPlaceholder is the value of redacted string fields.
+const RedactedPlaceholder = "[REDACTED]"
+
+// isRedacted returns true if the options of field have the boolean extension redactOption set to true.
+func isRedacted(field protoreflect.FieldDescriptor, redactOption protoreflect.ExtensionType) bool {
+	if redactOption == nil {
+		return false
+	}
+	options, ok := field.Options().(proto.Message)
+	if !ok || !proto.HasExtension(options, redactOption) {
+		return false
+	}
+	redact, ok := proto.GetExtension(options, redactOption).(bool)
+	return ok && redact
+}
+
+// redactedJSON returns the encoding of a redacted field.
+// Singular string fields are encoded as RedactedPlaceholder and other fields as null.
+func (o MarshalOptions) redactedJSON(field protoreflect.FieldDescriptor) interface{} {
+	if field.Kind() == protoreflect.StringKind && !field.IsList() && !field.IsMap() {
+		return o.unionValue(string(avro.StringType), RedactedPlaceholder)
+	}
+	return nil
+}
+
+// isRedactedPlaceholder returns true if data is the encoding of a redacted field.
+func isRedactedPlaceholder(data interface{}) bool {
+	str, err := decodeStringLike(data, string(avro.StringType))
+	return err == nil && str == RedactedPlaceholder
+}
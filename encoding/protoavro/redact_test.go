@@ -0,0 +1,64 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestMarshalOptions_RedactOption(t *testing.T) {
+	msg := &examplev1.ExampleRedact{
+		Name:       "name",
+		Secret:     "secret",
+		Pin:        1234,
+		Nested:     &examplev1.ExampleRedact_Nested{Value: "value"},
+		SecretList: []string{"a", "b"},
+	}
+	t.Run("redacted", func(t *testing.T) {
+		opts := MarshalOptions{RedactOption: examplev1.E_Redact}
+		got, err := opts.Encode(msg)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, map[string]interface{}{
+			"einride.avro.example.v1.ExampleRedact": map[string]interface{}{
+				"name":        map[string]interface{}{"string": "name"},
+				"secret":      map[string]interface{}{"string": RedactedPlaceholder},
+				"pin":         nil,
+				"nested":      nil,
+				"secret_list": nil,
+			},
+		}, got)
+
+		schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		codec, err := goavro.NewCodec(string(schemaBytes))
+		assert.NilError(t, err)
+		binary, err := codec.BinaryFromNative(nil, got)
+		assert.NilError(t, err)
+		native, _, err := codec.NativeFromBinary(binary)
+		assert.NilError(t, err)
+
+		var decoded examplev1.ExampleRedact
+		assert.NilError(t, UnmarshalOptions{RedactOption: examplev1.E_Redact}.Unmarshal(native, &decoded))
+		assert.DeepEqual(t, &examplev1.ExampleRedact{Name: "name"}, &decoded, protocmp.Transform())
+	})
+	t.Run("not configured", func(t *testing.T) {
+		got, err := MarshalOptions{}.Encode(msg)
+		assert.NilError(t, err)
+		var decoded examplev1.ExampleRedact
+		assert.NilError(t, UnmarshalOptions{}.Unmarshal(got, &decoded))
+		assert.DeepEqual(t, msg, &decoded, protocmp.Transform())
+	})
+	t.Run("placeholder without option", func(t *testing.T) {
+		var decoded examplev1.ExampleRedact
+		assert.NilError(t, UnmarshalOptions{}.Unmarshal(map[string]interface{}{
+			"secret": map[string]interface{}{"string": RedactedPlaceholder},
+		}, &decoded))
+		assert.Equal(t, RedactedPlaceholder, decoded.GetSecret())
+	})
+}
@@ -0,0 +1,115 @@
+package protoavro
+
+import (
+	"fmt"
+	"sort"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// repeatedMapKey returns the key field of a repeated message field that is encoded as an Avro map,
+// or nil if the field is encoded as an array.
+func (o SchemaOptions) repeatedMapKey(field protoreflect.FieldDescriptor) (protoreflect.FieldDescriptor, error) {
+	keyName, ok := o.RepeatedAsMap[string(field.FullName())]
+	if !ok {
+		return nil, nil
+	}
+	if !field.IsList() || field.Message() == nil {
+		return nil, fmt.Errorf("repeated as map: field %s is not a repeated message field", field.FullName())
+	}
+	keyField := field.Message().Fields().ByName(protoreflect.Name(keyName))
+	if keyField == nil {
+		return nil, fmt.Errorf("repeated as map: field %s: no key field '%s'", field.FullName(), keyName)
+	}
+	if keyField.Kind() != protoreflect.StringKind || keyField.Cardinality() == protoreflect.Repeated {
+		return nil, fmt.Errorf("repeated as map: field %s: key field '%s' is not a string", field.FullName(), keyName)
+	}
+	return keyField, nil
+}
+
+// mapToRepeatedKey returns the key field of a repeated message field that Avro maps are decoded into
+// by MapToRepeated, or nil if maps are not decoded into the field.
+func (o *UnmarshalOptions) mapToRepeatedKey(field protoreflect.FieldDescriptor) (protoreflect.FieldDescriptor, error) {
+	if _, ok := o.MapToRepeated[string(field.FullName())]; !ok {
+		return nil, nil
+	}
+	return SchemaOptions{RepeatedAsMap: o.MapToRepeated}.repeatedMapKey(field)
+}
+
+// isMapUnion returns true if data is a union value of the map branch.
+func isMapUnion(data interface{}) bool {
+	union, ok := data.(map[string]interface{})
+	if !ok || len(union) != 1 {
+		return false
+	}
+	_, ok = union[string(avro.MapType)]
+	return ok
+}
+
+func (o MarshalOptions) encodeRepeatedMap(
+	field protoreflect.FieldDescriptor,
+	keyField protoreflect.FieldDescriptor,
+	list protoreflect.List,
+	recursiveIndex int,
+) (interface{}, error) {
+	values := make(map[string]interface{}, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		element := list.Get(i)
+		key := element.Message().Get(keyField).String()
+		if _, ok := values[key]; ok {
+			return nil, fmt.Errorf("field %s: duplicate key '%s'", field.Name(), key)
+		}
+		value, err := o.fieldKindJSON(field, element, recursiveIndex)
+		if err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return o.unionValue("map", values), nil
+}
+
+func (d *decoder) decodeRepeatedMap(
+	data interface{},
+	f protoreflect.FieldDescriptor,
+	keyField protoreflect.FieldDescriptor,
+	list protoreflect.List,
+) error {
+	union, ok := data.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("field %s: expected map-like, got %T", f.Name(), data)
+	}
+	values, ok := union["map"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("field %s: expected key 'map' with map[string]interface{}", f.Name())
+	}
+	if err := d.checkElements(f, len(values)); err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := d.checkStringLength(keyField, key); err != nil {
+			return err
+		}
+		element := list.NewElement()
+		if values[key] != nil {
+			d.pushPath(fmt.Sprintf("[%s]", key))
+			value, err := d.decodeFieldKind(values[key], element, f)
+			d.popPath()
+			if err != nil {
+				return err
+			}
+			element = value
+		}
+		if got := element.Message().Get(keyField).String(); got != "" && got != key {
+			return fmt.Errorf("field %s: key '%s' does not match %s '%s'", f.Name(), key, keyField.Name(), got)
+		}
+		element.Message().Set(keyField, protoreflect.ValueOfString(key))
+		list.Append(element)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestMarshalOptions_RepeatedAsMap(t *testing.T) {
+	opts := MarshalOptions{
+		SchemaOptions: SchemaOptions{
+			RepeatedAsMap: map[string]string{
+				"google.example.library.v1.ListBooksResponse.books": "name",
+			},
+		},
+	}
+	msg := &library.ListBooksResponse{
+		Books: []*library.Book{
+			{Name: "shelves/1/books/1", Title: "Harry Potter"},
+			{Name: "shelves/1/books/2", Title: "The Hobbit"},
+		},
+		NextPageToken: "token",
+	}
+
+	t.Run("schema", func(t *testing.T) {
+		schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		record := schema.(avro.Union)[1].(avro.Record)
+		assert.Equal(t, record.Fields[0].Name, "books")
+		books, ok := record.Fields[0].Type.(avro.Union)[1].(avro.Map)
+		assert.Assert(t, ok)
+		assert.Equal(t, books.Type, avro.MapType)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		codec, err := goavro.NewCodec(string(schemaBytes))
+		assert.NilError(t, err)
+		datum, err := opts.Encode(msg)
+		assert.NilError(t, err)
+		binary, err := codec.BinaryFromNative(nil, datum)
+		assert.NilError(t, err)
+		size, err := opts.EncodedSize(msg)
+		assert.NilError(t, err)
+		assert.Equal(t, size, len(binary))
+		native, _, err := codec.NativeFromBinary(binary)
+		assert.NilError(t, err)
+		var got library.ListBooksResponse
+		assert.NilError(t, UnmarshalOptions{SchemaOptions: opts.SchemaOptions}.Unmarshal(native, &got))
+		assert.DeepEqual(t, msg, &got, protocmp.Transform())
+	})
+
+	t.Run("duplicate key", func(t *testing.T) {
+		_, err := opts.Encode(&library.ListBooksResponse{
+			Books: []*library.Book{
+				{Name: "shelves/1/books/1", Title: "Harry Potter"},
+				{Name: "shelves/1/books/1", Title: "The Hobbit"},
+			},
+		})
+		assert.ErrorContains(t, err, "field books: duplicate key 'shelves/1/books/1'")
+	})
+
+	t.Run("key mismatch", func(t *testing.T) {
+		var got library.ListBooksResponse
+		err := UnmarshalOptions{SchemaOptions: opts.SchemaOptions}.Unmarshal(map[string]interface{}{
+			"books": map[string]interface{}{
+				"map": map[string]interface{}{
+					"shelves/1/books/1": map[string]interface{}{
+						"name": map[string]interface{}{"string": "shelves/1/books/2"},
+					},
+				},
+			},
+		}, &got)
+		assert.ErrorContains(t, err, "field books: key 'shelves/1/books/1' does not match name 'shelves/1/books/2'")
+	})
+
+	t.Run("invalid key field", func(t *testing.T) {
+		_, err := SchemaOptions{
+			RepeatedAsMap: map[string]string{
+				"google.example.library.v1.ListBooksResponse.books": "read",
+			},
+		}.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.ErrorContains(t, err, "key field 'read' is not a string")
+	})
+}
+
+func TestUnmarshalOptions_MapToRepeated(t *testing.T) {
+	const field = "google.example.library.v1.ListBooksResponse.books"
+	msg := &library.ListBooksResponse{
+		Books: []*library.Book{
+			{Name: "shelves/1/books/1", Title: "Harry Potter"},
+			{Name: "shelves/1/books/2", Title: "The Hobbit"},
+		},
+		NextPageToken: "token",
+	}
+	opts := UnmarshalOptions{MapToRepeated: map[string]string{field: "name"}}
+
+	t.Run("from RepeatedAsMap", func(t *testing.T) {
+		writer := SchemaOptions{RepeatedAsMap: map[string]string{field: "name"}}
+		schema, err := writer.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		codec, err := goavro.NewCodec(string(schemaBytes))
+		assert.NilError(t, err)
+		datum, err := writer.Encode(msg)
+		assert.NilError(t, err)
+		binary, err := codec.BinaryFromNative(nil, datum)
+		assert.NilError(t, err)
+		native, _, err := codec.NativeFromBinary(binary)
+		assert.NilError(t, err)
+		var got library.ListBooksResponse
+		assert.NilError(t, opts.Unmarshal(native, &got))
+		assert.DeepEqual(t, msg, &got, protocmp.Transform())
+
+		// the decoded message encodes to the same datum.
+		again, err := writer.Encode(&got)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, datum, again)
+	})
+
+	t.Run("key injected", func(t *testing.T) {
+		var got library.ListBooksResponse
+		err := opts.Unmarshal(map[string]interface{}{
+			"books": map[string]interface{}{
+				"map": map[string]interface{}{
+					"shelves/1/books/2": map[string]interface{}{"title": "The Hobbit"},
+					"shelves/1/books/1": map[string]interface{}{"title": "Harry Potter"},
+				},
+			},
+			"next_page_token": "token",
+		}, &got)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, msg, &got, protocmp.Transform())
+	})
+
+	t.Run("arrays", func(t *testing.T) {
+		datum, err := SchemaOptions{}.Encode(msg)
+		assert.NilError(t, err)
+		var got library.ListBooksResponse
+		assert.NilError(t, opts.Unmarshal(datum, &got))
+		assert.DeepEqual(t, msg, &got, protocmp.Transform())
+	})
+
+	t.Run("invalid key field", func(t *testing.T) {
+		var got library.ListBooksResponse
+		err := UnmarshalOptions{MapToRepeated: map[string]string{field: "read"}}.Unmarshal(map[string]interface{}{
+			"books": map[string]interface{}{"map": map[string]interface{}{}},
+		}, &got)
+		assert.ErrorContains(t, err, "key field 'read' is not a string")
+	})
+}
@@ -0,0 +1,35 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// RPCSchema returns the JSON encoded Avro schemas of the input and output messages of a gRPC method,
+// for bridging gRPC methods to Avro-based message buses.
+// The schemas of streaming methods are those of the individual messages in the stream, which are
+// written as separate Avro values rather than as one array, since streams are unbounded.
+func RPCSchema(
+	method protoreflect.MethodDescriptor,
+	opts SchemaOptions,
+) (request, response json.RawMessage, err error) {
+	request, err = rpcMessageSchema(method.Input(), opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: request: %w", method.FullName(), err)
+	}
+	response, err = rpcMessageSchema(method.Output(), opts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: response: %w", method.FullName(), err)
+	}
+	return request, response, nil
+}
+
+func rpcMessageSchema(desc protoreflect.MessageDescriptor, opts SchemaOptions) (json.RawMessage, error) {
+	schema, err := opts.InferSchema(desc)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(schema)
+}
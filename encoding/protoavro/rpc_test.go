@@ -0,0 +1,61 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gotest.tools/v3/assert"
+)
+
+func TestRPCSchema(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		method   protoreflect.MethodDescriptor
+		request  proto.Message
+		response proto.Message
+	}{
+		{
+			name: "unary",
+			method: examplev1.File_einride_avro_example_v1_example_protocol_proto.Services().
+				Get(0).Methods().ByName("GetExample"),
+			request:  &examplev1.GetExampleRequest{Name: "examples/1"},
+			response: &examplev1.ExampleProtocolShared{},
+		},
+		{
+			name: "server streaming",
+			method: examplev1.File_einride_avro_example_v1_example_streaming_proto.Services().
+				Get(0).Methods().ByName("WatchExamples"),
+			request:  &examplev1.WatchExamplesRequest{Filter: "a"},
+			response: &examplev1.ExampleProtocolShared{},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			request, response, err := RPCSchema(tt.method, SchemaOptions{})
+			assert.NilError(t, err)
+			for _, s := range []struct {
+				schema json.RawMessage
+				msg    proto.Message
+			}{
+				{schema: request, msg: tt.request},
+				{schema: response, msg: tt.response},
+			} {
+				expected, err := InferSchema(s.msg.ProtoReflect().Descriptor())
+				assert.NilError(t, err)
+				expectedBytes, err := json.Marshal(expected)
+				assert.NilError(t, err)
+				assert.Equal(t, string(expectedBytes), string(s.schema))
+				codec, err := goavro.NewCodec(string(s.schema))
+				assert.NilError(t, err)
+				datum, err := SchemaOptions{}.Encode(s.msg)
+				assert.NilError(t, err)
+				_, err = codec.BinaryFromNative(nil, datum)
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
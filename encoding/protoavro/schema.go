@@ -1,63 +1,220 @@
 package protoavro
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"go.einride.tech/protobuf-avro/avro"
+	"go.einride.tech/protobuf-avro/internal/wkt"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
 )
 
 // InferSchema returns the Avro schema, with default SchemaOptions, for the protobuf message descriptor.
 func InferSchema(desc protoreflect.MessageDescriptor) (avro.Schema, error) {
-	return SchemaOptions{}.newSchemaInferrer().inferMessageSchema(desc, 0)
+	return SchemaOptions{}.newSchemaInferrer().inferSchema(desc)
 }
 
 // InferSchema returns the Avro schema for the protobuf message descriptor.
 func (o SchemaOptions) InferSchema(desc protoreflect.MessageDescriptor) (avro.Schema, error) {
-	return o.newSchemaInferrer().inferMessageSchema(desc, 0)
+	return o.newSchemaInferrer().inferSchema(desc)
+}
+
+// FieldSchema returns the JSON encoded Avro schema of the values of a single field, as in the record
+// of the containing message, so that tooling can build composite schemas or validate individual columns.
+// Named types used by the field are defined in the returned schema.
+func FieldSchema(field protoreflect.FieldDescriptor, opts SchemaOptions) (json.RawMessage, error) {
+	fieldType, err := opts.newSchemaInferrer().inferFieldType(field, 1)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(fieldType)
+}
+
+// inferFieldType returns the schema of the values of field in the record of the containing message.
+// The recursiveIndex of a field is one more than the depth of the record.
+func (s schemaInferrer) inferFieldType(field protoreflect.FieldDescriptor, recursiveIndex int) (avro.Schema, error) {
+	fieldSchema, err := s.inferField(field, recursiveIndex)
+	if err != nil {
+		return nil, err
+	}
+	if s.opts.isRequiredField(field) {
+		return nonNullable(fieldSchema.Type), nil
+	}
+	return avro.Nullable(fieldSchema.Type), nil
+}
+
+// Schema returns the Avro schema for the protobuf message descriptor as an indented JSON document.
+// Named types that are used more than once are defined at every use, or only at the first use with
+// PrintReferences. Recursive types are referenced within their own definitions in both forms.
+func (o SchemaOptions) Schema(desc protoreflect.MessageDescriptor) (json.RawMessage, error) {
+	schema, err := o.InferSchema(desc)
+	if err != nil {
+		return nil, err
+	}
+	schemaBytes, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+	mode := avro.NormalizeInline
+	if o.PrintReferences {
+		mode = avro.NormalizeReference
+	}
+	normalized, err := avro.NormalizeSchema(schemaBytes, mode)
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	if err := json.Indent(&b, normalized, "", "  "); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
 }
 
 type schemaInferrer struct {
 	opts SchemaOptions
 	seen map[protoreflect.FullName]struct{}
+	// records holds the messages of inferred records by Avro full name.
+	records map[string]protoreflect.FullName
+	// omitFields holds the full names of fields that are left out of records, for MinimalSchema.
+	omitFields map[protoreflect.FullName]struct{}
+	// depths holds the nesting depths of inferred records by message full name, for MaxNestDepth.
+	depths map[protoreflect.FullName]int
 }
 
 func (o SchemaOptions) newSchemaInferrer() schemaInferrer {
-	return schemaInferrer{seen: make(map[protoreflect.FullName]struct{}), opts: o}
+	return schemaInferrer{
+		seen:    make(map[protoreflect.FullName]struct{}),
+		records: make(map[string]protoreflect.FullName),
+		depths:  make(map[protoreflect.FullName]int),
+		opts:    o,
+	}
 }
 
 func (s schemaInferrer) inferMessageSchema(
 	message protoreflect.MessageDescriptor,
 	recursiveIndex int,
 ) (avro.Schema, error) {
-	if isWKT(message.FullName()) {
-		return schemaWKT(message)
+	if s.opts.isExpandedWKT(message.FullName()) {
+		if s.opts.TimestampDual && message.FullName() == wkt.Timestamp {
+			// the dual timestamp is a named record, which may only be defined once.
+			if _, ok := s.seen[message.FullName()]; ok {
+				return avro.Nullable(avro.Reference(wkt.Timestamp)), nil
+			}
+			s.seen[message.FullName()] = struct{}{}
+		}
+		schema, err := s.opts.schemaWKT(message)
+		if err != nil {
+			return nil, err
+		}
+		return s.opts.withLogicalTypeProps(schema), nil
 	}
+	fullName := s.opts.recordFullName(message)
 	if _, ok := s.seen[message.FullName()]; ok {
-		return avro.Nullable(avro.Reference(message.FullName())), nil
+		return avro.Nullable(avro.Reference(fullName)), nil
 	}
 	s.seen[message.FullName()] = struct{}{}
+	s.depths[message.FullName()] = recursiveIndex
+	if err := s.registerRecord(fullName, message); err != nil {
+		return nil, err
+	}
 	doc := message.ParentFile().SourceLocations().ByDescriptor(message).LeadingComments
 	record := avro.Record{
 		Type:      avro.RecordType,
 		Doc:       doc,
-		Name:      string(message.Name()),
-		Namespace: namespace(message),
+		Name:      fullName[strings.LastIndex(fullName, ".")+1:],
+		Namespace: avro.NamespaceOf(fullName),
 		Fields:    make([]avro.Field, 0, message.Fields().Len()),
 	}
 	for i := 0; i < message.Fields().Len(); i++ {
 		field := message.Fields().Get(i)
+		if _, ok := s.omitFields[field.FullName()]; ok {
+			continue
+		}
 		fieldSchema, err := s.inferField(field, recursiveIndex+1)
 		if err != nil {
 			return nil, err
 		}
-		fieldSchema.Type = avro.Nullable(fieldSchema.Type)
+		if s.opts.IncludeFieldNumbers {
+			if fieldSchema.Props == nil {
+				fieldSchema.Props = make(map[string]interface{}, 1)
+			}
+			fieldSchema.Props[fieldNumberProp] = int(field.Number())
+		}
+		if s.opts.AliasBothNames && field.JSONName() != fieldSchema.Name {
+			fieldSchema.Aliases = append(fieldSchema.Aliases, field.JSONName())
+		}
+		if s.opts.AnnotateDeprecated && isDeprecatedField(field) {
+			if fieldSchema.Props == nil {
+				fieldSchema.Props = make(map[string]interface{}, 1)
+			}
+			fieldSchema.Props[deprecatedProp] = true
+		}
+		if s.opts.AnnotateProtoTypes && field.Message() == nil {
+			if fieldSchema.Props == nil {
+				fieldSchema.Props = make(map[string]interface{}, 1)
+			}
+			fieldSchema.Props[protoTypeProp] = field.Kind().String()
+		}
+		if protoBytes, _ := s.opts.isProtoBytesField(field); protoBytes {
+			if fieldSchema.Props == nil {
+				fieldSchema.Props = make(map[string]interface{}, 1)
+			}
+			fieldSchema.Props[protoBytesProp] = string(field.Message().FullName())
+		}
+		if s.opts.isJSONMessageField(field, recursiveIndex+1) {
+			if fieldSchema.Props == nil {
+				fieldSchema.Props = make(map[string]interface{}, 1)
+			}
+			fieldSchema.Props[jsonMessageProp] = string(field.Message().FullName())
+		}
+		if epoch, ok := s.opts.timestampEpochProp(field); ok {
+			if fieldSchema.Props == nil {
+				fieldSchema.Props = make(map[string]interface{}, 1)
+			}
+			fieldSchema.Props[timestampEpochProp] = epoch
+		}
+		maxLength, hasSizeHint, err := s.opts.sizeHint(field)
+		if err != nil {
+			return nil, err
+		}
+		if hasSizeHint {
+			if fieldSchema.Props == nil {
+				fieldSchema.Props = make(map[string]interface{}, 1)
+			}
+			fieldSchema.Props[maxLengthProp] = maxLength
+		}
+		if s.opts.isRequiredField(field) {
+			fieldSchema.Type = nonNullable(fieldSchema.Type)
+		} else {
+			fieldSchema.Type = avro.Nullable(fieldSchema.Type)
+		}
 		record.Fields = append(
 			record.Fields,
 			fieldSchema,
 		)
 	}
+	if !message.IsMapEntry() {
+		fields, err := s.opts.tagOneofFieldSchemas(message, record.Fields)
+		if err != nil {
+			return nil, err
+		}
+		if fields, err = s.opts.groupFieldSchemas(message, fields); err != nil {
+			return nil, err
+		}
+		if fields, err = s.opts.withTypeNameField(message, fields); err != nil {
+			return nil, err
+		}
+		if record.Fields, err = s.opts.withChecksumField(message, fields); err != nil {
+			return nil, err
+		}
+	}
+	if recursiveIndex == 0 {
+		record.Fields = s.opts.orderFields(record.Fields)
+	}
 	if message.IsMapEntry() {
 		return record, nil
 	}
@@ -73,6 +230,26 @@ func namespace(desc protoreflect.Descriptor) string {
 
 func (s schemaInferrer) inferField(field protoreflect.FieldDescriptor, recursiveIndex int) (avro.Field, error) {
 	doc := field.ParentFile().SourceLocations().ByDescriptor(field).LeadingComments
+	if doc == "" {
+		doc = s.opts.FieldDocs[string(field.FullName())]
+	}
+	encrypted, err := s.opts.isEncryptedField(field)
+	if err != nil {
+		return avro.Field{}, err
+	}
+	if encrypted {
+		return encryptedFieldSchema(field, doc), nil
+	}
+	if s.opts.isEmptyAsNullField(field) {
+		return emptyFieldSchema(field, doc), nil
+	}
+	_, delimited, err := s.opts.delimiter(field)
+	if err != nil {
+		return avro.Field{}, err
+	}
+	if delimited {
+		return delimitedFieldSchema(field, doc), nil
+	}
 	if field.IsMap() {
 		mapType, err := s.inferMapSchema(field, recursiveIndex)
 		if err != nil {
@@ -89,6 +266,20 @@ func (s schemaInferrer) inferField(field protoreflect.FieldDescriptor, recursive
 		return avro.Field{}, err
 	}
 	if field.IsList() {
+		keyField, err := s.opts.repeatedMapKey(field)
+		if err != nil {
+			return avro.Field{}, err
+		}
+		if keyField != nil {
+			return avro.Field{
+				Name: string(field.Name()),
+				Doc:  doc,
+				Type: avro.Map{
+					Type:   avro.MapType,
+					Values: avro.Nullable(fieldKind),
+				},
+			}, nil
+		}
 		return avro.Field{
 			Name: string(field.Name()),
 			Doc:  doc,
@@ -99,11 +290,15 @@ func (s schemaInferrer) inferField(field protoreflect.FieldDescriptor, recursive
 		}, nil
 	}
 	if oneof := field.ContainingOneof(); oneof != nil {
-		return avro.Field{
+		avroField := avro.Field{
 			Name: string(field.Name()),
 			Doc:  oneofDoc(doc, oneof),
 			Type: avro.Nullable(fieldKind),
-		}, nil
+		}
+		if s.opts.AnnotateOneofs && !oneof.IsSynthetic() {
+			avroField.Props = map[string]interface{}{oneofProp: string(oneof.Name())}
+		}
+		return avroField, nil
 	}
 	return avro.Field{
 		Name: string(field.Name()),
@@ -112,6 +307,24 @@ func (s schemaInferrer) inferField(field protoreflect.FieldDescriptor, recursive
 	}, nil
 }
 
+// oneofProp is the custom field property that holds the name of the containing oneof.
+const oneofProp = "proto.oneof"
+
+// fieldNumberProp is the custom field property that holds the protobuf field number.
+const fieldNumberProp = "proto.fieldNumber"
+
+// protoTypeProp is the custom field property that holds the protobuf type of scalar fields.
+const protoTypeProp = "proto.type"
+
+// deprecatedProp is the custom field property that marks fields that are deprecated in protobuf.
+const deprecatedProp = "proto.deprecated"
+
+// isDeprecatedField returns true if field has the deprecated field option.
+func isDeprecatedField(field protoreflect.FieldDescriptor) bool {
+	options, ok := field.Options().(*descriptorpb.FieldOptions)
+	return ok && options.GetDeprecated()
+}
+
 func oneofDoc(doc string, oneof protoreflect.OneofDescriptor) string {
 	fieldNamesLi := make([]string, 0, oneof.Fields().Len())
 	for i := 0; i < oneof.Fields().Len(); i++ {
@@ -126,6 +339,13 @@ func oneofDoc(doc string, oneof protoreflect.OneofDescriptor) string {
 }
 
 func (s schemaInferrer) inferFieldKind(field protoreflect.FieldDescriptor, recursiveIndex int) (avro.Schema, error) {
+	symbols, isEnumInt, err := s.opts.enumIntSymbols(field)
+	if err != nil {
+		return nil, err
+	}
+	if isEnumInt {
+		return s.inferEnumIntSchema(field, symbols), nil
+	}
 	switch field.Kind() {
 	case protoreflect.DoubleKind:
 		return avro.Double(), nil
@@ -142,24 +362,40 @@ func (s schemaInferrer) inferFieldKind(field protoreflect.FieldDescriptor, recur
 		protoreflect.Fixed64Kind,
 		protoreflect.Sfixed64Kind,
 		protoreflect.Sint64Kind:
+		if s.opts.Int64AsString {
+			return avro.String(), nil
+		}
 		return avro.Long(), nil
 	case protoreflect.BoolKind:
+		if s.opts.BoolAsInt {
+			return avro.Integer(), nil
+		}
 		return avro.Boolean(), nil
 	case protoreflect.BytesKind:
-		return avro.Bytes(), nil
+		return s.inferBytesSchema(field)
 	case protoreflect.StringKind:
 		return avro.String(), nil
 	case protoreflect.EnumKind:
-		return s.inferEnumSchema(field.Enum()), nil
+		return s.inferEnumSchema(field.Enum())
 	case protoreflect.MessageKind, protoreflect.GroupKind:
+		protoBytes, err := s.opts.isProtoBytesField(field)
+		if err != nil {
+			return nil, err
+		}
+		if protoBytes {
+			return avro.Bytes(), nil
+		}
+		if s.opts.isJSONMessageField(field, recursiveIndex) {
+			return avro.String(), nil
+		}
 		return s.inferMessageSchema(field.Message(), recursiveIndex)
 	}
 	return nil, fmt.Errorf("unsupported field kind %s %s", field.Name(), field.Kind())
 }
 
-func (s schemaInferrer) inferEnumSchema(enum protoreflect.EnumDescriptor) avro.Schema {
+func (s schemaInferrer) inferEnumSchema(enum protoreflect.EnumDescriptor) (avro.Schema, error) {
 	if _, ok := s.seen[enum.FullName()]; ok {
-		return avro.Reference(enum.FullName())
+		return avro.Reference(enum.FullName()), nil
 	}
 	s.seen[enum.FullName()] = struct{}{}
 	doc := enum.ParentFile().SourceLocations().ByDescriptor(enum).LeadingComments
@@ -169,8 +405,40 @@ func (s schemaInferrer) inferEnumSchema(enum protoreflect.EnumDescriptor) avro.S
 		Name:      string(enum.Name()),
 		Namespace: namespace(enum),
 	}
+	if frozen, ok := s.opts.FreezeEnumSymbols[enum.FullName()]; ok {
+		e.Symbols = append([]string(nil), frozen...)
+		if zero := enum.Values().ByNumber(0); zero != nil && len(frozen) > 0 && frozen[0] != string(zero.Name()) &&
+			s.opts.isFrozenEnumSymbol(enum, string(zero.Name())) {
+			e.Default = string(zero.Name())
+		}
+		return s.opts.withEnumDefault(enum, e)
+	}
 	for i := 0; i < enum.Values().Len(); i++ {
 		e.Symbols = append(e.Symbols, string(enum.Values().Get(i).Name()))
 	}
-	return e
+	if s.opts.SortEnumSymbols && len(e.Symbols) > 0 {
+		// the first symbol is no longer the zero value, so make it explicit.
+		if zero := enum.Values().ByNumber(0); zero != nil {
+			e.Default = string(zero.Name())
+		} else {
+			e.Default = e.Symbols[0]
+		}
+		sort.Strings(e.Symbols)
+	}
+	return s.opts.withEnumDefault(enum, e)
+}
+
+// withEnumDefault returns e with the default symbol of EnumDefaults for enum, if any.
+func (o SchemaOptions) withEnumDefault(enum protoreflect.EnumDescriptor, e avro.Enum) (avro.Schema, error) {
+	def, ok := o.EnumDefaults[enum.FullName()]
+	if !ok {
+		return e, nil
+	}
+	for _, symbol := range e.Symbols {
+		if symbol == def {
+			e.Default = def
+			return e, nil
+		}
+	}
+	return nil, fmt.Errorf("enum %s: default %s is not a symbol", enum.FullName(), def)
 }
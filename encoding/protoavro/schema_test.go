@@ -1,12 +1,18 @@
 package protoavro
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/linkedin/goavro/v2"
 	"go.einride.tech/protobuf-avro/avro"
 	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
 	"google.golang.org/genproto/googleapis/example/library/v1"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/typepb"
 	"gotest.tools/v3/assert"
 )
 
@@ -1123,3 +1129,471 @@ func TestInferRootSchema(t *testing.T) {
 		})
 	}
 }
+
+func TestSchemaOptions_AnnotateOneofs(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		opts     SchemaOptions
+		msg      proto.Message
+		expected map[string]interface{}
+	}{
+		{
+			name: "oneof members",
+			opts: SchemaOptions{AnnotateOneofs: true},
+			msg:  &examplev1.ExampleOneof{},
+			expected: map[string]interface{}{
+				"oneof_empty_message_1": "oneof_fields_1",
+				"oneof_bool_1":          "oneof_fields_1",
+				"oneof_empty_message_2": "oneof_fields_2",
+				"oneof_message":         "oneof_fields_2",
+			},
+		},
+		{
+			name:     "regular and optional fields",
+			opts:     SchemaOptions{AnnotateOneofs: true},
+			msg:      &examplev1.ExampleOptional{},
+			expected: map[string]interface{}{},
+		},
+		{
+			name:     "disabled",
+			msg:      &examplev1.ExampleOneof{},
+			expected: map[string]interface{}{},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := tt.opts.InferSchema(tt.msg.ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			record, ok := schema.(avro.Union)[1].(avro.Record)
+			assert.Assert(t, ok)
+			got := map[string]interface{}{}
+			for _, field := range record.Fields {
+				if oneof, ok := field.Props["proto.oneof"]; ok {
+					got[field.Name] = oneof
+				}
+			}
+			assert.DeepEqual(t, tt.expected, got)
+			schemaBytes, err := json.Marshal(schema)
+			assert.NilError(t, err)
+			_, err = goavro.NewCodec(string(schemaBytes))
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestSchemaOptions_IncludeFieldNumbers(t *testing.T) {
+	opts := SchemaOptions{IncludeFieldNumbers: true, AnnotateOneofs: true}
+	schema, err := opts.InferSchema((&library.UpdateBookRequest{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	record, ok := schema.(avro.Union)[1].(avro.Record)
+	assert.Assert(t, ok)
+	assert.Equal(t, "book", record.Fields[0].Name)
+	assert.DeepEqual(t, map[string]interface{}{"proto.fieldNumber": 1}, record.Fields[0].Props)
+	assert.Equal(t, "update_mask", record.Fields[1].Name)
+	assert.DeepEqual(t, map[string]interface{}{"proto.fieldNumber": 2}, record.Fields[1].Props)
+	book, ok := record.Fields[0].Type.(avro.Union)[1].(avro.Record)
+	assert.Assert(t, ok)
+	got := map[string]interface{}{}
+	for _, field := range book.Fields {
+		got[field.Name] = field.Props["proto.fieldNumber"]
+	}
+	assert.DeepEqual(t, map[string]interface{}{"name": 1, "author": 2, "title": 3, "read": 4}, got)
+
+	oneofSchema, err := opts.InferSchema((&examplev1.ExampleOneof{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	oneofRecord, ok := oneofSchema.(avro.Union)[1].(avro.Record)
+	assert.Assert(t, ok)
+	assert.DeepEqual(
+		t,
+		map[string]interface{}{"proto.oneof": "oneof_fields_2", "proto.fieldNumber": 4},
+		oneofRecord.Fields[3].Props,
+	)
+
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(
+		string(schemaBytes),
+		`{"name":"read","type":[{"type":"null"},{"type":"boolean"}],"proto.fieldNumber":4}`,
+	))
+	_, err = goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+}
+
+func TestSchemaOptions_AnnotateProtoTypes(t *testing.T) {
+	opts := SchemaOptions{AnnotateProtoTypes: true}
+	msg := &examplev1.ExampleIntegers{
+		Int32Value:      -1,
+		Sint32Value:     -2,
+		Uint32Value:     3,
+		Fixed32Value:    4,
+		Sfixed32Value:   -5,
+		Int64Value:      -6,
+		Sint64Value:     -7,
+		Uint64Value:     8,
+		Fixed64Value:    9,
+		Sfixed64Value:   -10,
+		Sint64List:      []int64{-11},
+		Sint32ToFixed64: map[int32]uint64{-12: 13},
+	}
+	schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	record := schema.(avro.Union)[1].(avro.Record)
+	got := map[string]interface{}{}
+	for _, field := range record.Fields {
+		got[field.Name] = field.Props[protoTypeProp]
+	}
+	assert.DeepEqual(t, map[string]interface{}{
+		"int32_value":       "int32",
+		"sint32_value":      "sint32",
+		"uint32_value":      "uint32",
+		"fixed32_value":     "fixed32",
+		"sfixed32_value":    "sfixed32",
+		"int64_value":       "int64",
+		"sint64_value":      "sint64",
+		"uint64_value":      "uint64",
+		"fixed64_value":     "fixed64",
+		"sfixed64_value":    "sfixed64",
+		"sint64_list":       "sint64",
+		"sint32_to_fixed64": nil,
+	}, got)
+	entry := record.Fields[11].Type.(avro.Union)[1].(avro.Array).Items.(avro.Record)
+	assert.Equal(t, "sint32", entry.Fields[0].Props[protoTypeProp])
+	assert.Equal(t, "fixed64", entry.Fields[1].Props[protoTypeProp])
+	assert.DeepEqual(t, avro.Nullable(avro.Integer()), record.Fields[1].Type)
+
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(
+		string(schemaBytes),
+		`{"name":"sint32_value","type":[{"type":"null"},{"type":"int"}],"proto.type":"sint32"}`,
+	))
+	assertRoundTrip(t, opts, schema, msg)
+}
+
+func TestSchemaOptions_AnnotateDeprecated(t *testing.T) {
+	desc := (&descriptorpb.FileOptions{}).ProtoReflect().Descriptor()
+	schema, err := SchemaOptions{AnnotateDeprecated: true}.InferSchema(desc)
+	assert.NilError(t, err)
+	record, ok := schema.(avro.Union)[1].(avro.Record)
+	assert.Assert(t, ok)
+	var deprecated []string
+	for _, field := range record.Fields {
+		if field.Props[deprecatedProp] == true {
+			deprecated = append(deprecated, field.Name)
+		}
+	}
+	assert.DeepEqual(t, []string{"java_generate_equals_and_hash"}, deprecated)
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(schemaBytes), `"proto.deprecated":true`))
+	_, err = goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+
+	schema, err = SchemaOptions{}.InferSchema(desc)
+	assert.NilError(t, err)
+	schemaBytes, err = json.Marshal(schema)
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(schemaBytes), deprecatedProp))
+}
+
+func TestSchemaOptions_FieldDocs(t *testing.T) {
+	opts := SchemaOptions{
+		FieldDocs: map[string]string{
+			"google.example.library.v1.Book.title":   "The title of the book.",
+			"google.example.library.v1.Book.author":  "The name of the book author.",
+			"google.example.library.v1.Book.unknown": "Ignored.",
+		},
+	}
+	schema, err := opts.InferSchema((&library.Book{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	record, ok := schema.(avro.Union)[1].(avro.Record)
+	assert.Assert(t, ok)
+	got := map[string]string{}
+	for _, field := range record.Fields {
+		got[field.Name] = field.Doc
+	}
+	assert.DeepEqual(t, map[string]string{
+		"name":   "",
+		"author": "The name of the book author.",
+		"title":  "The title of the book.",
+		"read":   "",
+	}, got)
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(string(schemaBytes), `"doc":"The title of the book."`))
+	_, err = goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+}
+
+func TestSchemaOptions_SortEnumSymbols(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		opts     SchemaOptions
+		enum     protoreflect.EnumDescriptor
+		expected avro.Enum
+	}{
+		{
+			name: "declaration order",
+			enum: typepb.Field_CARDINALITY_UNKNOWN.Descriptor(),
+			expected: avro.Enum{
+				Type:      avro.EnumType,
+				Name:      "Cardinality",
+				Namespace: "google.protobuf.Field",
+				Symbols: []string{
+					"CARDINALITY_UNKNOWN",
+					"CARDINALITY_OPTIONAL",
+					"CARDINALITY_REQUIRED",
+					"CARDINALITY_REPEATED",
+				},
+			},
+		},
+		{
+			name: "sorted",
+			opts: SchemaOptions{SortEnumSymbols: true},
+			enum: typepb.Field_CARDINALITY_UNKNOWN.Descriptor(),
+			expected: avro.Enum{
+				Type:      avro.EnumType,
+				Name:      "Cardinality",
+				Namespace: "google.protobuf.Field",
+				Symbols: []string{
+					"CARDINALITY_OPTIONAL",
+					"CARDINALITY_REPEATED",
+					"CARDINALITY_REQUIRED",
+					"CARDINALITY_UNKNOWN",
+				},
+				Default: "CARDINALITY_UNKNOWN",
+			},
+		},
+		{
+			name: "sorted without zero value",
+			opts: SchemaOptions{SortEnumSymbols: true},
+			enum: descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Descriptor(),
+			expected: avro.Enum{
+				Type:      avro.EnumType,
+				Name:      "Label",
+				Namespace: "google.protobuf.FieldDescriptorProto",
+				Symbols:   []string{"LABEL_OPTIONAL", "LABEL_REPEATED", "LABEL_REQUIRED"},
+				// proto2 enums default to the first declared value.
+				Default: "LABEL_OPTIONAL",
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.opts.newSchemaInferrer().inferEnumSchema(tt.enum)
+			assert.NilError(t, err)
+			e := got.(avro.Enum)
+			e.Doc = ""
+			assert.DeepEqual(t, tt.expected, e)
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		opts := SchemaOptions{SortEnumSymbols: true}
+		msg := &examplev1.ExampleEnum{EnumValue: examplev1.ExampleEnum_ENUM_VALUE2}
+		schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		assertRoundTrip(t, opts, schema, msg)
+	})
+}
+
+func TestSchemaOptions_FreezeEnumSymbols(t *testing.T) {
+	opts := SchemaOptions{
+		FreezeEnumSymbols: map[protoreflect.FullName][]string{
+			"einride.avro.example.v1.ExampleEnum.Enum": {"ENUM_VALUE1", "ENUM_REMOVED", "ENUM_UNSPECIFIED"},
+		},
+		// frozen enums are not sorted.
+		SortEnumSymbols: true,
+	}
+	desc := (&examplev1.ExampleEnum{}).ProtoReflect().Descriptor()
+
+	t.Run("schema", func(t *testing.T) {
+		schema, err := opts.InferSchema(desc)
+		assert.NilError(t, err)
+		record, ok := schema.(avro.Union)[1].(avro.Record)
+		assert.Assert(t, ok)
+		assert.DeepEqual(t, avro.Nullable(avro.Enum{
+			Type:      avro.EnumType,
+			Name:      "Enum",
+			Namespace: "einride.avro.example.v1.ExampleEnum",
+			Symbols:   []string{"ENUM_VALUE1", "ENUM_REMOVED", "ENUM_UNSPECIFIED"},
+			Default:   "ENUM_UNSPECIFIED",
+		}), record.Fields[0].Type)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		schema, err := opts.InferSchema(desc)
+		assert.NilError(t, err)
+		assertRoundTrip(t, opts, schema, &examplev1.ExampleEnum{EnumValue: examplev1.ExampleEnum_ENUM_VALUE1})
+	})
+
+	t.Run("encode value that is not frozen", func(t *testing.T) {
+		_, err := opts.Encode(&examplev1.ExampleEnum{EnumValue: examplev1.ExampleEnum_ENUM_VALUE2})
+		assert.ErrorContains(t, err, "field enum_value: enum value ENUM_VALUE2 is not a frozen symbol")
+	})
+
+	t.Run("decode symbol that is not frozen", func(t *testing.T) {
+		var got examplev1.ExampleEnum
+		err := UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{"enum_value": "ENUM_VALUE2"}, &got)
+		assert.ErrorContains(t, err, "field enum_value: enum symbol ENUM_VALUE2 is not a frozen symbol")
+	})
+
+	t.Run("decode frozen symbol that is not a value", func(t *testing.T) {
+		got := examplev1.ExampleEnum{EnumValue: examplev1.ExampleEnum_ENUM_VALUE1}
+		err := UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{"enum_value": "ENUM_REMOVED"}, &got)
+		assert.NilError(t, err)
+		assert.Equal(t, examplev1.ExampleEnum_ENUM_UNSPECIFIED, got.GetEnumValue())
+	})
+}
+
+func TestFieldSchema(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		field    protoreflect.FieldDescriptor
+		expected string
+	}{
+		{
+			name:     "scalar",
+			field:    (&library.Book{}).ProtoReflect().Descriptor().Fields().ByName("title"),
+			expected: `[{"type":"null"},{"type":"string"}]`,
+		},
+		{
+			name:  "repeated message",
+			field: (&examplev1.ExampleList{}).ProtoReflect().Descriptor().Fields().ByName("nested_list"),
+			expected: `[{"type":"null"},{"type":"array","items":[{"type":"null"},{"type":"record",` +
+				`"namespace":"einride.avro.example.v1.ExampleList","name":"Nested","fields":[{"name":"string_list",` +
+				`"type":[{"type":"null"},{"type":"array","items":[{"type":"null"},{"type":"string"}]}]}]}]}]`,
+		},
+		{
+			name:  "map",
+			field: (&examplev1.ExampleMap{}).ProtoReflect().Descriptor().Fields().ByName("string_to_string"),
+			expected: `[{"type":"null"},{"type":"array","items":{"type":"record",` +
+				`"namespace":"einride.avro.example.v1.ExampleMap","name":"StringToStringEntry","fields":[` +
+				`{"name":"key","type":[{"type":"null"},{"type":"string"}]},` +
+				`{"name":"value","type":[{"type":"null"},{"type":"string"}]}]}}]`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := FieldSchema(tt.field, SchemaOptions{})
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, string(got))
+			_, err = goavro.NewCodec(string(got))
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestSchemaOptions_EnumDefaults(t *testing.T) {
+	desc := (&examplev1.ExampleEnum{}).ProtoReflect().Descriptor()
+	opts := SchemaOptions{
+		EnumDefaults: map[protoreflect.FullName]string{"einride.avro.example.v1.ExampleEnum.Enum": "ENUM_VALUE2"},
+	}
+	t.Run("schema", func(t *testing.T) {
+		schema, err := opts.InferSchema(desc)
+		assert.NilError(t, err)
+		e := schema.(avro.Union)[1].(avro.Record).Fields[0].Type.(avro.Union)[1].(avro.Enum)
+		assert.Equal(t, "ENUM_VALUE2", e.Default)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		_, err = goavro.NewCodec(string(schemaBytes))
+		assert.NilError(t, err)
+	})
+	t.Run("unknown symbol", func(t *testing.T) {
+		var warnings []string
+		var got examplev1.ExampleEnum
+		assert.NilError(t, UnmarshalOptions{SchemaOptions: opts, Warnings: &warnings}.Unmarshal(map[string]interface{}{
+			"enum_value": map[string]interface{}{"einride.avro.example.v1.ExampleEnum.Enum": "ENUM_VALUE3"},
+		}, &got))
+		assert.Equal(t, examplev1.ExampleEnum_ENUM_VALUE2, got.GetEnumValue())
+		assert.DeepEqual(t, []string{"enum_value: unknown enum symbol ENUM_VALUE3 decoded as default ENUM_VALUE2"}, warnings)
+	})
+	t.Run("absent", func(t *testing.T) {
+		var got examplev1.ExampleEnum
+		assert.NilError(t, UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{}, &got))
+		assert.Equal(t, examplev1.ExampleEnum_ENUM_UNSPECIFIED, got.GetEnumValue())
+	})
+	t.Run("absent with default on absence", func(t *testing.T) {
+		unmarshalOpts := UnmarshalOptions{SchemaOptions: opts, UseEnumDefaultOnAbsence: true}
+		var got examplev1.ExampleEnum
+		assert.NilError(t, unmarshalOpts.Unmarshal(map[string]interface{}{}, &got))
+		assert.Equal(t, examplev1.ExampleEnum_ENUM_VALUE2, got.GetEnumValue())
+		got.Reset()
+		assert.NilError(t, unmarshalOpts.Unmarshal(map[string]interface{}{"enum_value": nil}, &got))
+		assert.Equal(t, examplev1.ExampleEnum_ENUM_UNSPECIFIED, got.GetEnumValue())
+	})
+	t.Run("not a symbol", func(t *testing.T) {
+		opts := SchemaOptions{
+			EnumDefaults: map[protoreflect.FullName]string{"einride.avro.example.v1.ExampleEnum.Enum": "ENUM_VALUE3"},
+		}
+		_, err := opts.InferSchema(desc)
+		assert.ErrorContains(t, err, "enum einride.avro.example.v1.ExampleEnum.Enum: default ENUM_VALUE3 is not a symbol")
+	})
+}
+
+func TestSchemaOptions_Schema_PrintReferences(t *testing.T) {
+	msg := &examplev1.ExampleOneof{
+		OneofFields_1: &examplev1.ExampleOneof_OneofEmptyMessage_1{
+			OneofEmptyMessage_1: &examplev1.ExampleOneof_EmptyMessage{},
+		},
+		OneofFields_2: &examplev1.ExampleOneof_OneofEmptyMessage_2{
+			OneofEmptyMessage_2: &examplev1.ExampleOneof_EmptyMessage{},
+		},
+	}
+	for _, tt := range []struct {
+		name            string
+		printReferences bool
+		// expected is the second member of the union of oneof_empty_message_2, which reuses the record
+		// of oneof_empty_message_1.
+		expected interface{}
+	}{
+		{
+			name: "inline",
+			expected: map[string]interface{}{
+				"type":   "record",
+				"name":   "einride.avro.example.v1.ExampleOneof.EmptyMessage",
+				"fields": []interface{}{},
+			},
+		},
+		{
+			name:            "references",
+			printReferences: true,
+			expected:        "einride.avro.example.v1.ExampleOneof.EmptyMessage",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := SchemaOptions{PrintReferences: tt.printReferences}
+			schemaBytes, err := opts.Schema(msg.ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			var schema []interface{}
+			assert.NilError(t, json.Unmarshal(schemaBytes, &schema))
+			fields := schema[1].(map[string]interface{})["fields"].([]interface{})
+			var reused interface{}
+			for _, field := range fields {
+				if field := field.(map[string]interface{}); field["name"] == "oneof_empty_message_2" {
+					reused = field["type"].([]interface{})[1]
+				}
+			}
+			assert.DeepEqual(t, tt.expected, reused)
+
+			codec, err := goavro.NewCodec(string(schemaBytes))
+			assert.NilError(t, err)
+			data, err := opts.Encode(msg)
+			assert.NilError(t, err)
+			_, err = codec.BinaryFromNative(nil, data)
+			assert.NilError(t, err)
+		})
+	}
+
+	t.Run("recursive", func(t *testing.T) {
+		desc := (&examplev1.ExampleRecursive{}).ProtoReflect().Descriptor()
+		for _, printReferences := range []bool{false, true} {
+			schemaBytes, err := SchemaOptions{PrintReferences: printReferences}.Schema(desc)
+			assert.NilError(t, err)
+			_, err = goavro.NewCodec(string(schemaBytes))
+			assert.NilError(t, err)
+			assert.Assert(t, strings.Contains(string(schemaBytes), `"einride.avro.example.v1.ExampleRecursive"`))
+		}
+	})
+}
@@ -0,0 +1,555 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// EncodedSize returns the number of bytes that the Avro binary encoding of message would occupy,
+// without producing the encoding. Values are sized directly from the message, except values with
+// encodings of their own, such as bytes, maps and well-known types, which are sized from their Avro JSON
+// encoding.
+func (o MarshalOptions) EncodedSize(message proto.Message) (int, error) {
+	desc := message.ProtoReflect().Descriptor()
+	schema, err := o.InferSchema(desc)
+	if err != nil {
+		return 0, fmt.Errorf("infer schema: %w", err)
+	}
+	s := newSizer(o, schema)
+	if o.Envelope != nil || o.PostProcess != nil {
+		datum, err := o.encodeJSON(message)
+		if err != nil {
+			return 0, fmt.Errorf("encode json: %w", err)
+		}
+		return s.size(schema, "", datum)
+	}
+	if s.opts, err = o.withNestDepths(desc); err != nil {
+		return 0, err
+	}
+	return s.messageSize(schema, "", message.ProtoReflect(), 0)
+}
+
+// sizer computes binary encoding sizes by walking a schema and a message or datum in
+// the same order as the binary encoder.
+type sizer struct {
+	opts MarshalOptions
+	// named holds the named types of the schema by full name.
+	named map[string]avro.Schema
+}
+
+func newSizer(opts MarshalOptions, schema avro.Schema) sizer {
+	s := sizer{opts: opts, named: make(map[string]avro.Schema)}
+	s.register(schema, "")
+	return s
+}
+
+// register adds the named types defined in schema to the named types of s, so that references resolve
+// regardless of whether the values of the definitions are present.
+func (s sizer) register(schema avro.Schema, namespace string) {
+	switch schema := schema.(type) {
+	case avro.Union:
+		for _, member := range schema {
+			s.register(member, namespace)
+		}
+	case avro.Record:
+		name := avro.FullName(schema.Name, schema.Namespace, namespace)
+		s.named[name] = schema
+		for _, field := range schema.Fields {
+			s.register(field.Type, avro.NamespaceOf(name))
+		}
+	case avro.Enum:
+		s.named[avro.FullName(schema.Name, schema.Namespace, namespace)] = schema
+	case avro.Fixed:
+		s.named[avro.FullName(schema.Name, schema.Namespace, namespace)] = schema
+	case avro.Array:
+		s.register(schema.Items, namespace)
+	case avro.Map:
+		s.register(schema.Values, namespace)
+	}
+}
+
+// resolve returns the named type that schema references, or schema if it is not a reference.
+func (s sizer) resolve(schema avro.Schema, namespace string) (avro.Schema, error) {
+	reference, ok := schema.(avro.Reference)
+	if !ok {
+		return schema, nil
+	}
+	name := avro.FullName(string(reference), "", namespace)
+	named, ok := s.named[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown named type '%s'", name)
+	}
+	return named, nil
+}
+
+// valueMember returns the index and schema of the only member of union other than null.
+func valueMember(union avro.Union) (int, avro.Schema, bool) {
+	index := -1
+	for i, member := range union {
+		if member == avro.Null() {
+			continue
+		}
+		if index >= 0 {
+			return 0, nil, false
+		}
+		index = i
+	}
+	if index < 0 {
+		return 0, nil, false
+	}
+	return index, union[index], true
+}
+
+// messageSize returns the size of message, which is encoded with schema, like messageJSON.
+func (s sizer) messageSize(
+	schema avro.Schema,
+	namespace string,
+	message protoreflect.Message,
+	recursiveIndex int,
+) (int, error) {
+	schema, err := s.resolve(schema, namespace)
+	if err != nil {
+		return 0, err
+	}
+	if s.opts.isExpandedWKT(message.Descriptor().FullName()) {
+		return s.messageJSONSize(schema, namespace, message, recursiveIndex)
+	}
+	switch schema := schema.(type) {
+	case avro.Union:
+		if !message.IsValid() {
+			return s.size(schema, namespace, nil)
+		}
+		index, member, ok := valueMember(schema)
+		if !ok {
+			return s.messageJSONSize(schema, namespace, message, recursiveIndex)
+		}
+		n, err := s.messageSize(member, namespace, message, recursiveIndex)
+		if err != nil {
+			return 0, err
+		}
+		return varintSize(int64(index)) + n, nil
+	case avro.Record:
+		if !message.IsValid() {
+			return s.messageJSONSize(schema, namespace, message, recursiveIndex)
+		}
+		desc := message.Descriptor()
+		fields := make([]protoreflect.FieldDescriptor, 0, len(schema.Fields))
+		for _, field := range schema.Fields {
+			fd := desc.Fields().ByName(protoreflect.Name(field.Name))
+			if fd == nil {
+				// records with fields of their own, such as tagged oneofs and groups, are sized as encoded.
+				return s.messageJSONSize(schema, namespace, message, recursiveIndex)
+			}
+			fields = append(fields, fd)
+		}
+		name := avro.FullName(schema.Name, schema.Namespace, namespace)
+		var n int
+		for i, field := range schema.Fields {
+			fieldSize, err := s.fieldSize(field.Type, avro.NamespaceOf(name), message, fields[i], recursiveIndex)
+			if err != nil {
+				return 0, fmt.Errorf("%s.%s: %w", name, field.Name, err)
+			}
+			n += fieldSize
+		}
+		return n, nil
+	}
+	return s.messageJSONSize(schema, namespace, message, recursiveIndex)
+}
+
+// messageJSONSize returns the size of the Avro JSON encoding of message.
+func (s sizer) messageJSONSize(
+	schema avro.Schema,
+	namespace string,
+	message protoreflect.Message,
+	recursiveIndex int,
+) (int, error) {
+	datum, err := s.opts.messageJSON(message, recursiveIndex)
+	if err != nil {
+		return 0, err
+	}
+	if _, ok := schema.(avro.Union); !ok && !(s.opts.OmitRootElement && recursiveIndex == 0) {
+		datum = unionMember(datum)
+	}
+	return s.size(schema, namespace, datum)
+}
+
+// fieldSize returns the size of field in the record of message, like recordFieldJSON.
+func (s sizer) fieldSize(
+	schema avro.Schema,
+	namespace string,
+	message protoreflect.Message,
+	field protoreflect.FieldDescriptor,
+	recursiveIndex int,
+) (int, error) {
+	datum, ok, err := s.opts.encodedFieldJSON(message, field)
+	if err != nil {
+		return 0, err
+	}
+	if ok {
+		return s.size(schema, namespace, datum)
+	}
+	if field.ContainingOneof() != nil && !s.opts.isRequiredField(field) {
+		if !message.Has(field) {
+			return s.size(schema, namespace, nil)
+		}
+		return s.valueSize(schema, namespace, field, message.Get(field), recursiveIndex+1, false)
+	}
+	value := message.Get(field)
+	if s.opts.isRequiredField(field) {
+		if field.Message() != nil && !field.IsList() && !field.IsMap() && !message.Has(field) {
+			value = message.NewField(field)
+		}
+		return s.valueSize(schema, namespace, field, value, recursiveIndex+1, true)
+	}
+	if s.opts.OmitEmptyMessages && isEmptyMessageField(field, value) {
+		return s.size(schema, namespace, nil)
+	}
+	return s.valueSize(schema, namespace, field, value, recursiveIndex+1, false)
+}
+
+// valueSize returns the size of the value of field, like fieldJSON.
+// Values of required fields are encoded without union.
+func (s sizer) valueSize(
+	schema avro.Schema,
+	namespace string,
+	field protoreflect.FieldDescriptor,
+	value protoreflect.Value,
+	recursiveIndex int,
+	required bool,
+) (int, error) {
+	jsonSize := func() (int, error) {
+		datum, err := s.opts.fieldJSON(field, value, recursiveIndex)
+		if err != nil {
+			return 0, err
+		}
+		if required {
+			datum = unionMember(datum)
+		}
+		return s.size(schema, namespace, datum)
+	}
+	if field.IsMap() {
+		return jsonSize()
+	}
+	if !field.IsList() {
+		return s.kindSize(schema, namespace, field, value, recursiveIndex)
+	}
+	if keyField, err := s.opts.repeatedMapKey(field); err != nil || keyField != nil {
+		return jsonSize()
+	}
+	schema, err := s.resolve(schema, namespace)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	if union, ok := schema.(avro.Union); ok {
+		index, member, ok := valueMember(union)
+		if !ok {
+			return jsonSize()
+		}
+		n = varintSize(int64(index))
+		schema = member
+	}
+	array, ok := schema.(avro.Array)
+	if !ok {
+		return jsonSize()
+	}
+	list := value.List()
+	// a non-empty array is written as a single block: item count followed by the items.
+	// all arrays are terminated by a zero block count.
+	n += varintSize(0)
+	if list.Len() > 0 {
+		n += varintSize(int64(list.Len()))
+	}
+	for i := 0; i < list.Len(); i++ {
+		itemSize, err := s.kindSize(array.Items, namespace, field, list.Get(i), recursiveIndex)
+		if err != nil {
+			return 0, err
+		}
+		n += itemSize
+	}
+	return n, nil
+}
+
+// kindSize returns the size of a single value of field, like fieldKindJSON.
+func (s sizer) kindSize(
+	schema avro.Schema,
+	namespace string,
+	field protoreflect.FieldDescriptor,
+	value protoreflect.Value,
+	recursiveIndex int,
+) (int, error) {
+	jsonSize := func() (int, error) {
+		datum, err := s.opts.fieldKindJSON(field, value, recursiveIndex)
+		if err != nil {
+			return 0, err
+		}
+		return s.size(schema, namespace, datum)
+	}
+	if _, isEnumInt, err := s.opts.enumIntSymbols(field); err != nil || isEnumInt {
+		return jsonSize()
+	}
+	var datum interface{}
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		protoBytes, err := s.opts.isProtoBytesField(field)
+		if err != nil || protoBytes || s.opts.isJSONMessageField(field, s.opts.nestDepth(field, recursiveIndex)) {
+			return jsonSize()
+		}
+		return s.messageSize(schema, namespace, value.Message(), recursiveIndex)
+	case protoreflect.EnumKind:
+		symbol := string(field.Enum().Values().Get(int(value.Enum())).Name())
+		if !s.opts.isFrozenEnumSymbol(field.Enum(), symbol) {
+			return jsonSize()
+		}
+		datum = symbol
+	case protoreflect.StringKind:
+		datum = value.String()
+	case protoreflect.Int32Kind, protoreflect.Sfixed32Kind, protoreflect.Sint32Kind:
+		datum = int32(value.Int())
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		datum = int32(value.Uint())
+	case protoreflect.Int64Kind, protoreflect.Sfixed64Kind, protoreflect.Sint64Kind:
+		if s.opts.Int64AsString {
+			return jsonSize()
+		}
+		datum = value.Int()
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if s.opts.Int64AsString {
+			return jsonSize()
+		}
+		datum = int64(value.Uint())
+	case protoreflect.BoolKind:
+		if s.opts.BoolAsInt {
+			return jsonSize()
+		}
+		datum = value.Bool()
+	case protoreflect.DoubleKind, protoreflect.FloatKind:
+		if s.opts.isSpecialFloat(value.Float()) {
+			return jsonSize()
+		}
+		datum = value.Float()
+	default:
+		return jsonSize()
+	}
+	schema, err := s.resolve(schema, namespace)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	if union, ok := schema.(avro.Union); ok {
+		index, member, ok := valueMember(union)
+		if !ok {
+			return jsonSize()
+		}
+		if schema, err = s.resolve(member, namespace); err != nil {
+			return 0, err
+		}
+		n = varintSize(int64(index))
+	}
+	valueSize, err := s.size(schema, namespace, datum)
+	if err != nil {
+		return 0, err
+	}
+	return n + valueSize, nil
+}
+
+func (s sizer) size(schema avro.Schema, namespace string, datum interface{}) (int, error) {
+	switch schema := schema.(type) {
+	case avro.Reference:
+		named, err := s.resolve(schema, namespace)
+		if err != nil {
+			return 0, err
+		}
+		return s.size(named, namespace, datum)
+	case avro.Union:
+		return s.unionSize(schema, namespace, datum)
+	case avro.Primitive:
+		return primitiveSize(schema, datum)
+	case avro.AnnotatedPrimitive:
+		return primitiveSize(schema.Primitive, datum)
+	case avro.Record:
+		name := avro.FullName(schema.Name, schema.Namespace, namespace)
+		record, ok := datum.(map[string]interface{})
+		if !ok {
+			return 0, fmt.Errorf("record %s: expected map[string]interface{}, got %T", name, datum)
+		}
+		var n int
+		for _, field := range schema.Fields {
+			fieldSize, err := s.size(field.Type, avro.NamespaceOf(name), record[field.Name])
+			if err != nil {
+				return 0, fmt.Errorf("%s.%s: %w", name, field.Name, err)
+			}
+			n += fieldSize
+		}
+		return n, nil
+	case avro.Enum:
+		name := avro.FullName(schema.Name, schema.Namespace, namespace)
+		symbol, ok := datum.(string)
+		if !ok {
+			return 0, fmt.Errorf("enum %s: expected string, got %T", name, datum)
+		}
+		for i, candidate := range schema.Symbols {
+			if candidate == symbol {
+				return varintSize(int64(i)), nil
+			}
+		}
+		return 0, fmt.Errorf("enum %s: unknown symbol '%s'", name, symbol)
+	case avro.Fixed:
+		return schema.Size, nil
+	case avro.Array:
+		items, ok := datum.([]interface{})
+		if !ok {
+			return 0, fmt.Errorf("array: expected []interface{}, got %T", datum)
+		}
+		// a non-empty array is written as a single block: item count followed by the items.
+		// all arrays are terminated by a zero block count.
+		n := varintSize(0)
+		if len(items) > 0 {
+			n += varintSize(int64(len(items)))
+		}
+		for _, item := range items {
+			itemSize, err := s.size(schema.Items, namespace, item)
+			if err != nil {
+				return 0, err
+			}
+			n += itemSize
+		}
+		return n, nil
+	case avro.Map:
+		values, ok := datum.(map[string]interface{})
+		if !ok {
+			return 0, fmt.Errorf("map: expected map[string]interface{}, got %T", datum)
+		}
+		// maps are written as blocks in the same way as arrays, with each key preceding its value.
+		n := varintSize(0)
+		if len(values) > 0 {
+			n += varintSize(int64(len(values)))
+		}
+		for key, value := range values {
+			valueSize, err := s.size(schema.Values, namespace, value)
+			if err != nil {
+				return 0, err
+			}
+			n += varintSize(int64(len(key))) + len(key) + valueSize
+		}
+		return n, nil
+	}
+	return 0, fmt.Errorf("unsupported schema %T", schema)
+}
+
+func (s sizer) unionSize(union avro.Union, namespace string, datum interface{}) (int, error) {
+	if datum == nil {
+		for i, member := range union {
+			if member == avro.Null() {
+				return varintSize(int64(i)), nil
+			}
+		}
+		return 0, fmt.Errorf("union has no null member")
+	}
+	m, ok := datum.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return 0, fmt.Errorf("union: expected single-key map[string]interface{}, got %T", datum)
+	}
+	for branch, value := range m {
+		for i, member := range union {
+			if branchName(member, namespace) != branch {
+				continue
+			}
+			n, err := s.size(member, namespace, value)
+			if err != nil {
+				return 0, err
+			}
+			return varintSize(int64(i)) + n, nil
+		}
+		return 0, fmt.Errorf("union: unknown branch '%s'", branch)
+	}
+	return 0, nil
+}
+
+// goavroLogicalTypes are the logical types that goavro names union members by, as type.logicalType.
+// Members with other logical types, such as custom bytes logical types, are named by their type.
+var goavroLogicalTypes = map[string]struct{}{
+	"int.date":              {},
+	"int.time-millis":       {},
+	"long.time-micros":      {},
+	"long.timestamp-millis": {},
+	"long.timestamp-micros": {},
+	"bytes.decimal":         {},
+	"fixed.decimal":         {},
+}
+
+// branchName returns the name that identifies schema as a union member.
+func branchName(schema avro.Schema, namespace string) string {
+	switch schema := schema.(type) {
+	case avro.Reference:
+		return avro.FullName(string(schema), "", namespace)
+	case avro.AnnotatedPrimitive:
+		return branchName(schema.Primitive, namespace)
+	case avro.Primitive:
+		if _, ok := goavroLogicalTypes[string(schema.Type)+"."+string(schema.LogicalType)]; ok {
+			return string(schema.Type) + "." + string(schema.LogicalType)
+		}
+		return string(schema.Type)
+	case avro.Record:
+		return avro.FullName(schema.Name, schema.Namespace, namespace)
+	case avro.Enum:
+		return avro.FullName(schema.Name, schema.Namespace, namespace)
+	case avro.Fixed:
+		return avro.FullName(schema.Name, schema.Namespace, namespace)
+	case avro.Array:
+		return string(avro.ArrayType)
+	case avro.Map:
+		return string(avro.MapType)
+	}
+	return ""
+}
+
+func primitiveSize(schema avro.Primitive, datum interface{}) (int, error) {
+	switch schema.Type {
+	case avro.NullType:
+		return 0, nil
+	case avro.BooleanType:
+		return 1, nil
+	case avro.IntType, avro.LongType:
+		switch i := datum.(type) {
+		case int:
+			return varintSize(int64(i)), nil
+		case int32:
+			return varintSize(int64(i)), nil
+		case int64:
+			return varintSize(i), nil
+		}
+		return 0, fmt.Errorf("%s: expected int-like, got %T", schema.Type, datum)
+	case avro.FloatType:
+		return 4, nil
+	case avro.DoubleType:
+		return 8, nil
+	case avro.StringType:
+		str, ok := datum.(string)
+		if !ok {
+			return 0, fmt.Errorf("string: expected string, got %T", datum)
+		}
+		return varintSize(int64(len(str))) + len(str), nil
+	case avro.BytesType:
+		bs, ok := datum.([]byte)
+		if !ok {
+			return 0, fmt.Errorf("bytes: expected []byte, got %T", datum)
+		}
+		return varintSize(int64(len(bs))) + len(bs), nil
+	}
+	return 0, fmt.Errorf("unsupported primitive type %s", schema.Type)
+}
+
+// varintSize returns the size of the zig-zag variable-length encoding of i.
+func varintSize(i int64) int {
+	u := uint64((i << 1) ^ (i >> 63))
+	n := 1
+	for u >= 0x80 {
+		u >>= 7
+		n++
+	}
+	return n
+}
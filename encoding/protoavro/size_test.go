@@ -0,0 +1,204 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/genproto/googleapis/type/date"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gotest.tools/v3/assert"
+)
+
+func TestMarshalOptions_EncodedSize(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		opts MarshalOptions
+		msg  proto.Message
+	}{
+		{
+			name: "library.Book",
+			msg: &library.Book{
+				Name:   "shelves/1/books/1",
+				Author: "J. K. Rowling",
+				Title:  "Harry Potter",
+				Read:   true,
+			},
+		},
+		{
+			name: "library.Book: omit root",
+			opts: MarshalOptions{SchemaOptions: SchemaOptions{OmitRootElement: true}},
+			msg:  &library.Book{Name: strings.Repeat("x", 200)},
+		},
+		{
+			name: "library.UpdateBookRequest: unset message",
+			msg:  &library.UpdateBookRequest{},
+		},
+		{
+			name: "examplev1.ExampleList",
+			msg: &examplev1.ExampleList{
+				Int64List:  []int64{-1, 0, 1, 1 << 40, -1 << 62},
+				StringList: []string{"a", "", "ccc"},
+				EnumList: []examplev1.ExampleList_Enum{
+					examplev1.ExampleList_ENUM_VALUE1,
+					examplev1.ExampleList_ENUM_VALUE2,
+				},
+				NestedList: []*examplev1.ExampleList_Nested{
+					{StringList: []string{"nested"}},
+					{},
+				},
+				FloatValueList: []*wrapperspb.FloatValue{wrapperspb.Float(1)},
+			},
+		},
+		{
+			name: "examplev1.ExampleOptional: unset",
+			msg:  &examplev1.ExampleOptional{},
+		},
+		{
+			name: "examplev1.ExampleOptional",
+			msg: &examplev1.ExampleOptional{
+				OptionalEnum:   examplev1.ExampleOptional_ENUM_VALUE1.Enum(),
+				EnumValue:      examplev1.ExampleOptional_ENUM_VALUE1,
+				OptionalString: proto.String("value"),
+				OptionalInt64:  proto.Int64(-1 << 40),
+				Nested:         &examplev1.ExampleOptional_Nested{Value: "nested"},
+			},
+		},
+		{
+			name: "examplev1.ExampleOptional: omit empty messages",
+			opts: MarshalOptions{OmitEmptyMessages: true},
+			msg:  &examplev1.ExampleOptional{Nested: &examplev1.ExampleOptional_Nested{}},
+		},
+		{
+			name: "examplev1.ExampleMap",
+			msg: &examplev1.ExampleMap{
+				StringToString: map[string]string{"a": "b", "c": "d"},
+				StringToNested: map[string]*examplev1.ExampleMap_Nested{
+					"nested": {StringToString: map[string]string{"e": "f"}},
+				},
+				StringToEnum:  map[string]examplev1.ExampleMap_Enum{"g": examplev1.ExampleMap_ENUM_VALUE2},
+				Int32ToString: map[int32]string{-70: "h"},
+				BoolToString:  map[bool]string{true: "i"},
+			},
+		},
+		{
+			name: "examplev1.ExampleOneof",
+			msg: &examplev1.ExampleOneof{
+				OneofFields_1: &examplev1.ExampleOneof_OneofBool_1{OneofBool_1: true},
+				OneofFields_2: &examplev1.ExampleOneof_OneofMessage{
+					OneofMessage: &examplev1.ExampleOneof_Message{StringValue: "value"},
+				},
+			},
+		},
+		{
+			name: "examplev1.ExampleRecursive",
+			msg: &examplev1.ExampleRecursive{
+				Recursive: &examplev1.ExampleRecursive{
+					Recursive: &examplev1.ExampleRecursive{},
+				},
+			},
+		},
+		{
+			name: "examplev1.ExampleWrappers",
+			msg: &examplev1.ExampleWrappers{
+				DoubleValue: wrapperspb.Double(2),
+				BytesValue:  wrapperspb.Bytes([]byte{1, 2, 3}),
+				Int64Value:  wrapperspb.Int64(-123456789),
+			},
+		},
+		{
+			name: "examplev1.ExampleTimestamp",
+			msg: &examplev1.ExampleTimestamp{
+				Timestamp: timestamppb.New(time.Date(2021, 6, 27, 1, 39, 24, 0, time.UTC)),
+			},
+		},
+		{
+			name: "examplev1.ExampleDate",
+			msg: &examplev1.ExampleDate{
+				Date: &date.Date{Year: 2021, Month: 6, Day: 27},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.opts.EncodedSize(tt.msg)
+			assert.NilError(t, err)
+
+			schema, err := tt.opts.InferSchema(tt.msg.ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			schemaBytes, err := json.Marshal(schema)
+			assert.NilError(t, err)
+			codec, err := goavro.NewCodec(string(schemaBytes))
+			assert.NilError(t, err)
+			datum, err := tt.opts.encodeJSON(tt.msg)
+			assert.NilError(t, err)
+			encoded, err := codec.BinaryFromNative(nil, datum)
+			assert.NilError(t, err)
+			assert.Equal(t, len(encoded), got)
+		})
+	}
+}
+
+func Test_varintSize(t *testing.T) {
+	for _, tt := range []struct {
+		value    int64
+		expected int
+	}{
+		{value: 0, expected: 1},
+		{value: -1, expected: 1},
+		{value: 63, expected: 1},
+		{value: -64, expected: 1},
+		{value: 64, expected: 2},
+		{value: -65, expected: 2},
+		{value: 8191, expected: 2},
+		{value: 8192, expected: 3},
+		{value: 1<<63 - 1, expected: 10},
+		{value: -1 << 63, expected: 10},
+	} {
+		assert.Equal(t, tt.expected, varintSize(tt.value), "value %d", tt.value)
+	}
+}
+
+func Test_sizer_InheritedNamespace(t *testing.T) {
+	// Inner has no namespace and inherits com.example from Outer, so the
+	// references "Inner" and "com.example.Inner" both resolve to it.
+	schema := avro.Record{
+		Type:      avro.RecordType,
+		Name:      "Outer",
+		Namespace: "com.example",
+		Fields: []avro.Field{
+			{
+				Name: "inner",
+				Type: avro.Record{
+					Type:   avro.RecordType,
+					Name:   "Inner",
+					Fields: []avro.Field{{Name: "value", Type: avro.Long()}},
+				},
+			},
+			{Name: "relative", Type: avro.Union{avro.Null(), avro.Reference("Inner")}},
+			{Name: "absolute", Type: avro.Array{Type: avro.ArrayType, Items: avro.Reference("com.example.Inner")}},
+		},
+	}
+	datum := map[string]interface{}{
+		"inner":    map[string]interface{}{"value": int64(1)},
+		"relative": map[string]interface{}{"com.example.Inner": map[string]interface{}{"value": int64(300)}},
+		"absolute": []interface{}{map[string]interface{}{"value": int64(-1)}},
+	}
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	codec, err := goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+	binary, err := codec.BinaryFromNative(nil, datum)
+	assert.NilError(t, err)
+	got, err := newSizer(MarshalOptions{}, schema).size(schema, "", datum)
+	assert.NilError(t, err)
+	assert.Equal(t, len(binary), got)
+}
@@ -0,0 +1,40 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxLengthProp is the custom field property that holds the size hint of string and bytes fields.
+const maxLengthProp = "maxLength"
+
+// sizeHint returns the SizeHints entry of field, and false if it has none.
+func (o SchemaOptions) sizeHint(field protoreflect.FieldDescriptor) (int, bool, error) {
+	maxLength, ok := o.SizeHints[string(field.FullName())]
+	if !ok {
+		return 0, false, nil
+	}
+	if field.IsMap() || field.Kind() != protoreflect.StringKind && field.Kind() != protoreflect.BytesKind {
+		return 0, false, fmt.Errorf("field %s: size hint on field of kind %s", field.Name(), field.Kind())
+	}
+	if maxLength <= 0 {
+		return 0, false, fmt.Errorf("field %s: size hint %d is not positive", field.Name(), maxLength)
+	}
+	return maxLength, true, nil
+}
+
+// checkSizeHint returns an error if EnforceSizeHints is set and length exceeds the size hint of field f.
+func (d *decoder) checkSizeHint(f protoreflect.FieldDescriptor, length int) error {
+	if !d.opts.EnforceSizeHints {
+		return nil
+	}
+	maxLength, ok, err := d.opts.sizeHint(f)
+	if err != nil {
+		return err
+	}
+	if ok && length > maxLength {
+		return fmt.Errorf("field %s: length %d exceeds size hint %d", f.Name(), length, maxLength)
+	}
+	return nil
+}
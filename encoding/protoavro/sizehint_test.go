@@ -0,0 +1,95 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_SizeHints(t *testing.T) {
+	opts := SchemaOptions{SizeHints: map[string]int{
+		"google.example.library.v1.Book.title":       10,
+		"einride.avro.example.v1.ExampleBytes.bytes": 4,
+	}}
+
+	t.Run("schema", func(t *testing.T) {
+		schema, err := opts.InferSchema((&library.Book{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		record, ok := schema.(avro.Union)[1].(avro.Record)
+		assert.Assert(t, ok)
+		assert.Equal(t, "title", record.Fields[2].Name)
+		assert.DeepEqual(t, map[string]interface{}{"maxLength": 10}, record.Fields[2].Props)
+		assert.Assert(t, record.Fields[1].Props == nil)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		assert.Assert(t, strings.Contains(
+			string(schemaBytes),
+			`{"name":"title","type":[{"type":"null"},{"type":"string"}],"maxLength":10}`,
+		))
+		_, err = goavro.NewCodec(string(schemaBytes))
+		assert.NilError(t, err)
+	})
+
+	t.Run("invalid kind", func(t *testing.T) {
+		opts := SchemaOptions{SizeHints: map[string]int{"google.example.library.v1.Book.read": 10}}
+		_, err := opts.InferSchema((&library.Book{}).ProtoReflect().Descriptor())
+		assert.ErrorContains(t, err, "field read: size hint on field of kind bool")
+	})
+
+	for _, tt := range []struct {
+		name        string
+		opts        UnmarshalOptions
+		data        map[string]interface{}
+		got         proto.Message
+		expected    proto.Message
+		errContains string
+	}{
+		{
+			name:     "string within hint",
+			opts:     UnmarshalOptions{SchemaOptions: opts, EnforceSizeHints: true},
+			data:     map[string]interface{}{"title": map[string]interface{}{"string": "short"}},
+			got:      &library.Book{},
+			expected: &library.Book{Title: "short"},
+		},
+		{
+			name:        "string exceeds hint",
+			opts:        UnmarshalOptions{SchemaOptions: opts, EnforceSizeHints: true},
+			data:        map[string]interface{}{"title": map[string]interface{}{"string": "far too long"}},
+			got:         &library.Book{},
+			errContains: "field title: length 12 exceeds size hint 10",
+		},
+		{
+			name:        "bytes exceeds hint",
+			opts:        UnmarshalOptions{SchemaOptions: opts, EnforceSizeHints: true},
+			data:        map[string]interface{}{"bytes": map[string]interface{}{"bytes": []byte("12345")}},
+			got:         &examplev1.ExampleBytes{},
+			errContains: "field bytes: length 5 exceeds size hint 4",
+		},
+		{
+			name:     "not enforced",
+			opts:     UnmarshalOptions{SchemaOptions: opts},
+			data:     map[string]interface{}{"title": map[string]interface{}{"string": "far too long"}},
+			got:      &library.Book{},
+			expected: &library.Book{Title: "far too long"},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Unmarshal(tt.data, tt.got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, tt.got, protocmp.Transform())
+		})
+	}
+}
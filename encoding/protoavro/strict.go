@@ -0,0 +1,74 @@
+package protoavro
+
+// Limits enabled by Strict when the options do not set tighter ones.
+const (
+	strictMaxDepth        = 100
+	strictMaxElements     = 1 << 20
+	strictMaxStringLength = 1 << 20
+)
+
+// Strict returns a copy of o with maximal checking enabled, for validation pipelines
+// that must reject data that does not follow the schema closely:
+//
+//   - The lenient behaviors of Tolerant, UnknownUnionBranchAsNull, AutoParseStringifiedScalars,
+//     AutoParseStringifiedMessages and FlattenNestedArrays are disabled, and CoercionTable is cleared,
+//     so unknown fields, unknown union branches and values that need coercion, such as numeric strings,
+//     records encoded as JSON strings and nested arrays, are rejected.
+//   - Field names and map keys are matched exactly: NameNormalization, FieldNameCase and MapKeyNormalizer
+//     are cleared.
+//   - Null map values and null list elements are rejected, with NullMapValueError and NullListElementError.
+//   - RawExtras and OnUnknownField are cleared, so that unknown fields are rejected instead of being
+//     stored or skipped.
+//   - RejectUnknownEnums rejects unknown enum symbols, and enum values are only accepted as exact symbols:
+//     AcceptEnumNumbers, AcceptEnumObjects, TrimEnumSymbols and StripEnumNamespace are disabled.
+//   - RejectAmbiguousFieldNames rejects field names that match different fields.
+//   - EnforceProto2Required rejects records with missing proto2 required fields.
+//   - FailOnPrecisionLoss rejects integers truncated to 32-bit or unsigned fields and doubles rounded to float
+//     fields, and FloatOverflowError rejects values that exceed the range of float fields.
+//   - Timestamps are only accepted as encoded by the schema: AutoDetectTimestampPrecision is disabled,
+//     TimestampInput is TimestampInputDefault and TimestampLayouts are cleared.
+//   - MaxDepth, MaxElements and MaxStringLength default to 100 records, 1Mi elements and 1 MiB.
+//
+// Float and double fields only accept Avro float and double values regardless of options,
+// and data is validated against WriterSchema when it is set.
+func (o UnmarshalOptions) Strict() UnmarshalOptions {
+	o.NumericStrings = false
+	o.LenientBools = false
+	o.ScalarAsList = false
+	o.DiscardUnknownFields = false
+	o.CaseInsensitiveFieldNames = false
+	o.UnknownUnionBranchAsNull = false
+	o.AutoParseStringifiedScalars = false
+	o.AutoParseStringifiedMessages = false
+	o.CoercionTable = nil
+	o.FlattenNestedArrays = false
+	o.NameNormalization = nil
+	o.FieldNameCase = FieldNameAsIs
+	o.MapKeyNormalizer = nil
+	o.NullMapValuePolicy = NullMapValueError
+	o.NullListElementPolicy = NullListElementError
+	o.RawExtras = nil
+	o.OnUnknownField = nil
+	o.RejectUnknownEnums = true
+	o.AcceptEnumNumbers = false
+	o.AcceptEnumObjects = false
+	o.TrimEnumSymbols = false
+	o.StripEnumNamespace = false
+	o.RejectAmbiguousFieldNames = true
+	o.EnforceProto2Required = true
+	o.FailOnPrecisionLoss = true
+	o.FloatOverflowPolicy = FloatOverflowError
+	o.AutoDetectTimestampPrecision = false
+	o.TimestampInput = TimestampInputDefault
+	o.TimestampLayouts = nil
+	if o.MaxDepth == 0 {
+		o.MaxDepth = strictMaxDepth
+	}
+	if o.MaxElements == 0 {
+		o.MaxElements = strictMaxElements
+	}
+	if o.MaxStringLength == 0 {
+		o.MaxStringLength = strictMaxStringLength
+	}
+	return o
+}
@@ -0,0 +1,47 @@
+package protoavro
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// SubjectNameStrategy returns the schema registry subject of the schema for message desc,
+// when used as the key or the value of records in topic.
+type SubjectNameStrategy func(topic string, desc protoreflect.MessageDescriptor, isKey bool) string
+
+// TopicNameStrategy returns subjects named by the topic, such as "orders-value", so that a topic
+// has a single schema for its keys and a single schema for its values.
+// It is the default strategy of schema registries.
+func TopicNameStrategy(topic string, _ protoreflect.MessageDescriptor, isKey bool) string {
+	return topic + subjectSuffix(isKey)
+}
+
+// RecordNameStrategy returns subjects named by the full name of the message, such as
+// "einride.example.v1.Order", so that a topic can hold several message types that are evolved
+// across all topics. The message full name is the record full name unless SchemaOptions.RecordNames,
+// RecordNamePrefix or RecordNameSuffix rename the record.
+func RecordNameStrategy(_ string, desc protoreflect.MessageDescriptor, _ bool) string {
+	return string(desc.FullName())
+}
+
+// TopicRecordNameStrategy returns subjects named by the topic and the full name of the message,
+// such as "orders-einride.example.v1.Order", so that a topic can hold several message types that
+// are evolved per topic.
+func TopicRecordNameStrategy(topic string, desc protoreflect.MessageDescriptor, _ bool) string {
+	return topic + "-" + string(desc.FullName())
+}
+
+func subjectSuffix(isKey bool) string {
+	if isKey {
+		return "-key"
+	}
+	return "-value"
+}
+
+// Subject returns the schema registry subject of the schema for message desc, when used as the key
+// or the value of records in topic, according to SubjectNameStrategy, or TopicNameStrategy when not set.
+func (o SchemaOptions) Subject(topic string, desc protoreflect.MessageDescriptor, isKey bool) string {
+	if o.SubjectNameStrategy != nil {
+		return o.SubjectNameStrategy(topic, desc, isKey)
+	}
+	return TopicNameStrategy(topic, desc, isKey)
+}
@@ -0,0 +1,55 @@
+package protoavro
+
+import (
+	"testing"
+
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_Subject(t *testing.T) {
+	desc := (&library.Book{}).ProtoReflect().Descriptor()
+	for _, tt := range []struct {
+		name     string
+		opts     SchemaOptions
+		isKey    bool
+		expected string
+	}{
+		{
+			name:     "default value",
+			expected: "books-value",
+		},
+		{
+			name:     "default key",
+			isKey:    true,
+			expected: "books-key",
+		},
+		{
+			name:     "topic name",
+			opts:     SchemaOptions{SubjectNameStrategy: TopicNameStrategy},
+			isKey:    true,
+			expected: "books-key",
+		},
+		{
+			name:     "record name",
+			opts:     SchemaOptions{SubjectNameStrategy: RecordNameStrategy},
+			expected: "google.example.library.v1.Book",
+		},
+		{
+			name:     "record name key",
+			opts:     SchemaOptions{SubjectNameStrategy: RecordNameStrategy},
+			isKey:    true,
+			expected: "google.example.library.v1.Book",
+		},
+		{
+			name:     "topic record name",
+			opts:     SchemaOptions{SubjectNameStrategy: TopicRecordNameStrategy},
+			expected: "books-google.example.library.v1.Book",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.opts.Subject("books", desc, tt.isKey))
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"go.einride.tech/protobuf-avro/internal/wkt"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TimestampInput determines which representations of google.protobuf.Timestamp values are accepted on decode,
+// in addition to the schema encoding.
+type TimestampInput int
+
+const (
+	// TimestampInputDefault accepts timestamps as encoded by the schema.
+	TimestampInputDefault TimestampInput = iota
+	// TimestampInputFloatSeconds also accepts floating point seconds since the Unix epoch, such as
+	// 1712345678.123456, as emitted by some producers. Fractions are rounded to microseconds.
+	// Doubles only have microsecond precision up to about year 2242, and decoding less precise values
+	// adds a warning. Producers should prefer the integer timestamp-micros encoding.
+	TimestampInputFloatSeconds
+)
+
+// floatSecondsKeys are the union branches that floating point seconds are accepted in.
+var floatSecondsKeys = []string{"double", "float", "long.timestamp-micros"}
+
+// floatSeconds returns the floating point seconds of a timestamp, if v holds a floating point value.
+func floatSeconds(v map[string]interface{}) (float64, bool) {
+	for _, key := range floatSecondsKeys {
+		switch value := v[key].(type) {
+		case float64:
+			return value, true
+		case float32:
+			return float64(value), true
+		case json.Number:
+			if f, err := value.Float64(); err == nil {
+				return f, true
+			}
+		}
+	}
+	return 0, false
+}
+
+// isFloatSeconds returns true if data is an unwrapped floating point value.
+func isFloatSeconds(data interface{}) bool {
+	switch data.(type) {
+	case float64, float32, json.Number:
+		return true
+	}
+	return false
+}
+
+func (d *decoder) decodeFloatSeconds(f float64) (*timestamppb.Timestamp, error) {
+	if math.IsNaN(f) || f < minTimestampSeconds || f >= maxTimestampSeconds+1 {
+		return nil, fmt.Errorf("google.protobuf.Timestamp: float seconds %v out of range", f)
+	}
+	if ulp := math.Nextafter(math.Abs(f), math.Inf(1)) - math.Abs(f); ulp > 1e-6 {
+		d.warn("float seconds %v only have a precision of %v seconds", f, ulp)
+	}
+	seconds, fraction := math.Modf(f)
+	micros := int64(math.Round(fraction * 1e6))
+	if micros < 0 {
+		seconds--
+		micros += 1e6
+	}
+	if micros >= 1e6 {
+		seconds++
+		micros -= 1e6
+	}
+	return &timestamppb.Timestamp{Seconds: int64(seconds), Nanos: int32(micros * 1e3)}, nil
+}
+
+// isoTimestampLayout is the layout of ISO-8601 timestamps without a time zone.
+const isoTimestampLayout = "2006-01-02T15:04:05.999999999"
+
+// isoTimestamp returns the ISO-8601 string of a timestamp, if v holds a string value.
+func isoTimestamp(v map[string]interface{}) (string, bool) {
+	s, ok := v["string"].(string)
+	return s, ok
+}
+
+// parseTimestampLayouts parses s with the first of TimestampLayouts that matches it, in loc when the
+// layout has no time zone.
+func (d *decoder) parseTimestampLayouts(s string, loc *time.Location) (time.Time, bool) {
+	for _, layout := range d.opts.TimestampLayouts {
+		if tm, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return tm, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// decodeISOTimestamp decodes an ISO-8601 timestamp, such as 2024-01-02T03:04:05Z, or a timestamp in one of
+// TimestampLayouts. Timestamps without a time zone, such as 2024-01-02T03:04:05, are ambiguous and are
+// interpreted in DefaultTimeZone, or UTC when not set.
+func (d *decoder) decodeISOTimestamp(s string) (*timestamppb.Timestamp, error) {
+	tm, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		loc := d.opts.DefaultTimeZone
+		if loc == nil {
+			loc = time.UTC
+		}
+		var errNoZone error
+		if tm, errNoZone = time.ParseInLocation(isoTimestampLayout, s, loc); errNoZone != nil {
+			var ok bool
+			if tm, ok = d.parseTimestampLayouts(s, loc); !ok {
+				if len(d.opts.TimestampLayouts) > 0 {
+					return nil, fmt.Errorf("google.protobuf.Timestamp: %q matches neither RFC 3339 nor TimestampLayouts", s)
+				}
+				return nil, fmt.Errorf("google.protobuf.Timestamp: %w", err)
+			}
+		}
+	}
+	ts := timestamppb.New(tm)
+	if err := ts.CheckValid(); err != nil {
+		return nil, fmt.Errorf("google.protobuf.Timestamp: %w", err)
+	}
+	return ts, nil
+}
+
+// schemaDualTimestamp returns the schema of timestamps with SchemaOptions.TimestampDual, a record with
+// both an ISO-8601 string and microseconds since the epoch.
+func schemaDualTimestamp() avro.Schema {
+	return avro.Nullable(avro.Record{
+		Type:      avro.RecordType,
+		Name:      "Timestamp",
+		Namespace: "google.protobuf",
+		Fields: []avro.Field{
+			{Name: "iso", Type: avro.Nullable(avro.String())},
+			{Name: "micros", Type: avro.Nullable(avro.TimestampMicros())},
+		},
+	})
+}
+
+func (o *MarshalOptions) encodeDualTimestamp(seconds, nanos int64) map[string]interface{} {
+	return o.unionValue(wkt.Timestamp, map[string]interface{}{
+		"iso":    o.unionValue("string", time.Unix(seconds, nanos).UTC().Format(time.RFC3339Nano)),
+		"micros": o.unionValue("long.timestamp-micros", seconds*1e6+nanos/1e3),
+	})
+}
+
+// dualTimestampRecord returns the record of a timestamp encoded with SchemaOptions.TimestampDual,
+// optionally wrapped in a union.
+func dualTimestampRecord(v map[string]interface{}) (map[string]interface{}, bool) {
+	if record, ok := v[wkt.Timestamp].(map[string]interface{}); ok && len(v) == 1 {
+		return record, true
+	}
+	_, hasISO := v["iso"]
+	_, hasMicros := v["micros"]
+	return v, hasISO || hasMicros
+}
+
+// decodeDualTimestamp decodes the micros field of the record, or the iso field when micros is null.
+func (d *decoder) decodeDualTimestamp(record map[string]interface{}) (*timestamppb.Timestamp, error) {
+	if micros := unwrapUnion(record["micros"]); micros != nil {
+		if tm, ok := micros.(time.Time); ok {
+			return timestamppb.New(tm), nil
+		}
+		i, err := decodeIntValue(micros)
+		if err != nil {
+			return nil, fmt.Errorf("google.protobuf.Timestamp: micros: %w", err)
+		}
+		return timestamppb.New(time.Unix(i/1e6, (i%1e6)*1e3)), nil
+	}
+	switch iso := unwrapUnion(record["iso"]).(type) {
+	case nil:
+		return nil, fmt.Errorf("google.protobuf.Timestamp: neither iso nor micros is set")
+	case string:
+		return d.decodeISOTimestamp(iso)
+	default:
+		return nil, fmt.Errorf("google.protobuf.Timestamp: iso: expected string, got %T", iso)
+	}
+}
+
+// unwrapUnion returns the value of a union with a single branch, or v if it is not a union.
+func unwrapUnion(v interface{}) interface{} {
+	if union, ok := v.(map[string]interface{}); ok && len(union) == 1 {
+		for _, value := range union {
+			return value
+		}
+	}
+	return v
+}
+
+// timestampEpochProp is the custom field property that holds the epoch of timestamp fields with
+// SchemaOptions.TimestampEpoch.
+const timestampEpochProp = "proto.timestampEpoch"
+
+// timestampEpochProp returns the epoch of timestamp field as an RFC 3339 string, and false if
+// field is not a timestamp field or TimestampEpoch is not set.
+func (o SchemaOptions) timestampEpochProp(field protoreflect.FieldDescriptor) (string, bool) {
+	if o.TimestampEpoch.IsZero() || o.TimestampDual || field.IsMap() || field.Message() == nil ||
+		field.Message().FullName() != wkt.Timestamp {
+		return "", false
+	}
+	return o.TimestampEpoch.UTC().Format(time.RFC3339Nano), true
+}
+
+// epochOffset returns the offset of a timestamp from epoch, with nanos normalized to [0, 1e9).
+func epochOffset(seconds, nanos int64, epoch time.Time) (int64, int64) {
+	seconds -= epoch.Unix()
+	nanos -= int64(epoch.Nanosecond())
+	if nanos < 0 {
+		seconds--
+		nanos += 1e9
+	}
+	return seconds, nanos
+}
+
+// decodeEpochTimestamp decodes a timestamp encoded as an offset from UnmarshalOptions.TimestampEpoch.
+func (d *decoder) decodeEpochTimestamp(v map[string]interface{}) (*timestamppb.Timestamp, error) {
+	offset, err := decodeInt(v, string(avro.LongType))
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Timestamp: %w", err)
+	}
+	epoch := d.opts.TimestampEpoch
+	var tm time.Time
+	if d.opts.TimestampMillis {
+		tm = time.Unix(epoch.Unix()+offset/1e3, int64(epoch.Nanosecond())+(offset%1e3)*1e6)
+	} else {
+		tm = time.Unix(epoch.Unix()+offset/1e6, int64(epoch.Nanosecond())+(offset%1e6)*1e3)
+	}
+	ts := timestamppb.New(tm)
+	if err := ts.CheckValid(); err != nil {
+		return nil, fmt.Errorf("google.protobuf.Timestamp: %w", err)
+	}
+	return ts, nil
+}
@@ -0,0 +1,191 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Tolerant returns a copy of o with the lenient behaviors enabled that are commonly needed together
+// to decode messy data from producers that do not follow the schema closely:
+//
+//   - NumericStrings decodes numeric strings into numeric fields.
+//   - LenientBools decodes strings such as "true" and the integers 0 and 1 into bool fields.
+//   - ScalarAsList decodes single values of repeated fields as lists with one element.
+//   - DiscardUnknownFields skips record fields that match no field of the message.
+//   - CaseInsensitiveFieldNames matches record field names ignoring case.
+//
+// Unknown enum symbols are always decoded as the zero enum value, and need no option.
+func (o UnmarshalOptions) Tolerant() UnmarshalOptions {
+	o.NumericStrings = true
+	o.LenientBools = true
+	o.ScalarAsList = true
+	o.DiscardUnknownFields = true
+	o.CaseInsensitiveFieldNames = true
+	return o
+}
+
+// coerceScalar converts lenient encodings of scalar values, such as numeric strings, into the values
+// that decodeFieldKind expects for the kind of field f.
+// Values that can not be converted are returned unchanged, to be rejected by decodeFieldKind.
+func (d *decoder) coerceScalar(data interface{}, f protoreflect.FieldDescriptor) interface{} {
+	if !d.opts.NumericStrings && !d.opts.LenientBools {
+		return data
+	}
+	str, isString := data.(string)
+	if m, ok := data.(map[string]interface{}); ok && len(m) == 1 {
+		str, isString = m[string(avro.StringType)].(string)
+	}
+	switch f.Kind() {
+	case protoreflect.BoolKind:
+		if !d.opts.LenientBools {
+			return data
+		}
+		if isString {
+			if b, ok := d.opts.BoolStrings.parse(str); ok {
+				return b
+			}
+			return data
+		}
+		if i, err := decodeIntValue(data); err == nil && (i == 0 || i == 1) {
+			return i == 1
+		}
+	case protoreflect.Int32Kind, protoreflect.Sfixed32Kind, protoreflect.Sint32Kind,
+		protoreflect.Int64Kind, protoreflect.Sfixed64Kind, protoreflect.Sint64Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if d.opts.NumericStrings && isString {
+			return json.Number(strings.TrimSpace(str))
+		}
+	case protoreflect.DoubleKind:
+		if !d.opts.NumericStrings || !isString {
+			return data
+		}
+		if dbl, err := strconv.ParseFloat(strings.TrimSpace(str), 64); err == nil {
+			return dbl
+		}
+	case protoreflect.FloatKind:
+		if !d.opts.NumericStrings || !isString {
+			return data
+		}
+		if d.opts.FailOnPrecisionLoss {
+			// the value is rounded to float by decodeFieldKind, which checks for precision loss.
+			if dbl, err := strconv.ParseFloat(strings.TrimSpace(str), 64); err == nil {
+				return dbl
+			}
+			return data
+		}
+		if flt, err := strconv.ParseFloat(strings.TrimSpace(str), 32); err == nil {
+			return float32(flt)
+		}
+	}
+	return data
+}
+
+// BoolStrings are the strings that UnmarshalOptions.LenientBools decodes into bool fields as true and false.
+// Strings are matched ignoring case and surrounding whitespace.
+type BoolStrings struct {
+	True, False []string
+}
+
+// defaultBoolStrings are used when UnmarshalOptions.BoolStrings is empty. They include the strings accepted
+// by strconv.ParseBool, and the forms common in data derived from flat files.
+var defaultBoolStrings = BoolStrings{
+	True:  []string{"true", "t", "yes", "y", "1"},
+	False: []string{"false", "f", "no", "n", "0"},
+}
+
+// parse returns the bool value of str, and false if str is not one of the strings of b.
+func (b BoolStrings) parse(str string) (value bool, ok bool) {
+	if len(b.True) == 0 && len(b.False) == 0 {
+		b = defaultBoolStrings
+	}
+	str = strings.TrimSpace(str)
+	for _, t := range b.True {
+		if strings.EqualFold(str, t) {
+			return true, true
+		}
+	}
+	for _, f := range b.False {
+		if strings.EqualFold(str, f) {
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// maxStringifiedDepth is the number of times that stringified scalars are unquoted, to decode
+// double-encoded values such as "\"123\"".
+const maxStringifiedDepth = 3
+
+// parseStringifiedScalar parses string values of bool and numeric fields as JSON, when
+// AutoParseStringifiedScalars is set, so that "123" decodes into int fields and "true" into bool fields.
+// Values that do not parse as JSON of the kind of field f are returned unchanged.
+func (d *decoder) parseStringifiedScalar(data interface{}, f protoreflect.FieldDescriptor) interface{} {
+	if !d.opts.AutoParseStringifiedScalars {
+		return data
+	}
+	str, isString := data.(string)
+	if m, ok := data.(map[string]interface{}); ok && len(m) == 1 {
+		str, isString = m[string(avro.StringType)].(string)
+	}
+	if !isString {
+		return data
+	}
+	var value interface{} = str
+	for i := 0; i < maxStringifiedDepth; i++ {
+		s, ok := value.(string)
+		if !ok {
+			break
+		}
+		decoder := json.NewDecoder(strings.NewReader(s))
+		decoder.UseNumber()
+		var parsed interface{}
+		if err := decoder.Decode(&parsed); err != nil || decoder.More() {
+			return data
+		}
+		value = parsed
+	}
+	switch v := value.(type) {
+	case bool:
+		if f.Kind() == protoreflect.BoolKind {
+			return v
+		}
+	case json.Number:
+		switch f.Kind() {
+		case protoreflect.DoubleKind, protoreflect.FloatKind:
+			if dbl, err := v.Float64(); err == nil {
+				return dbl
+			}
+		case protoreflect.Int32Kind, protoreflect.Sfixed32Kind, protoreflect.Sint32Kind,
+			protoreflect.Int64Kind, protoreflect.Sfixed64Kind, protoreflect.Sint64Kind,
+			protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+			protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+			return v
+		}
+	}
+	return data
+}
+
+// findFieldFold returns the field of desc with a JSON name or text name that matches name ignoring case,
+// or nil if no field matches.
+func findFieldFold(desc protoreflect.MessageDescriptor, name string) (protoreflect.FieldDescriptor, error) {
+	var match protoreflect.FieldDescriptor
+	for i := 0; i < desc.Fields().Len(); i++ {
+		fd := desc.Fields().Get(i)
+		if !strings.EqualFold(fd.JSONName(), name) && !strings.EqualFold(fd.TextName(), name) {
+			continue
+		}
+		if match != nil {
+			return nil, fmt.Errorf(
+				"ambiguous field name %s: matches fields %s and %s ignoring case", name, match.Name(), fd.Name(),
+			)
+		}
+		match = fd
+	}
+	return match, nil
+}
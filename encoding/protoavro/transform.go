@@ -0,0 +1,19 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// transform applies the ValueTransforms to data, in order, before it is decoded as a value of field f.
+func (d *decoder) transform(data interface{}, f protoreflect.FieldDescriptor) (interface{}, error) {
+	for i, fn := range d.opts.ValueTransforms {
+		transformed, err := fn(f, data)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: value transform %d: %w", f.Name(), i, err)
+		}
+		data = transformed
+	}
+	return data, nil
+}
@@ -0,0 +1,74 @@
+package protoavro
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnmarshalOptions_ValueTransforms(t *testing.T) {
+	stringTransform := func(fn func(string) string) func(protoreflect.FieldDescriptor, interface{}) (interface{}, error) {
+		return func(fd protoreflect.FieldDescriptor, raw interface{}) (interface{}, error) {
+			if fd.Kind() != protoreflect.StringKind {
+				return raw, nil
+			}
+			if union, ok := raw.(map[string]interface{}); ok {
+				if str, ok := union["string"].(string); ok {
+					return map[string]interface{}{"string": fn(str)}, nil
+				}
+			}
+			if str, ok := raw.(string); ok {
+				return fn(str), nil
+			}
+			return raw, nil
+		}
+	}
+	opts := UnmarshalOptions{
+		ValueTransforms: []func(protoreflect.FieldDescriptor, interface{}) (interface{}, error){
+			stringTransform(strings.TrimSpace),
+			stringTransform(strings.ToLower),
+		},
+	}
+	data := map[string]interface{}{
+		"int64_list": map[string]interface{}{"array": []interface{}{
+			map[string]interface{}{"long": int64(1)},
+		}},
+		"string_list": map[string]interface{}{"array": []interface{}{
+			map[string]interface{}{"string": " Foo "},
+			"BAR\t",
+		}},
+	}
+	var got examplev1.ExampleList
+	assert.NilError(t, opts.Unmarshal(data, &got))
+	assert.DeepEqual(t, &examplev1.ExampleList{
+		Int64List:  []int64{1},
+		StringList: []string{"foo", "bar"},
+	}, &got, protocmp.Transform())
+	t.Run("order", func(t *testing.T) {
+		var got examplev1.ExampleList
+		assert.NilError(t, UnmarshalOptions{
+			ValueTransforms: []func(protoreflect.FieldDescriptor, interface{}) (interface{}, error){
+				stringTransform(func(s string) string { return s + "!" }),
+				stringTransform(strings.TrimSpace),
+			},
+		}.Unmarshal(data, &got))
+		assert.DeepEqual(t, []string{"Foo !", "BAR\t!"}, got.StringList)
+	})
+	t.Run("error", func(t *testing.T) {
+		var got examplev1.ExampleList
+		err := UnmarshalOptions{
+			ValueTransforms: []func(protoreflect.FieldDescriptor, interface{}) (interface{}, error){
+				stringTransform(strings.TrimSpace),
+				func(fd protoreflect.FieldDescriptor, raw interface{}) (interface{}, error) {
+					return nil, errors.New("boom")
+				},
+			},
+		}.Unmarshal(map[string]interface{}{"string_list": data["string_list"]}, &got)
+		assert.ErrorContains(t, err, "field string_list: value transform 1: boom")
+	})
+}
@@ -0,0 +1,72 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// defaultTypeNameField is the name of the field that holds the message full name with EmbedTypeName.
+const defaultTypeNameField = "_type"
+
+// typeNameField returns the name of the field that holds the message full name with EmbedTypeName.
+func (o SchemaOptions) typeNameField() string {
+	if o.TypeNameField != "" {
+		return o.TypeNameField
+	}
+	return defaultTypeNameField
+}
+
+// withTypeNameField returns the fields of the record of message with the type name field appended,
+// when EmbedTypeName is set.
+func (o SchemaOptions) withTypeNameField(message protoreflect.MessageDescriptor, fields []avro.Field) (
+	[]avro.Field,
+	error,
+) {
+	if !o.EmbedTypeName {
+		return fields, nil
+	}
+	name := o.typeNameField()
+	for _, field := range fields {
+		if field.Name == name {
+			return nil, fmt.Errorf("%s: field %s conflicts with the type name field", message.FullName(), name)
+		}
+	}
+	return append(fields, avro.Field{
+		Name: name,
+		Type: avro.String(),
+	}), nil
+}
+
+// removeTypeName returns record without the type name field, which must hold the full name of message
+// when present.
+func (o *UnmarshalOptions) removeTypeName(
+	message protoreflect.MessageDescriptor,
+	record map[string]interface{},
+) (map[string]interface{}, error) {
+	if !o.EmbedTypeName {
+		return record, nil
+	}
+	name := o.typeNameField()
+	data, ok := record[name]
+	if !ok {
+		return record, nil
+	}
+	if data != nil {
+		typeName, err := decodeStringLike(data, string(avro.StringType))
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", name, err)
+		}
+		if typeName != string(message.FullName()) {
+			return nil, fmt.Errorf("field %s: type name %s does not match %s", name, typeName, message.FullName())
+		}
+	}
+	result := make(map[string]interface{}, len(record)-1)
+	for key, value := range record {
+		if key != name {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package protoavro
+
+import (
+	"testing"
+
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"gotest.tools/v3/assert"
+)
+
+func TestSchemaOptions_EmbedTypeName(t *testing.T) {
+	opts := SchemaOptions{EmbedTypeName: true}
+	schema, err := opts.InferSchema((&examplev1.ExampleNested{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	root := schema.(avro.Union)[1].(avro.Record)
+	assert.DeepEqual(t, avro.Field{Name: "_type", Type: avro.String()}, root.Fields[len(root.Fields)-1])
+	level1 := root.Fields[0].Type.(avro.Union)[1].(avro.Record)
+	assert.Equal(t, "_type", level1.Fields[len(level1.Fields)-1].Name)
+	level2 := level1.Fields[0].Type.(avro.Union)[1].(avro.Record)
+	entry := level2.Fields[1].Type.(avro.Union)[1].(avro.Array).Items.(avro.Record)
+	assert.Equal(t, 2, len(entry.Fields))
+
+	msg := &examplev1.ExampleNested{
+		Name: "a",
+		Level1: &examplev1.ExampleNested_Level1{
+			Level2: &examplev1.ExampleNested_Level2{
+				Level3Map: map[string]*examplev1.ExampleNested_Level3{"b": {Value: "c"}},
+			},
+		},
+	}
+	native := assertRoundTrip(t, opts, schema, msg)
+	record := native.(map[string]interface{})["einride.avro.example.v1.ExampleNested"].(map[string]interface{})
+	assert.Equal(t, "einride.avro.example.v1.ExampleNested", record["_type"])
+	nested := record["level1"].(map[string]interface{})["einride.avro.example.v1.ExampleNested.Level1"]
+	assert.Equal(t, "einride.avro.example.v1.ExampleNested.Level1", nested.(map[string]interface{})["_type"])
+
+	t.Run("custom field name", func(t *testing.T) {
+		opts := SchemaOptions{EmbedTypeName: true, TypeNameField: "proto_type"}
+		schema, err := opts.InferSchema((&examplev1.ExampleNested{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		native := assertRoundTrip(t, opts, schema, msg)
+		record := native.(map[string]interface{})["einride.avro.example.v1.ExampleNested"].(map[string]interface{})
+		assert.Equal(t, "einride.avro.example.v1.ExampleNested", record["proto_type"])
+	})
+	t.Run("mismatched type name", func(t *testing.T) {
+		var got examplev1.ExampleNested
+		err := UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{
+			"name":  "a",
+			"_type": "einride.avro.example.v1.ExampleList",
+		}, &got)
+		assert.ErrorContains(t, err, "field _type: type name einride.avro.example.v1.ExampleList does not match")
+	})
+	t.Run("absent type name", func(t *testing.T) {
+		var got examplev1.ExampleNested
+		assert.NilError(t, UnmarshalOptions{SchemaOptions: opts}.Unmarshal(map[string]interface{}{"name": "a"}, &got))
+		assert.Equal(t, "a", got.Name)
+	})
+	t.Run("conflicting field", func(t *testing.T) {
+		opts := SchemaOptions{EmbedTypeName: true, TypeNameField: "name"}
+		_, err := opts.InferSchema((&examplev1.ExampleNested{}).ProtoReflect().Descriptor())
+		assert.ErrorContains(t, err, "field name conflicts with the type name field")
+	})
+}
@@ -0,0 +1,228 @@
+package protoavro
+
+import (
+	"fmt"
+
+	"go.einride.tech/protobuf-avro/avro"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// UnionSchema returns an Avro union of the records for the protobuf message descriptors,
+// for data where each value may be any one of several unrelated message types.
+// Values are distinguished by the record name of their union branch.
+func (o SchemaOptions) UnionSchema(descs []protoreflect.MessageDescriptor) (avro.Schema, error) {
+	s := o.newSchemaInferrer()
+	union := avro.Union{avro.Null()}
+	seen := make(map[protoreflect.FullName]struct{}, len(descs))
+	for _, desc := range descs {
+		if _, ok := seen[desc.FullName()]; ok {
+			return nil, fmt.Errorf("duplicate message type %s", desc.FullName())
+		}
+		seen[desc.FullName()] = struct{}{}
+		schema, err := s.inferMessageSchema(desc, 0)
+		if err != nil {
+			return nil, err
+		}
+		// Avro unions may not contain other unions, so flatten the nullable message schemas.
+		for _, member := range avro.Nullable(schema) {
+			if member == avro.Null() {
+				continue
+			}
+			union = append(union, member)
+		}
+	}
+	return union, nil
+}
+
+// UnmarshalUnion decodes a value written with a schema from UnionSchema.
+// The message type is resolved from the record name of the union branch.
+// A nil message is returned for null values.
+func (o UnmarshalOptions) UnmarshalUnion(
+	data interface{},
+	resolver protoregistry.MessageTypeResolver,
+) (proto.Message, error) {
+	if data == nil {
+		return nil, nil
+	}
+	branches, ok := data.(map[string]interface{})
+	if !ok || len(branches) != 1 {
+		return nil, fmt.Errorf("expected union encoded as map[string]interface{} with a single key, got %T", data)
+	}
+	for name := range branches {
+		messageType, err := resolver.FindMessageByName(o.messageName(name))
+		if err != nil {
+			return nil, fmt.Errorf("resolve union branch '%s': %w", name, err)
+		}
+		message := messageType.New().Interface()
+		if err := o.Unmarshal(data, message); err != nil {
+			return nil, err
+		}
+		return message, nil
+	}
+	return nil, nil
+}
+
+// countResolution records a resolution of a message type by name, and returns an error if it exceeds
+// UnmarshalOptions.MaxUnionResolutions.
+func (d *decoder) countResolution() error {
+	d.resolutions++
+	if d.opts.MaxUnionResolutions > 0 && d.resolutions > d.opts.MaxUnionResolutions {
+		return fmt.Errorf("%d type resolutions exceed max union resolutions %d", d.resolutions, d.opts.MaxUnionResolutions)
+	}
+	return nil
+}
+
+// isUnknownUnionBranch returns true if UnknownUnionBranchAsNull is set and data is a union value
+// with a branch that field f is never encoded as.
+// When element is true, data is an element of a repeated field, or a map value.
+func (d *decoder) isUnknownUnionBranch(data interface{}, f protoreflect.FieldDescriptor, element bool) bool {
+	if !d.opts.UnknownUnionBranchAsNull {
+		return false
+	}
+	union, ok := data.(map[string]interface{})
+	if !ok || len(union) != 1 {
+		return false
+	}
+	for branch := range union {
+		if d.isKnownUnionBranch(f, branch, element) {
+			return false
+		}
+		d.warn("unknown union branch '%s' decoded as null", branch)
+		if d.opts.RawExtras != nil {
+			d.stashRaw(data)
+		}
+		return true
+	}
+	return false
+}
+
+// isKnownUnionBranch returns true if field f is encoded as branch in the schema inferred under the
+// options, or if the decoder accepts branch for f, such as int for long fields.
+// When element is true, branch is of an element of a repeated field, or of a map value.
+func (d *decoder) isKnownUnionBranch(f protoreflect.FieldDescriptor, branch string, element bool) bool {
+	if !element && f.IsList() {
+		if _, ok := d.opts.MapToRepeated[string(f.FullName())]; ok && branch == string(avro.MapType) {
+			return true
+		}
+	}
+	if element && f.IsMap() {
+		if d.isAcceptedBranch(f.MapValue(), branch) {
+			return true
+		}
+	} else if element || !f.IsList() && !f.IsMap() {
+		if d.isAcceptedBranch(f, branch) {
+			return true
+		}
+	}
+	branches, err := d.fieldBranches(f, element)
+	if err != nil {
+		// fields without an inferable schema are decoded as usual, and fail there.
+		return true
+	}
+	_, ok := branches[branch]
+	return ok
+}
+
+// isAcceptedBranch returns true if the decoder accepts branch for a single value of field f,
+// in addition to the branches of the schema.
+func (d *decoder) isAcceptedBranch(f protoreflect.FieldDescriptor, branch string) bool {
+	if _, ok := d.opts.DecimalFields[string(f.FullName())]; ok && f.Kind() == protoreflect.StringKind {
+		for _, decimalBranch := range decimalBranches {
+			if branch == decimalBranch {
+				return true
+			}
+		}
+		return false
+	}
+	switch f.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if d.opts.isExpandedWKT(f.Message().FullName()) {
+			return true
+		}
+		// records with a single field are not unions.
+		fd, err := d.opts.findField(f.Message(), branch)
+		return fd != nil || err != nil
+	case protoreflect.EnumKind:
+		return d.opts.AcceptEnumNumbers && (branch == string(avro.IntType) || branch == string(avro.LongType))
+	case protoreflect.BoolKind:
+		return branch == string(avro.BooleanType)
+	case protoreflect.Int64Kind,
+		protoreflect.Sint64Kind,
+		protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind,
+		protoreflect.Fixed64Kind:
+		return branch == string(avro.LongType) || branch == string(avro.IntType)
+	case protoreflect.DoubleKind, protoreflect.FloatKind:
+		return branch == string(avro.DoubleType) || branch == string(avro.FloatType)
+	}
+	return false
+}
+
+// unionBranchesKey identifies the values, or the elements, of a field.
+type unionBranchesKey struct {
+	field   protoreflect.FullName
+	element bool
+}
+
+// fieldBranches returns the names of the union members that the values of field f are encoded as,
+// in the schema inferred under the options.
+// When element is true, the names are of the elements of a repeated field, or of the values of a map.
+func (d *decoder) fieldBranches(f protoreflect.FieldDescriptor, element bool) (map[string]struct{}, error) {
+	key := unionBranchesKey{field: f.FullName(), element: element}
+	if branches, ok := d.unionBranches[key]; ok {
+		return branches, nil
+	}
+	field, depth := f, 1
+	if element && f.IsMap() {
+		// map values are fields of the map entry record.
+		field = f.MapValue()
+	}
+	if recordDepth, ok := d.nestDepths[field.ContainingMessage().FullName()]; ok {
+		depth = recordDepth + 1
+	}
+	s := d.opts.newSchemaInferrer()
+	if field.Message() != nil && !field.IsMap() {
+		// message values are identified by record name, so their records are referenced instead of inferred.
+		s.seen[field.Message().FullName()] = struct{}{}
+	}
+	schema, err := s.inferFieldType(field, depth)
+	if err != nil {
+		return nil, err
+	}
+	if element && !f.IsMap() {
+		schema = elementSchema(schema)
+	}
+	branches := make(map[string]struct{})
+	if union, ok := schema.(avro.Union); ok {
+		for _, member := range union {
+			branches[branchName(member, "")] = struct{}{}
+		}
+	} else {
+		branches[branchName(schema, "")] = struct{}{}
+	}
+	if d.unionBranches == nil {
+		d.unionBranches = make(map[unionBranchesKey]map[string]struct{})
+	}
+	d.unionBranches[key] = branches
+	return branches, nil
+}
+
+// elementSchema returns the schema of the elements of the array or map in schema, which may be nullable.
+func elementSchema(schema avro.Schema) avro.Schema {
+	if union, ok := schema.(avro.Union); ok {
+		for _, member := range union {
+			if member != avro.Null() {
+				schema = member
+			}
+		}
+	}
+	switch schema := schema.(type) {
+	case avro.Array:
+		return schema.Items
+	case avro.Map:
+		return schema.Values
+	}
+	return schema
+}
@@ -0,0 +1,286 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnionSchema(t *testing.T) {
+	descs := []protoreflect.MessageDescriptor{
+		(&library.Book{}).ProtoReflect().Descriptor(),
+		(&examplev1.ExampleEnum{}).ProtoReflect().Descriptor(),
+		(&examplev1.ExampleList{}).ProtoReflect().Descriptor(),
+	}
+	schema, err := SchemaOptions{}.UnionSchema(descs)
+	assert.NilError(t, err)
+	union, ok := schema.(avro.Union)
+	assert.Assert(t, ok)
+	assert.Equal(t, len(union), 4)
+	assert.Equal(t, union[0], avro.Schema(avro.Null()))
+	for i, desc := range descs {
+		record, ok := union[i+1].(avro.Record)
+		assert.Assert(t, ok)
+		assert.Equal(t, record.Namespace+"."+record.Name, string(desc.FullName()))
+	}
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	codec, err := goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+
+	for _, msg := range []proto.Message{
+		&library.Book{
+			Name:   "shelves/1/books/1",
+			Title:  "Harry Potter",
+			Author: "J. K. Rowling",
+		},
+		&examplev1.ExampleEnum{
+			EnumValue: examplev1.ExampleEnum_ENUM_VALUE2,
+		},
+		&examplev1.ExampleList{
+			StringList: []string{"a", "b"},
+			EnumList:   []examplev1.ExampleList_Enum{examplev1.ExampleList_ENUM_VALUE1},
+		},
+	} {
+		msg := msg
+		t.Run(string(msg.ProtoReflect().Descriptor().FullName()), func(t *testing.T) {
+			datum, err := SchemaOptions{}.Encode(msg)
+			assert.NilError(t, err)
+			binary, err := codec.BinaryFromNative(nil, datum)
+			assert.NilError(t, err)
+			native, _, err := codec.NativeFromBinary(binary)
+			assert.NilError(t, err)
+			got, err := UnmarshalOptions{}.UnmarshalUnion(native, protoregistry.GlobalTypes)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, msg, got, protocmp.Transform())
+		})
+	}
+}
+
+func TestUnionSchema_Duplicate(t *testing.T) {
+	desc := (&library.Book{}).ProtoReflect().Descriptor()
+	_, err := SchemaOptions{}.UnionSchema([]protoreflect.MessageDescriptor{desc, desc})
+	assert.ErrorContains(t, err, "duplicate message type google.example.library.v1.Book")
+}
+
+func TestUnmarshalUnion(t *testing.T) {
+	t.Run("null", func(t *testing.T) {
+		got, err := UnmarshalOptions{}.UnmarshalUnion(nil, protoregistry.GlobalTypes)
+		assert.NilError(t, err)
+		assert.Assert(t, got == nil)
+	})
+	t.Run("unknown branch", func(t *testing.T) {
+		_, err := UnmarshalOptions{}.UnmarshalUnion(
+			map[string]interface{}{"example.Unknown": map[string]interface{}{}},
+			protoregistry.GlobalTypes,
+		)
+		assert.ErrorContains(t, err, "resolve union branch 'example.Unknown'")
+	})
+	t.Run("not a union", func(t *testing.T) {
+		_, err := UnmarshalOptions{}.UnmarshalUnion([]interface{}{}, protoregistry.GlobalTypes)
+		assert.ErrorContains(t, err, "expected union encoded as map[string]interface{} with a single key")
+	})
+}
+
+func TestUnmarshalOptions_UnknownUnionBranchAsNull(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		opts        UnmarshalOptions
+		data        map[string]interface{}
+		expected    *examplev1.ExampleList
+		errContains string
+	}{
+		{
+			name: "known branch",
+			data: map[string]interface{}{
+				"string_list": map[string]interface{}{"array": []interface{}{"a"}},
+				"int64_list":  map[string]interface{}{"array": []interface{}{map[string]interface{}{"long": int64(1)}}},
+			},
+			expected: &examplev1.ExampleList{StringList: []string{"a"}, Int64List: []int64{1}},
+		},
+		{
+			name: "unknown branch strict",
+			data: map[string]interface{}{
+				"int64_list": map[string]interface{}{"array": []interface{}{map[string]interface{}{"string": "1"}}},
+			},
+			errContains: "field int64_list: expected key 'long'",
+		},
+		{
+			name: "unknown element branch lenient",
+			opts: UnmarshalOptions{UnknownUnionBranchAsNull: true},
+			data: map[string]interface{}{
+				"int64_list": map[string]interface{}{
+					"array": []interface{}{
+						map[string]interface{}{"string": "1"},
+						map[string]interface{}{"long": int64(2)},
+					},
+				},
+			},
+			expected: &examplev1.ExampleList{Int64List: []int64{0, 2}},
+		},
+		{
+			name: "unknown field branch lenient",
+			opts: UnmarshalOptions{UnknownUnionBranchAsNull: true},
+			data: map[string]interface{}{
+				"string_list": map[string]interface{}{"map": map[string]interface{}{}},
+				"nested_list": map[string]interface{}{
+					"array": []interface{}{
+						map[string]interface{}{"einride.avro.example.v1.ExampleList.Other": map[string]interface{}{}},
+						map[string]interface{}{
+							"einride.avro.example.v1.ExampleList.Nested": map[string]interface{}{
+								"string_list": map[string]interface{}{"array": []interface{}{"a"}},
+							},
+						},
+						map[string]interface{}{"string_list": map[string]interface{}{"array": []interface{}{"b"}}},
+					},
+				},
+			},
+			expected: &examplev1.ExampleList{
+				NestedList: []*examplev1.ExampleList_Nested{
+					{},
+					{StringList: []string{"a"}},
+					{StringList: []string{"b"}},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleList
+			err := tt.opts.Unmarshal(tt.data, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, &got, protocmp.Transform())
+		})
+	}
+}
+
+func TestUnmarshalOptions_MaxUnionResolutions(t *testing.T) {
+	anys := make([]interface{}, 0, 10)
+	for i := 0; i < 10; i++ {
+		anys = append(anys, map[string]interface{}{
+			"string": `{"@type":"type.googleapis.com/google.protobuf.StringValue","value":"a"}`,
+		})
+	}
+	data := map[string]interface{}{"anys": map[string]interface{}{"array": anys}}
+	t.Run("within limit", func(t *testing.T) {
+		var got examplev1.ExampleAnyList
+		assert.NilError(t, UnmarshalOptions{MaxUnionResolutions: 10}.Unmarshal(data, &got))
+		assert.Equal(t, 10, len(got.GetAnys()))
+	})
+	t.Run("exceeds limit", func(t *testing.T) {
+		var got examplev1.ExampleAnyList
+		err := UnmarshalOptions{MaxUnionResolutions: 5}.Unmarshal(data, &got)
+		assert.ErrorContains(t, err, "google.protobuf.Any: 6 type resolutions exceed max union resolutions 5")
+	})
+}
+
+func TestUnmarshalOptions_UnknownUnionBranchAsNull_RoundTrip(t *testing.T) {
+	for _, tt := range []struct {
+		name      string
+		marshal   MarshalOptions
+		unmarshal UnmarshalOptions
+		msg       proto.Message
+	}{
+		{
+			name: "maps",
+			msg: &examplev1.ExampleMap{
+				StringToNested: map[string]*examplev1.ExampleMap_Nested{
+					"a": {StringToString: map[string]string{"b": "c"}},
+				},
+				StringToEnum: map[string]examplev1.ExampleMap_Enum{"d": examplev1.ExampleMap_ENUM_VALUE2},
+			},
+		},
+		{
+			name:    "maps as parallel arrays",
+			marshal: MarshalOptions{SchemaOptions: SchemaOptions{MapEncoding: MapAsParallelArrays}},
+			msg: &examplev1.ExampleMap{
+				StringToString: map[string]string{"a": "b"},
+				Int32ToString:  map[int32]string{1: "c"},
+			},
+		},
+		{
+			name:    "int64 as string",
+			marshal: MarshalOptions{SchemaOptions: SchemaOptions{Int64AsString: true}},
+			msg: &examplev1.ExampleIntegers{
+				Int64Value:      -1,
+				Sint64List:      []int64{1, 2},
+				Sint32ToFixed64: map[int32]uint64{3: 4},
+			},
+		},
+		{
+			name: "encrypted fields",
+			marshal: MarshalOptions{
+				SchemaOptions:  SchemaOptions{EncryptedFields: []string{"einride.avro.example.v1.ExampleRedact.secret"}},
+				FieldEncryptor: xorCipher,
+			},
+			unmarshal: UnmarshalOptions{FieldDecryptor: xorCipher},
+			msg:       &examplev1.ExampleRedact{Name: "name", Secret: "secret", Pin: 1234},
+		},
+		{
+			name:    "max nest depth",
+			marshal: MarshalOptions{SchemaOptions: SchemaOptions{MaxNestDepth: 2}},
+			msg: &examplev1.ExampleNested{
+				Level1: &examplev1.ExampleNested_Level1{
+					Level2: &examplev1.ExampleNested_Level2{Level3: &examplev1.ExampleNested_Level3{Value: "a"}},
+				},
+				Name: "b",
+			},
+		},
+		{
+			name: "delimited repeated fields",
+			marshal: MarshalOptions{SchemaOptions: SchemaOptions{
+				DelimitedRepeatedFields: map[string]string{"einride.avro.example.v1.ExampleList.string_list": ","},
+			}},
+			msg: &examplev1.ExampleList{StringList: []string{"a", "b"}},
+		},
+		{
+			name: "nested proto bytes fields",
+			marshal: MarshalOptions{SchemaOptions: SchemaOptions{
+				NestedProtoBytesFields: map[string]protoreflect.MessageType{
+					"einride.avro.example.v1.ExampleNested.level1": (&examplev1.ExampleNested_Level1{}).ProtoReflect().Type(),
+				},
+			}},
+			msg: &examplev1.ExampleNested{
+				Level1: &examplev1.ExampleNested_Level1{
+					Level2: &examplev1.ExampleNested_Level2{Level3: &examplev1.ExampleNested_Level3{Value: "a"}},
+				},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			schema, err := tt.marshal.InferSchema(tt.msg.ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			schemaBytes, err := json.Marshal(schema)
+			assert.NilError(t, err)
+			codec, err := goavro.NewCodec(string(schemaBytes))
+			assert.NilError(t, err)
+			datum, err := tt.marshal.Encode(tt.msg)
+			assert.NilError(t, err)
+			binary, err := codec.BinaryFromNative(nil, datum)
+			assert.NilError(t, err)
+			native, _, err := codec.NativeFromBinary(binary)
+			assert.NilError(t, err)
+			opts := tt.unmarshal
+			opts.SchemaOptions = tt.marshal.SchemaOptions
+			opts.UnknownUnionBranchAsNull = true
+			got := tt.msg.ProtoReflect().New().Interface()
+			assert.NilError(t, opts.Unmarshal(native, got))
+			assert.DeepEqual(t, tt.msg, got, protocmp.Transform())
+		})
+	}
+}
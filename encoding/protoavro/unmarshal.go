@@ -1,36 +1,55 @@
 package protoavro
 
 import (
+	"bufio"
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 
 	"github.com/linkedin/goavro/v2"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
+// defaultReadBufferSize is the size of the read buffer used when UnmarshalOptions.ReadBufferSize is not set.
+const defaultReadBufferSize = 64 << 10
+
 // NewUnmarshaler returns a new unmarshaler that reads protobuf messages from reader in
 // Avro binary format.
 func NewUnmarshaler(reader io.Reader) (*Unmarshaler, error) {
-	r, err := goavro.NewOCFReader(reader)
-	if err != nil {
-		return nil, fmt.Errorf("new ocf writer: %w", err)
-	}
-	return &Unmarshaler{r: r}, nil
+	return UnmarshalOptions{}.NewUnmarshaler(reader)
 }
 
 // NewUnmarshaler returns a new unmarshaler that reads protobuf messages from reader in
 // Avro binary format.
 func (o SchemaOptions) NewUnmarshaler(reader io.Reader) (*Unmarshaler, error) {
-	r, err := goavro.NewOCFReader(reader)
+	return UnmarshalOptions{SchemaOptions: o}.NewUnmarshaler(reader)
+}
+
+// NewUnmarshaler returns a new unmarshaler that reads protobuf messages from reader in
+// Avro binary format.
+func (o UnmarshalOptions) NewUnmarshaler(reader io.Reader) (*Unmarshaler, error) {
+	bufferSize := o.ReadBufferSize
+	if bufferSize <= 0 {
+		bufferSize = defaultReadBufferSize
+	}
+	if o.MaxBlockBytes > 0 || o.MaxBlockCount > 0 {
+		reader = newOCFLimitReader(reader, o.MaxBlockBytes, o.MaxBlockCount)
+	}
+	r, err := goavro.NewOCFReader(bufio.NewReaderSize(reader, bufferSize))
 	if err != nil {
 		return nil, fmt.Errorf("new ocf writer: %w", err)
 	}
+	if o.CaptureFieldOrder != nil && len(o.WriterSchema) == 0 {
+		o.WriterSchema = json.RawMessage(r.Codec().Schema())
+	}
 	return &Unmarshaler{opts: o, r: r}, nil
 }
 
 // Unmarshaler reads and decodes Avro binary encoded messages.
 type Unmarshaler struct {
-	opts SchemaOptions
+	opts UnmarshalOptions
 	r    *goavro.OCFReader
 }
 
@@ -40,6 +59,12 @@ func (m *Unmarshaler) Scan() bool {
 	return m.r.Scan()
 }
 
+// Err returns the error that ended scanning, or nil if all messages were read.
+// Errors include files that exceed UnmarshalOptions.MaxBlockBytes or MaxBlockCount.
+func (m *Unmarshaler) Err() error {
+	return m.r.Err()
+}
+
 // Unmarshal consumes one message from the reader and places it in message.
 func (m *Unmarshaler) Unmarshal(message proto.Message) error {
 	data, err := m.r.Read()
@@ -51,3 +76,71 @@ func (m *Unmarshaler) Unmarshal(message proto.Message) error {
 	}
 	return nil
 }
+
+// Unmarshal decodes the Avro JSON encoded data and places the result in message.
+func (o UnmarshalOptions) Unmarshal(data interface{}, message proto.Message) error {
+	if err := o.decodeJSON(data, message); err != nil {
+		return fmt.Errorf("decode message: %w", err)
+	}
+	return nil
+}
+
+// UnmarshalPartial decodes the Avro JSON encoded data and places the result in message, like Unmarshal,
+// but returns the fields of the top-level record that match no field of message instead of failing.
+// This allows callers to inspect or re-route data from producers with newer schemas.
+// Unknown fields of nested records are rejected as with Unmarshal.
+func (o UnmarshalOptions) UnmarshalPartial(data interface{}, message proto.Message) (map[string]interface{}, error) {
+	d := o.newDecoder()
+	d.leftover = map[string]interface{}{}
+	if err := d.decodeJSON(data, message); err != nil {
+		return nil, fmt.Errorf("decode message: %w", err)
+	}
+	if len(d.leftover) == 0 {
+		return nil, nil
+	}
+	return d.leftover, nil
+}
+
+// UnmarshalReader reads a single Avro JSON encoded message from reader and places the result in message.
+// The data is parsed using the schema inferred for message.
+// A leading UTF-8 byte order mark and whitespace around the JSON document are ignored.
+func (o UnmarshalOptions) UnmarshalReader(reader io.Reader, message proto.Message) error {
+	text, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("read: %w", err)
+	}
+	text = bytes.TrimPrefix(text, utf8BOM)
+	text = bytes.TrimSpace(text)
+	codec, err := o.newCodec(message.ProtoReflect().Descriptor())
+	if err != nil {
+		return err
+	}
+	data, rest, err := codec.NativeFromTextual(text)
+	if err != nil {
+		return fmt.Errorf("parse: %w", err)
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return fmt.Errorf("parse: unexpected data after message")
+	}
+	return o.Unmarshal(data, message)
+}
+
+// utf8BOM is the UTF-8 encoding of the byte order mark.
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// newCodec returns a codec for the schema inferred for desc.
+func (o SchemaOptions) newCodec(desc protoreflect.MessageDescriptor) (*goavro.Codec, error) {
+	schema, err := o.InferSchema(desc)
+	if err != nil {
+		return nil, fmt.Errorf("infer schema: %w", err)
+	}
+	schemaBytes, err := json.Marshal(schema)
+	if err != nil {
+		return nil, fmt.Errorf("json marshal schema: %w", err)
+	}
+	codec, err := goavro.NewCodec(string(schemaBytes))
+	if err != nil {
+		return nil, fmt.Errorf("new codec: %w", err)
+	}
+	return codec, nil
+}
@@ -0,0 +1,2109 @@
+package protoavro
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	"google.golang.org/genproto/googleapis/example/library/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"gotest.tools/v3/assert"
+)
+
+func TestUnmarshalOptions_WriterSchema(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		msg         proto.Message
+		data        interface{}
+		expected    proto.Message
+		errContains string
+		// coerced is true when the data decodes without a writer schema
+		coerced bool
+	}{
+		{
+			name: "conforming",
+			msg:  &library.Book{},
+			data: map[string]interface{}{
+				"google.example.library.v1.Book": map[string]interface{}{
+					"name":   map[string]interface{}{"string": "books/1"},
+					"author": map[string]interface{}{"string": "J. K. Rowling"},
+					"title":  map[string]interface{}{"string": "Harry Potter"},
+					"read":   map[string]interface{}{"boolean": true},
+				},
+			},
+			expected: &library.Book{
+				Name:   "books/1",
+				Author: "J. K. Rowling",
+				Title:  "Harry Potter",
+				Read:   true,
+			},
+		},
+		{
+			name: "type mismatch",
+			msg:  &library.Book{},
+			data: map[string]interface{}{
+				"google.example.library.v1.Book": map[string]interface{}{
+					"name":   map[string]interface{}{"string": "books/1"},
+					"author": map[string]interface{}{"string": "J. K. Rowling"},
+					"title":  map[string]interface{}{"string": "Harry Potter"},
+					"read":   map[string]interface{}{"string": "true"},
+				},
+			},
+			errContains: "writer schema: invalid data",
+		},
+		{
+			name: "unknown enum symbol",
+			msg:  &examplev1.ExampleEnum{},
+			data: map[string]interface{}{
+				"einride.avro.example.v1.ExampleEnum": map[string]interface{}{
+					"enum_value": map[string]interface{}{
+						"einride.avro.example.v1.ExampleEnum.Enum": "ENUM_VALUE3",
+					},
+				},
+			},
+			errContains: "writer schema: invalid data",
+			coerced:     true,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			schema, err := InferSchema(tt.msg.ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			schemaBytes, err := json.Marshal(schema)
+			assert.NilError(t, err)
+			opts := UnmarshalOptions{WriterSchema: schemaBytes}
+			got := tt.msg.ProtoReflect().New().Interface()
+			err = opts.Unmarshal(tt.data, got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				if tt.coerced {
+					assert.NilError(t, UnmarshalOptions{}.Unmarshal(tt.data, got))
+				}
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got, protocmp.Transform())
+		})
+	}
+}
+
+func TestUnmarshalOptions_WriterSchema_Invalid(t *testing.T) {
+	opts := UnmarshalOptions{WriterSchema: json.RawMessage(`{"type": "unknown"}`)}
+	err := opts.Unmarshal(map[string]interface{}{}, &library.Book{})
+	assert.ErrorContains(t, err, "writer schema")
+}
+
+func TestUnmarshalOptions_WriterSchema_UnwrapKey(t *testing.T) {
+	schema, err := InferSchema((&library.Book{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	wrapperSchema, err := json.Marshal(avro.Record{
+		Type:   avro.RecordType,
+		Name:   "Wrapper",
+		Fields: []avro.Field{{Name: "value", Type: schema}},
+	})
+	assert.NilError(t, err)
+	opts := UnmarshalOptions{WriterSchema: wrapperSchema, UnwrapKey: "value"}
+	book := map[string]interface{}{
+		"google.example.library.v1.Book": map[string]interface{}{
+			"name":   map[string]interface{}{"string": "books/1"},
+			"author": map[string]interface{}{"string": ""},
+			"title":  map[string]interface{}{"string": ""},
+			"read":   map[string]interface{}{"boolean": false},
+		},
+	}
+	// the writer schema describes the data before it is unwrapped.
+	var got library.Book
+	assert.NilError(t, opts.Unmarshal(map[string]interface{}{"value": book}, &got))
+	assert.DeepEqual(t, &library.Book{Name: "books/1"}, &got, protocmp.Transform())
+	assert.ErrorContains(t, opts.Unmarshal(book, &got), "writer schema: invalid data")
+
+	compiled, err := writerSchemas.compile(wrapperSchema)
+	assert.NilError(t, err)
+	cached, err := writerSchemas.compile(wrapperSchema)
+	assert.NilError(t, err)
+	assert.Assert(t, compiled == cached)
+}
+
+func TestUnmarshalOptions_WriterSchema_ExactTypes(t *testing.T) {
+	schema, err := InferSchema((&examplev1.ExampleWrappers{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	for _, tt := range []struct {
+		name        string
+		field       string
+		value       interface{}
+		errContains string
+	}{
+		{
+			name:  "int",
+			field: "int32_value",
+			value: map[string]interface{}{"int": int32(3)},
+		},
+		{
+			name:        "float for int",
+			field:       "int32_value",
+			value:       map[string]interface{}{"int": float64(3)},
+			errContains: "int32_value: expected int, got float64",
+		},
+		{
+			name:        "float for long",
+			field:       "int64_value",
+			value:       map[string]interface{}{"long": float64(5)},
+			errContains: "int64_value: expected long, got float64",
+		},
+		{
+			name:        "int for long",
+			field:       "int64_value",
+			value:       map[string]interface{}{"long": int32(5)},
+			errContains: "int64_value: expected long, got int32",
+		},
+		{
+			name:        "double for float",
+			field:       "float_value",
+			value:       map[string]interface{}{"float": float64(1.5)},
+			errContains: "float_value: expected float, got float64",
+		},
+		{
+			name:        "string for bytes",
+			field:       "bytes_value",
+			value:       map[string]interface{}{"bytes": "AQI="},
+			errContains: "bytes_value: expected bytes, got string",
+		},
+		{
+			name:        "unknown branch",
+			field:       "string_value",
+			value:       map[string]interface{}{"bytes": []byte("value")},
+			errContains: "string_value: unknown union branch 'bytes'",
+		},
+		{
+			name:        "missing field",
+			field:       "bool_value",
+			errContains: "bool_value: missing field without default",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			datum, err := MarshalOptions{}.encodeJSON(&examplev1.ExampleWrappers{})
+			assert.NilError(t, err)
+			record := datum.(map[string]interface{})["einride.avro.example.v1.ExampleWrappers"].(map[string]interface{})
+			if tt.value != nil {
+				record[tt.field] = tt.value
+			} else {
+				delete(record, tt.field)
+			}
+			var got examplev1.ExampleWrappers
+			err = UnmarshalOptions{WriterSchema: schemaBytes}.Unmarshal(datum, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, "writer schema: invalid data: ")
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestUnmarshalOptions_WriterSchema_CacheBound(t *testing.T) {
+	for i := 0; i <= maxWriterSchemas; i++ {
+		schema := json.RawMessage(fmt.Sprintf(`{"type": "enum", "name": "CacheBound%d", "symbols": ["A"]}`, i))
+		assert.NilError(t, validateWriterSchema(schema, "A"))
+	}
+	writerSchemas.mu.Lock()
+	defer writerSchemas.mu.Unlock()
+	assert.Assert(t, len(writerSchemas.schemas) <= maxWriterSchemas)
+}
+
+func TestUnmarshalOptions_MaxStringLength(t *testing.T) {
+	opts := UnmarshalOptions{MaxStringLength: 5}
+	t.Run("at limit", func(t *testing.T) {
+		var got library.Book
+		err := opts.Unmarshal(map[string]interface{}{
+			"name": map[string]interface{}{"string": "12345"},
+		}, &got)
+		assert.NilError(t, err)
+		assert.Equal(t, got.GetName(), "12345")
+	})
+	t.Run("over limit", func(t *testing.T) {
+		var got library.Book
+		err := opts.Unmarshal(map[string]interface{}{
+			"name": map[string]interface{}{"string": "123456"},
+		}, &got)
+		assert.ErrorContains(t, err, "field name: string length 6 exceeds max length 5")
+	})
+	t.Run("map key over limit", func(t *testing.T) {
+		var got examplev1.ExampleMap
+		err := opts.Unmarshal(map[string]interface{}{
+			"string_to_string": []interface{}{
+				map[string]interface{}{"key": "123456", "value": "1"},
+			},
+		}, &got)
+		assert.ErrorContains(t, err, "field key: string length 6 exceeds max length 5")
+	})
+	t.Run("enum symbol over limit", func(t *testing.T) {
+		var got examplev1.ExampleEnum
+		err := opts.Unmarshal(map[string]interface{}{
+			"enum_value": map[string]interface{}{"einride.avro.example.v1.ExampleEnum.Enum": "ENUM_VALUE1"},
+		}, &got)
+		assert.ErrorContains(t, err, "field enum_value: string length 11 exceeds max length 5")
+	})
+	t.Run("timestamp string over limit", func(t *testing.T) {
+		var got examplev1.ExampleTimestamp
+		err := opts.Unmarshal(map[string]interface{}{
+			"timestamp": map[string]interface{}{"string": "2021-01-01T00:00:00Z"},
+		}, &got)
+		assert.ErrorContains(t, err, "field timestamp: string length 20 exceeds max length 5")
+	})
+	t.Run("int64 string over limit", func(t *testing.T) {
+		opts := opts
+		opts.Int64AsString = true
+		var got examplev1.ExampleIntegers
+		err := opts.Unmarshal(map[string]interface{}{
+			"int64_value": map[string]interface{}{"string": "123456"},
+		}, &got)
+		assert.ErrorContains(t, err, "field int64_value: string length 6 exceeds max length 5")
+	})
+	t.Run("unlimited", func(t *testing.T) {
+		var got library.Book
+		err := UnmarshalOptions{}.Unmarshal(map[string]interface{}{
+			"name": map[string]interface{}{"string": "123456"},
+		}, &got)
+		assert.NilError(t, err)
+	})
+}
+
+func TestUnmarshalOptions_MaxBytesLength(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		encoding    BytesEncoding
+		data        interface{}
+		errContains string
+	}{
+		{
+			name: "raw at limit",
+			data: map[string]interface{}{"bytes": []byte{1, 2, 3, 4}},
+		},
+		{
+			name:        "raw over limit",
+			data:        map[string]interface{}{"bytes": []byte{1, 2, 3, 4, 5}},
+			errContains: "field bytes: bytes length 5 exceeds max length 4",
+		},
+		{
+			name:     "base64 at limit",
+			encoding: BytesDataURL,
+			data:     map[string]interface{}{"string": "data:;base64,AQIDBA=="},
+		},
+		{
+			name:        "base64 over limit",
+			encoding:    BytesDataURL,
+			data:        map[string]interface{}{"string": "data:;base64,AQIDBAU="},
+			errContains: "field bytes: bytes length 5 exceeds max length 4",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := UnmarshalOptions{
+				SchemaOptions:  SchemaOptions{BytesEncoding: tt.encoding},
+				MaxBytesLength: 4,
+			}
+			var got examplev1.ExampleBytes
+			err := opts.Unmarshal(map[string]interface{}{"bytes": tt.data}, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, []byte{1, 2, 3, 4}, got.GetBytes())
+		})
+	}
+	t.Run("unlimited", func(t *testing.T) {
+		var got examplev1.ExampleBytes
+		err := UnmarshalOptions{}.Unmarshal(map[string]interface{}{"bytes": make([]byte, 1<<20)}, &got)
+		assert.NilError(t, err)
+	})
+}
+
+func TestUnmarshalOptions_CaptureEnumSymbols(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		msg      proto.Message
+		data     interface{}
+		expected map[string]string
+	}{
+		{
+			name: "singular",
+			msg:  &examplev1.ExampleEnum{},
+			data: map[string]interface{}{
+				"enum_value": map[string]interface{}{
+					"einride.avro.example.v1.ExampleEnum.Enum": "ENUM_VALUE2",
+				},
+			},
+			expected: map[string]string{"enum_value": "ENUM_VALUE2"},
+		},
+		{
+			name: "unknown symbol",
+			msg:  &examplev1.ExampleEnum{},
+			data: map[string]interface{}{
+				"enum_value": map[string]interface{}{
+					"einride.avro.example.v1.ExampleEnum.Enum": "ENUM_VALUE_RENAMED",
+				},
+			},
+			expected: map[string]string{"enum_value": "ENUM_VALUE_RENAMED"},
+		},
+		{
+			name: "list",
+			msg:  &examplev1.ExampleList{},
+			data: map[string]interface{}{
+				"enum_list": map[string]interface{}{
+					"array": []interface{}{"ENUM_VALUE1", "ENUM_VALUE2"},
+				},
+			},
+			expected: map[string]string{"enum_list[0]": "ENUM_VALUE1", "enum_list[1]": "ENUM_VALUE2"},
+		},
+		{
+			name: "map",
+			msg:  &examplev1.ExampleMap{},
+			data: map[string]interface{}{
+				"string_to_enum": []interface{}{
+					map[string]interface{}{"key": "a", "value": "ENUM_VALUE1"},
+				},
+			},
+			expected: map[string]string{"string_to_enum[a]": "ENUM_VALUE1"},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			symbols := map[string]string{}
+			opts := UnmarshalOptions{CaptureEnumSymbols: symbols}
+			assert.NilError(t, opts.Unmarshal(tt.data, tt.msg))
+			assert.DeepEqual(t, tt.expected, symbols)
+		})
+	}
+}
+
+func TestUnmarshalOptions_RequiredFields(t *testing.T) {
+	opts := UnmarshalOptions{
+		RequiredFields: []string{
+			"google.example.library.v1.Book.name",
+			"google.example.library.v1.Book.title",
+			"google.example.library.v1.ListBooksResponse.next_page_token",
+		},
+	}
+	for _, tt := range []struct {
+		name        string
+		msg         proto.Message
+		data        map[string]interface{}
+		errContains string
+	}{
+		{
+			name: "present",
+			msg:  &library.Book{},
+			data: map[string]interface{}{
+				"name":  map[string]interface{}{"string": "shelves/1/books/1"},
+				"title": map[string]interface{}{"string": ""},
+			},
+		},
+		{
+			name: "missing",
+			msg:  &library.Book{},
+			data: map[string]interface{}{
+				"author": map[string]interface{}{"string": "J. K. Rowling"},
+			},
+			errContains: "missing required fields: name, title",
+		},
+		{
+			name: "null",
+			msg:  &library.Book{},
+			data: map[string]interface{}{
+				"name":  nil,
+				"title": map[string]interface{}{"string": "Harry Potter"},
+			},
+			errContains: "missing required fields: name",
+		},
+		{
+			name: "nested present",
+			msg:  &library.ListBooksResponse{},
+			data: map[string]interface{}{
+				"next_page_token": map[string]interface{}{"string": ""},
+				"books": map[string]interface{}{
+					"array": []interface{}{
+						map[string]interface{}{
+							"name":  map[string]interface{}{"string": "shelves/1/books/1"},
+							"title": map[string]interface{}{"string": "Harry Potter"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "nested missing",
+			msg:  &library.ListBooksResponse{},
+			data: map[string]interface{}{
+				"books": map[string]interface{}{
+					"array": []interface{}{
+						map[string]interface{}{
+							"name":  map[string]interface{}{"string": "shelves/1/books/1"},
+							"title": map[string]interface{}{"string": "Harry Potter"},
+						},
+						map[string]interface{}{
+							"name": map[string]interface{}{"string": "shelves/1/books/2"},
+						},
+					},
+				},
+			},
+			errContains: "missing required fields: books[1].title, next_page_token",
+		},
+		{
+			name: "nested message absent",
+			msg:  &library.ListBooksResponse{},
+			data: map[string]interface{}{
+				"next_page_token": map[string]interface{}{"string": "token"},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := opts.Unmarshal(tt.data, tt.msg)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestUnmarshal_NullEnum(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		data     map[string]interface{}
+		expected *examplev1.ExampleOptional
+	}{
+		{
+			name: "null",
+			data: map[string]interface{}{
+				"optional_enum": nil,
+				"enum_value":    nil,
+			},
+			expected: &examplev1.ExampleOptional{},
+		},
+		{
+			name: "zero symbol",
+			data: map[string]interface{}{
+				"optional_enum": map[string]interface{}{
+					"einride.avro.example.v1.ExampleOptional.Enum": "ENUM_UNSPECIFIED",
+				},
+				"enum_value": map[string]interface{}{
+					"einride.avro.example.v1.ExampleOptional.Enum": "ENUM_UNSPECIFIED",
+				},
+			},
+			expected: &examplev1.ExampleOptional{
+				OptionalEnum: examplev1.ExampleOptional_ENUM_UNSPECIFIED.Enum(),
+			},
+		},
+		{
+			name: "symbol",
+			data: map[string]interface{}{
+				"optional_enum": map[string]interface{}{
+					"einride.avro.example.v1.ExampleOptional.Enum": "ENUM_VALUE1",
+				},
+				"enum_value": map[string]interface{}{
+					"einride.avro.example.v1.ExampleOptional.Enum": "ENUM_VALUE2",
+				},
+			},
+			expected: &examplev1.ExampleOptional{
+				OptionalEnum: examplev1.ExampleOptional_ENUM_VALUE1.Enum(),
+				EnumValue:    examplev1.ExampleOptional_ENUM_VALUE2,
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleOptional
+			assert.NilError(t, UnmarshalOptions{}.Unmarshal(tt.data, &got))
+			assert.DeepEqual(t, tt.expected, &got, protocmp.Transform())
+			// null decodes as the zero value, without presence for optional fields
+			assert.Equal(t, tt.expected.OptionalEnum == nil, got.OptionalEnum == nil)
+		})
+	}
+}
+
+func TestUnmarshal_TopLevel(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		data        interface{}
+		errContains string
+	}{
+		{
+			name: "null",
+			data: nil,
+		},
+		{
+			name: "array",
+			data: []interface{}{map[string]interface{}{}},
+			errContains: "google.example.library.v1.Book: expected Avro record encoded as map[string]interface{}, " +
+				"got array of 1 elements: decode each element as a separate message",
+		},
+		{
+			name: "scalar",
+			data: "books/1",
+			errContains: "google.example.library.v1.Book: expected Avro record encoded as map[string]interface{} " +
+				`such as {"name": value}, got scalar string`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got library.Book
+			err := UnmarshalOptions{}.Unmarshal(tt.data, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, &library.Book{}, &got, protocmp.Transform())
+		})
+	}
+}
+
+func TestUnmarshalOptions_UnmarshalReader(t *testing.T) {
+	const text = `{"google.example.library.v1.Book": {` +
+		`"name": {"string": "shelves/1/books/1"}, "author": {"string": "J. K. Rowling"}, ` +
+		`"title": {"string": "Harry Potter"}, "read": {"boolean": true}}}`
+	expected := &library.Book{
+		Name:   "shelves/1/books/1",
+		Author: "J. K. Rowling",
+		Title:  "Harry Potter",
+		Read:   true,
+	}
+	for _, tt := range []struct {
+		name        string
+		input       string
+		errContains string
+	}{
+		{
+			name:  "plain",
+			input: text,
+		},
+		{
+			name:  "byte order mark",
+			input: "\xEF\xBB\xBF" + text,
+		},
+		{
+			name:  "leading newlines",
+			input: "\n\r\n  " + text,
+		},
+		{
+			name:  "byte order mark and surrounding whitespace",
+			input: "\xEF\xBB\xBF\n" + text + "\n",
+		},
+		{
+			name:        "trailing data",
+			input:       text + " {}",
+			errContains: "unexpected data after message",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got library.Book
+			err := UnmarshalOptions{}.UnmarshalReader(strings.NewReader(tt.input), &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, expected, &got, protocmp.Transform())
+		})
+	}
+}
+
+func TestUnmarshal_UnionWrappedListElements(t *testing.T) {
+	const book = `{"google.example.library.v1.Book": {` +
+		`"name": {"string": "shelves/1/books/1"}, "author": {"string": ""}, ` +
+		`"title": {"string": "Harry Potter"}, "read": {"boolean": false}}}`
+	// strict Avro JSON wraps each element of an array of nullable items in its union branch.
+	const text = `{"google.example.library.v1.ListBooksResponse": {` +
+		`"books": {"array": [` + book + `, null]}, "next_page_token": {"string": "next"}}}`
+	expected := &library.ListBooksResponse{
+		Books: []*library.Book{
+			{Name: "shelves/1/books/1", Title: "Harry Potter"},
+			{},
+		},
+		NextPageToken: "next",
+	}
+
+	t.Run("textual", func(t *testing.T) {
+		var got library.ListBooksResponse
+		assert.NilError(t, UnmarshalOptions{}.UnmarshalReader(strings.NewReader(text), &got))
+		assert.DeepEqual(t, expected, &got, protocmp.Transform())
+	})
+
+	t.Run("native with writer schema", func(t *testing.T) {
+		schema, err := InferSchema((&library.ListBooksResponse{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		schemaBytes, err := json.Marshal(schema)
+		assert.NilError(t, err)
+		codec, err := goavro.NewCodec(string(schemaBytes))
+		assert.NilError(t, err)
+		native, _, err := codec.NativeFromTextual([]byte(text))
+		assert.NilError(t, err)
+		var got library.ListBooksResponse
+		assert.NilError(t, UnmarshalOptions{WriterSchema: schemaBytes}.Unmarshal(native, &got))
+		assert.DeepEqual(t, expected, &got, protocmp.Transform())
+	})
+
+	t.Run("scalar elements", func(t *testing.T) {
+		var got examplev1.ExampleList
+		err := UnmarshalOptions{}.Unmarshal(map[string]interface{}{
+			"int64_list": map[string]interface{}{
+				"array": []interface{}{map[string]interface{}{"long": int64(1)}, nil, int64(3)},
+			},
+		}, &got)
+		assert.NilError(t, err)
+		assert.DeepEqual(t, []int64{1, 0, 3}, got.GetInt64List())
+	})
+}
+
+func TestUnmarshalOptions_IgnoreExtraTopLevelKeys(t *testing.T) {
+	opts := UnmarshalOptions{IgnoreExtraTopLevelKeys: []string{"_kafka_offset", "_kafka_partition"}}
+	for _, tt := range []struct {
+		name        string
+		msg         proto.Message
+		data        map[string]interface{}
+		expected    proto.Message
+		errContains string
+	}{
+		{
+			name: "sidecar keys",
+			msg:  &library.Book{},
+			data: map[string]interface{}{
+				"name":             map[string]interface{}{"string": "shelves/1/books/1"},
+				"_kafka_offset":    int64(42),
+				"_kafka_partition": int32(1),
+			},
+			expected: &library.Book{Name: "shelves/1/books/1"},
+		},
+		{
+			name: "unknown key",
+			msg:  &library.Book{},
+			data: map[string]interface{}{
+				"name":          map[string]interface{}{"string": "shelves/1/books/1"},
+				"_kafka_offset": int64(42),
+				"_unknown":      "value",
+			},
+			errContains: "unexpected field _unknown",
+		},
+		{
+			name: "sidecar key in nested record",
+			msg:  &library.ListBooksResponse{},
+			data: map[string]interface{}{
+				"_kafka_offset": int64(42),
+				"books": map[string]interface{}{
+					"array": []interface{}{
+						map[string]interface{}{"_kafka_offset": int64(42)},
+					},
+				},
+			},
+			errContains: "unexpected field _kafka_offset",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := opts.Unmarshal(tt.data, tt.msg)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, tt.msg, protocmp.Transform())
+		})
+	}
+}
+
+func TestUnmarshalOptions_StripEnumNamespace(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		opts     UnmarshalOptions
+		symbol   string
+		expected examplev1.ExampleEnum_Enum
+	}{
+		{
+			name:     "prefixed",
+			opts:     UnmarshalOptions{StripEnumNamespace: true},
+			symbol:   "einride.avro.example.v1.ExampleEnum.Enum.ENUM_VALUE2",
+			expected: examplev1.ExampleEnum_ENUM_VALUE2,
+		},
+		{
+			name:     "unprefixed",
+			opts:     UnmarshalOptions{StripEnumNamespace: true},
+			symbol:   "ENUM_VALUE2",
+			expected: examplev1.ExampleEnum_ENUM_VALUE2,
+		},
+		{
+			name:     "other prefix",
+			opts:     UnmarshalOptions{StripEnumNamespace: true},
+			symbol:   "einride.avro.example.v1.Other.ENUM_VALUE2",
+			expected: examplev1.ExampleEnum_ENUM_UNSPECIFIED,
+		},
+		{
+			name:     "prefixed without option",
+			symbol:   "einride.avro.example.v1.ExampleEnum.Enum.ENUM_VALUE2",
+			expected: examplev1.ExampleEnum_ENUM_UNSPECIFIED,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleEnum
+			assert.NilError(t, tt.opts.Unmarshal(map[string]interface{}{"enum_value": tt.symbol}, &got))
+			assert.Equal(t, tt.expected, got.GetEnumValue())
+		})
+	}
+}
+
+func TestUnmarshalOptions_Warnings(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		opts     UnmarshalOptions
+		msg      proto.Message
+		data     map[string]interface{}
+		expected []string
+	}{
+		{
+			name: "no warnings",
+			msg:  &examplev1.ExampleEnum{},
+			data: map[string]interface{}{"enum_value": "ENUM_VALUE1"},
+		},
+		{
+			name:     "unknown enum symbol",
+			msg:      &examplev1.ExampleList{},
+			data:     map[string]interface{}{"enum_list": []interface{}{"ENUM_VALUE1", "ENUM_VALUE3"}},
+			expected: []string{"enum_list[1]: unknown enum symbol ENUM_VALUE3 decoded as zero value"},
+		},
+		{
+			name: "truncated integers",
+			msg:  &examplev1.ExampleMap{},
+			data: map[string]interface{}{
+				"int32_to_string": []interface{}{
+					map[string]interface{}{"key": int64(1<<32 + 1), "value": "a"},
+				},
+				"uint32_to_string": []interface{}{
+					map[string]interface{}{"key": int64(-1), "value": "b"},
+				},
+			},
+			expected: []string{
+				"int32_to_string: value 4294967297 truncated to int32",
+				"uint32_to_string: value -1 truncated to uint32",
+			},
+		},
+		{
+			name: "skipped extra top-level key",
+			opts: UnmarshalOptions{IgnoreExtraTopLevelKeys: []string{"_kafka_offset"}},
+			msg:  &examplev1.ExampleEnum{},
+			data: map[string]interface{}{
+				"enum_value":    "ENUM_VALUE1",
+				"_kafka_offset": int64(42),
+			},
+			expected: []string{"skipped extra top-level key _kafka_offset"},
+		},
+		{
+			name: "unknown union branch",
+			opts: UnmarshalOptions{UnknownUnionBranchAsNull: true},
+			msg:  &examplev1.ExampleList{},
+			data: map[string]interface{}{
+				"string_list": map[string]interface{}{"map": map[string]interface{}{}},
+			},
+			expected: []string{"string_list: unknown union branch 'map' decoded as null"},
+		},
+		{
+			name: "redacted value",
+			opts: UnmarshalOptions{RedactOption: examplev1.E_Redact},
+			msg:  &examplev1.ExampleRedact{},
+			data: map[string]interface{}{
+				"secret": map[string]interface{}{"string": RedactedPlaceholder},
+			},
+			expected: []string{"secret: skipped redacted value"},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var warnings []string
+			opts := tt.opts
+			opts.Warnings = &warnings
+			assert.NilError(t, opts.Unmarshal(tt.data, tt.msg))
+			sort.Strings(warnings)
+			assert.DeepEqual(t, tt.expected, warnings)
+		})
+	}
+}
+
+func TestUnmarshalOptions_Tolerant(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		msg      proto.Message
+		data     map[string]interface{}
+		expected proto.Message
+	}{
+		{
+			name: "records",
+			msg:  &library.ListBooksResponse{},
+			data: map[string]interface{}{
+				"Books": map[string]interface{}{
+					"NAME":      "shelves/1/books/1",
+					"read":      int64(1),
+					"publisher": "unknown",
+				},
+				"nextPageToken": map[string]interface{}{"string": "token"},
+				"_metadata":     map[string]interface{}{"offset": int64(1)},
+			},
+			expected: &library.ListBooksResponse{
+				Books:         []*library.Book{{Name: "shelves/1/books/1", Read: true}},
+				NextPageToken: "token",
+			},
+		},
+		{
+			name: "scalars",
+			msg:  &library.ListBooksRequest{},
+			data: map[string]interface{}{
+				"parent":    "shelves/1",
+				"page_size": map[string]interface{}{"string": " 10 "},
+			},
+			expected: &library.ListBooksRequest{Parent: "shelves/1", PageSize: 10},
+		},
+		{
+			name: "lists",
+			msg:  &examplev1.ExampleList{},
+			data: map[string]interface{}{
+				"string_list": "a",
+				"int64_list":  []interface{}{"1", int64(2), "3e2"},
+				"enum_list":   "ENUM_VALUE3",
+			},
+			expected: &examplev1.ExampleList{
+				StringList: []string{"a"},
+				Int64List:  []int64{1, 2, 300},
+				EnumList:   []examplev1.ExampleList_Enum{examplev1.ExampleList_ENUM_UNSPECIFIED},
+			},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Assert(t, UnmarshalOptions{}.Unmarshal(tt.data, proto.Clone(tt.msg)) != nil)
+			assert.NilError(t, UnmarshalOptions{}.Tolerant().Unmarshal(tt.data, tt.msg))
+			assert.DeepEqual(t, tt.expected, tt.msg, protocmp.Transform())
+		})
+	}
+}
+
+func TestUnmarshalOptions_BoolStrings(t *testing.T) {
+	custom := BoolStrings{True: []string{"Y", "J"}, False: []string{"N"}}
+	for _, tt := range []struct {
+		name        string
+		boolStrings BoolStrings
+		value       interface{}
+		expected    bool
+		errContains string
+	}{
+		{name: "default true", value: "true", expected: true},
+		{name: "default false", value: "False", expected: false},
+		{name: "default yes", value: "yes", expected: true},
+		{name: "default no", value: "NO", expected: false},
+		{name: "default Y", value: "Y", expected: true},
+		{name: "default N", value: " n ", expected: false},
+		{name: "default 1", value: "1", expected: true},
+		{name: "default 0", value: map[string]interface{}{"string": "0"}, expected: false},
+		{name: "integer", value: int64(1), expected: true},
+		{name: "default unrecognized", value: "maybe", errContains: "field read: expected bool-like"},
+		{name: "custom true", boolStrings: custom, value: "J", expected: true},
+		{name: "custom false", boolStrings: custom, value: "n", expected: false},
+		{name: "custom unrecognized", boolStrings: custom, value: "true", errContains: "field read: expected bool-like"},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := UnmarshalOptions{LenientBools: true, BoolStrings: tt.boolStrings}
+			var got library.Book
+			err := opts.Unmarshal(map[string]interface{}{"read": tt.value}, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, got.GetRead())
+		})
+	}
+}
+
+func TestUnmarshalOptions_CaseInsensitiveFieldNames_Ambiguous(t *testing.T) {
+	opts := UnmarshalOptions{CaseInsensitiveFieldNames: true}
+	var msg examplev1.ExampleNameCollision
+	err := opts.Unmarshal(map[string]interface{}{"TITLE": "a"}, &msg)
+	assert.ErrorContains(t, err, "ambiguous field name TITLE: matches fields display_name and title ignoring case")
+}
+
+func TestUnmarshalOptions_Strict(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		opts        UnmarshalOptions
+		msg         proto.Message
+		data        map[string]interface{}
+		errContains string
+	}{
+		{
+			name: "passes tolerant",
+			msg:  &library.ListBooksResponse{},
+			data: map[string]interface{}{
+				"books": map[string]interface{}{
+					"array": []interface{}{
+						map[string]interface{}{"name": "shelves/1/books/1", "READ": "true"},
+					},
+				},
+			},
+			errContains: "unexpected field READ",
+		},
+		{
+			name:        "unknown enum symbol",
+			msg:         &examplev1.ExampleEnum{},
+			data:        map[string]interface{}{"enum_value": "ENUM_VALUE3"},
+			errContains: "field enum_value: unknown enum symbol ENUM_VALUE3",
+		},
+		{
+			name: "proto2 required",
+			msg:  &descriptorpb.UninterpretedOption_NamePart{},
+			data: map[string]interface{}{
+				"name_part": "name",
+			},
+			errContains: "missing required fields: is_extension",
+		},
+		{
+			name: "max depth",
+			opts: UnmarshalOptions{MaxDepth: 2},
+			msg:  &examplev1.ExampleRecursive{},
+			data: map[string]interface{}{
+				"recursive": map[string]interface{}{
+					"recursive": map[string]interface{}{},
+				},
+			},
+			errContains: "recursive.recursive: records nested deeper than max depth 2",
+		},
+		{
+			name: "max elements",
+			opts: UnmarshalOptions{MaxElements: 2},
+			msg:  &examplev1.ExampleList{},
+			data: map[string]interface{}{
+				"string_list": []interface{}{"a", "b", "c"},
+			},
+			errContains: "field string_list: 3 elements exceed max elements 2",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NilError(t, UnmarshalOptions{}.Tolerant().Unmarshal(tt.data, proto.Clone(tt.msg)))
+			err := tt.opts.Strict().Unmarshal(tt.data, tt.msg)
+			assert.ErrorContains(t, err, tt.errContains)
+		})
+	}
+}
+
+func TestUnmarshalOptions_Strict_LenientOptions(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		opts        UnmarshalOptions
+		msg         proto.Message
+		data        map[string]interface{}
+		errContains string
+	}{
+		{
+			name:        "stringified scalars",
+			opts:        UnmarshalOptions{AutoParseStringifiedScalars: true},
+			msg:         &examplev1.ExampleIntegers{},
+			data:        map[string]interface{}{"int32_value": map[string]interface{}{"string": "123"}},
+			errContains: "field int32_value: expected key 'int'",
+		},
+		{
+			name:        "stringified messages",
+			opts:        UnmarshalOptions{AutoParseStringifiedMessages: true},
+			msg:         &examplev1.ExampleRecursive{},
+			data:        map[string]interface{}{"recursive": `{"recursive": null}`},
+			errContains: "expected message encoded as map[string]interface{}, got string",
+		},
+		{
+			name: "coercion table",
+			opts: UnmarshalOptions{
+				CoercionTable: map[Coercion]func(interface{}) (interface{}, error){
+					{From: "string", To: "long"}: func(interface{}) (interface{}, error) { return int64(123), nil },
+				},
+			},
+			msg:         &examplev1.ExampleIntegers{},
+			data:        map[string]interface{}{"int64_value": map[string]interface{}{"string": "123"}},
+			errContains: "field int64_value: expected key 'long'",
+		},
+		{
+			name: "nested arrays",
+			opts: UnmarshalOptions{FlattenNestedArrays: true},
+			msg:  &examplev1.ExampleList{},
+			data: map[string]interface{}{
+				"int64_list": []interface{}{[]interface{}{int64(1), int64(2)}, int64(3)},
+			},
+			errContains: "field int64_list: expected int-like, got []interface {}",
+		},
+		{
+			name: "null map values",
+			msg:  &examplev1.ExampleMap{},
+			data: map[string]interface{}{
+				"string_to_string": []interface{}{
+					map[string]interface{}{"key": "a", "value": nil},
+				},
+			},
+			errContains: "string_to_string[a]: null map value",
+		},
+		{
+			name:        "null list elements",
+			msg:         &examplev1.ExampleList{},
+			data:        map[string]interface{}{"string_list": []interface{}{"a", nil}},
+			errContains: "string_list[1]: null list element",
+		},
+		{
+			name:        "raw extras",
+			opts:        UnmarshalOptions{RawExtras: &map[string]interface{}{}},
+			msg:         &library.Book{},
+			data:        map[string]interface{}{"extra": "value"},
+			errContains: "unexpected field extra",
+		},
+		{
+			name: "unknown field callback",
+			opts: UnmarshalOptions{
+				OnUnknownField: func(protoreflect.FullName, string, interface{}) error { return nil },
+			},
+			msg:         &library.Book{},
+			data:        map[string]interface{}{"extra": "value"},
+			errContains: "unexpected field extra",
+		},
+		{
+			name:        "name normalization",
+			opts:        UnmarshalOptions{NameNormalization: []FieldNameCase{FieldNameCamelToPascal}},
+			msg:         &examplev1.ExampleList{},
+			data:        map[string]interface{}{"Int64List": []interface{}{int64(1)}},
+			errContains: "unexpected field Int64List",
+		},
+		{
+			name:        "field name case",
+			opts:        UnmarshalOptions{FieldNameCase: FieldNameCamelToPascal},
+			msg:         &examplev1.ExampleList{},
+			data:        map[string]interface{}{"Int64List": []interface{}{int64(1)}},
+			errContains: "unexpected field Int64List",
+		},
+		{
+			name:        "enum numbers",
+			opts:        UnmarshalOptions{AcceptEnumNumbers: true},
+			msg:         &examplev1.ExampleEnum{},
+			data:        map[string]interface{}{"enum_value": map[string]interface{}{"int": int32(1)}},
+			errContains: "field enum_value: expected key 'einride.avro.example.v1.ExampleEnum.Enum'",
+		},
+		{
+			name: "enum objects",
+			opts: UnmarshalOptions{AcceptEnumObjects: true},
+			msg:  &examplev1.ExampleEnum{},
+			data: map[string]interface{}{
+				"enum_value": map[string]interface{}{"symbol": "ENUM_VALUE1", "value": int32(1)},
+			},
+			errContains: "field enum_value: expected key 'einride.avro.example.v1.ExampleEnum.Enum'",
+		},
+		{
+			name:        "trimmed enum symbols",
+			opts:        UnmarshalOptions{TrimEnumSymbols: true},
+			msg:         &examplev1.ExampleEnum{},
+			data:        map[string]interface{}{"enum_value": " ENUM_VALUE1 "},
+			errContains: "field enum_value: unknown enum symbol  ENUM_VALUE1 ",
+		},
+		{
+			name:        "enum namespaces",
+			opts:        UnmarshalOptions{StripEnumNamespace: true},
+			msg:         &examplev1.ExampleEnum{},
+			data:        map[string]interface{}{"enum_value": "einride.avro.example.v1.ExampleEnum.Enum.ENUM_VALUE1"},
+			errContains: "field enum_value: unknown enum symbol einride.avro.example.v1.ExampleEnum.Enum.ENUM_VALUE1",
+		},
+		{
+			name:        "int32 truncation",
+			msg:         &examplev1.ExampleIntegers{},
+			data:        map[string]interface{}{"int32_value": map[string]interface{}{"int": int64(1) << 40}},
+			errContains: "field int32_value: precision loss: value 1099511627776 truncated to int32",
+		},
+		{
+			name:        "negative uint32",
+			msg:         &examplev1.ExampleIntegers{},
+			data:        map[string]interface{}{"uint32_value": map[string]interface{}{"int": int32(-1)}},
+			errContains: "field uint32_value: precision loss: value -1 truncated to uint32",
+		},
+		{
+			name:        "float overflow",
+			msg:         &examplev1.ExampleWrappers{},
+			data:        map[string]interface{}{"float_value": map[string]interface{}{"float": 1e300}},
+			errContains: "google.protobuf.FloatValue: field value: value 1e+300 overflows float",
+		},
+		{
+			name:        "float seconds timestamps",
+			opts:        UnmarshalOptions{TimestampInput: TimestampInputFloatSeconds},
+			msg:         &examplev1.ExampleTimestamp{},
+			data:        map[string]interface{}{"timestamp": map[string]interface{}{"double": 1712345678.5}},
+			errContains: "google.protobuf.Timestamp: expected key 'long.timestamp-micros'",
+		},
+		{
+			name:        "timestamp layouts",
+			opts:        UnmarshalOptions{TimestampLayouts: []string{"2006-01-02 15:04:05"}},
+			msg:         &examplev1.ExampleTimestamp{},
+			data:        map[string]interface{}{"timestamp": map[string]interface{}{"string": "2024-01-02 03:04:05"}},
+			errContains: `parsing time "2024-01-02 03:04:05"`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NilError(t, tt.opts.Unmarshal(tt.data, proto.Clone(tt.msg)))
+			err := tt.opts.Strict().Unmarshal(tt.data, tt.msg)
+			assert.ErrorContains(t, err, tt.errContains)
+		})
+	}
+
+	t.Run("map key normalizer", func(t *testing.T) {
+		opts := UnmarshalOptions{MapKeyNormalizer: strings.TrimSpace}.Strict()
+		var got examplev1.ExampleMap
+		assert.NilError(t, opts.Unmarshal(map[string]interface{}{
+			"string_to_string": []interface{}{
+				map[string]interface{}{"key": " a ", "value": "1"},
+			},
+		}, &got))
+		assert.DeepEqual(t, map[string]string{" a ": "1"}, got.GetStringToString())
+	})
+
+	t.Run("timestamp precision", func(t *testing.T) {
+		opts := UnmarshalOptions{AutoDetectTimestampPrecision: true}.Strict()
+		var got examplev1.ExampleTimestamp
+		assert.NilError(t, opts.Unmarshal(map[string]interface{}{
+			"timestamp": map[string]interface{}{"long.timestamp-micros": int64(1712345678)},
+		}, &got))
+		// the value is microseconds, as encoded by the schema, not seconds.
+		assert.Equal(t, time.Date(1970, 1, 1, 0, 28, 32, 345678000, time.UTC), got.GetTimestamp().AsTime())
+	})
+}
+
+func TestUnmarshal_PromotedTypes(t *testing.T) {
+	t.Run("int written into long fields", func(t *testing.T) {
+		writerSchema := `{
+			"type": "record",
+			"name": "ExampleList",
+			"namespace": "einride.avro.example.v1",
+			"fields": [
+				{"name": "int64_list", "type": ["null", {"type": "array", "items": ["null", "int"]}]}
+			]
+		}`
+		codec, err := goavro.NewCodec(writerSchema)
+		assert.NilError(t, err)
+		binary, err := codec.BinaryFromNative(nil, map[string]interface{}{
+			"int64_list": map[string]interface{}{
+				"array": []interface{}{
+					map[string]interface{}{"int": int32(1)},
+					map[string]interface{}{"int": int32(-2)},
+				},
+			},
+		})
+		assert.NilError(t, err)
+		native, _, err := codec.NativeFromBinary(binary)
+		assert.NilError(t, err)
+		var got examplev1.ExampleList
+		assert.NilError(t, UnmarshalOptions{}.Unmarshal(native, &got))
+		assert.DeepEqual(t, &examplev1.ExampleList{Int64List: []int64{1, -2}}, &got, protocmp.Transform())
+	})
+	t.Run("float written into double field", func(t *testing.T) {
+		var got descriptorpb.UninterpretedOption
+		assert.NilError(t, UnmarshalOptions{}.Unmarshal(map[string]interface{}{
+			"double_value": map[string]interface{}{"float": float32(1.5)},
+		}, &got))
+		assert.Equal(t, 1.5, got.GetDoubleValue())
+	})
+	t.Run("long written into int field", func(t *testing.T) {
+		var got library.ListBooksRequest
+		err := UnmarshalOptions{}.Unmarshal(map[string]interface{}{
+			"page_size": map[string]interface{}{"long": int64(1)},
+		}, &got)
+		assert.ErrorContains(t, err, "field page_size: expected key 'int'")
+	})
+}
+
+func TestUnmarshalOptions_AutoParseStringifiedMessages(t *testing.T) {
+	opts := UnmarshalOptions{AutoParseStringifiedMessages: true}
+	for _, tt := range []struct {
+		name        string
+		opts        UnmarshalOptions
+		data        map[string]interface{}
+		expected    proto.Message
+		errContains string
+	}{
+		{
+			name: "stringified",
+			opts: opts,
+			data: map[string]interface{}{
+				"book": map[string]interface{}{"string": `{"name": "shelves/1/books/1", "read": true}`},
+			},
+			expected: &library.UpdateBookRequest{Book: &library.Book{Name: "shelves/1/books/1", Read: true}},
+		},
+		{
+			name: "record",
+			opts: opts,
+			data: map[string]interface{}{
+				"book": map[string]interface{}{"name": "shelves/1/books/1"},
+			},
+			expected: &library.UpdateBookRequest{Book: &library.Book{Name: "shelves/1/books/1"}},
+		},
+		{
+			name: "invalid JSON",
+			opts: opts,
+			data: map[string]interface{}{
+				"book": `{"name": `,
+			},
+			errContains: "field book: parse stringified message: unexpected end of JSON input",
+		},
+		{
+			name: "disabled",
+			data: map[string]interface{}{
+				"book": `{"name": "shelves/1/books/1"}`,
+			},
+			errContains: "expected message encoded as map[string]interface{}, got string",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got library.UpdateBookRequest
+			err := tt.opts.Unmarshal(tt.data, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, &got, protocmp.Transform())
+		})
+	}
+}
+
+func TestUnmarshalOptions_AutoParseStringifiedScalars(t *testing.T) {
+	opts := UnmarshalOptions{AutoParseStringifiedScalars: true}
+	for _, tt := range []struct {
+		name        string
+		opts        UnmarshalOptions
+		data        map[string]interface{}
+		got         proto.Message
+		expected    proto.Message
+		errContains string
+	}{
+		{
+			name:     "int",
+			opts:     opts,
+			data:     map[string]interface{}{"page_size": map[string]interface{}{"string": "123"}},
+			got:      &library.ListShelvesRequest{},
+			expected: &library.ListShelvesRequest{PageSize: 123},
+		},
+		{
+			name:     "double-encoded int",
+			opts:     opts,
+			data:     map[string]interface{}{"page_size": `"123"`},
+			got:      &library.ListShelvesRequest{},
+			expected: &library.ListShelvesRequest{PageSize: 123},
+		},
+		{
+			name:     "bool",
+			opts:     opts,
+			data:     map[string]interface{}{"read": map[string]interface{}{"string": "true"}},
+			got:      &library.Book{},
+			expected: &library.Book{Read: true},
+		},
+		{
+			name:     "string field",
+			opts:     opts,
+			data:     map[string]interface{}{"title": map[string]interface{}{"string": "123"}},
+			got:      &library.Book{},
+			expected: &library.Book{Title: "123"},
+		},
+		{
+			name:        "not a number",
+			opts:        opts,
+			data:        map[string]interface{}{"page_size": "twelve"},
+			got:         &library.ListShelvesRequest{},
+			errContains: "field page_size",
+		},
+		{
+			name:        "disabled",
+			data:        map[string]interface{}{"page_size": map[string]interface{}{"string": "123"}},
+			got:         &library.ListShelvesRequest{},
+			errContains: "field page_size: expected key 'int'",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.opts.Unmarshal(tt.data, tt.got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, tt.got, protocmp.Transform())
+		})
+	}
+}
+
+func TestUnmarshalOptions_RawExtras(t *testing.T) {
+	t.Run("unknown fields", func(t *testing.T) {
+		var extras map[string]interface{}
+		opts := UnmarshalOptions{RawExtras: &extras}
+		var got library.UpdateBookRequest
+		assert.NilError(t, opts.Unmarshal(map[string]interface{}{
+			"book": map[string]interface{}{
+				"name":  "shelves/1/books/1",
+				"isbn":  map[string]interface{}{"string": "978-0"},
+				"pages": int64(42),
+			},
+			"request_id": "abc",
+		}, &got))
+		expected := &library.UpdateBookRequest{Book: &library.Book{Name: "shelves/1/books/1"}}
+		assert.DeepEqual(t, expected, &got, protocmp.Transform())
+		assert.DeepEqual(t, map[string]interface{}{
+			"book.isbn":  map[string]interface{}{"string": "978-0"},
+			"book.pages": int64(42),
+			"request_id": "abc",
+		}, extras)
+	})
+
+	t.Run("unknown union branches", func(t *testing.T) {
+		var extras map[string]interface{}
+		opts := UnmarshalOptions{RawExtras: &extras, UnknownUnionBranchAsNull: true}
+		var got examplev1.ExampleList
+		assert.NilError(t, opts.Unmarshal(map[string]interface{}{
+			"string_list": []interface{}{"a", map[string]interface{}{"long": int64(1)}},
+			"int64_list":  map[string]interface{}{"map": map[string]interface{}{}},
+		}, &got))
+		assert.DeepEqual(t, &examplev1.ExampleList{StringList: []string{"a", ""}}, &got, protocmp.Transform())
+		assert.DeepEqual(t, map[string]interface{}{
+			"string_list[1]": map[string]interface{}{"long": int64(1)},
+			"int64_list":     map[string]interface{}{"map": map[string]interface{}{}},
+		}, extras)
+	})
+
+	t.Run("nothing unmapped", func(t *testing.T) {
+		var extras map[string]interface{}
+		opts := UnmarshalOptions{RawExtras: &extras}
+		var got library.Book
+		assert.NilError(t, opts.Unmarshal(map[string]interface{}{"name": "shelves/1/books/1"}, &got))
+		assert.Assert(t, extras == nil)
+	})
+}
+
+func TestUnmarshalOptions_UnmarshalPartial(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		data             interface{}
+		expected         proto.Message
+		expectedLeftover map[string]interface{}
+		errContains      string
+	}{
+		{
+			name: "no unknown fields",
+			data: map[string]interface{}{
+				"name": map[string]interface{}{"string": "shelves/1/books/1"},
+			},
+			expected: &library.Book{Name: "shelves/1/books/1"},
+		},
+		{
+			name: "unknown fields",
+			data: map[string]interface{}{
+				"name":      map[string]interface{}{"string": "shelves/1/books/1"},
+				"publisher": map[string]interface{}{"string": "Bloomsbury"},
+				"pages":     int64(223),
+			},
+			expected: &library.Book{Name: "shelves/1/books/1"},
+			expectedLeftover: map[string]interface{}{
+				"publisher": map[string]interface{}{"string": "Bloomsbury"},
+				"pages":     int64(223),
+			},
+		},
+		{
+			name: "union wrapped",
+			data: map[string]interface{}{
+				"google.example.library.v1.Book": map[string]interface{}{
+					"title": map[string]interface{}{"string": "Harry Potter"},
+					"isbn":  "9780747532699",
+				},
+			},
+			expected:         &library.Book{Title: "Harry Potter"},
+			expectedLeftover: map[string]interface{}{"isbn": "9780747532699"},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got library.Book
+			leftover, err := UnmarshalOptions{}.UnmarshalPartial(tt.data, &got)
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, &got, protocmp.Transform())
+			assert.DeepEqual(t, tt.expectedLeftover, leftover)
+		})
+	}
+	t.Run("unknown nested field", func(t *testing.T) {
+		var got library.UpdateBookRequest
+		_, err := UnmarshalOptions{}.UnmarshalPartial(map[string]interface{}{
+			"book": map[string]interface{}{"publisher": "Bloomsbury"},
+		}, &got)
+		assert.ErrorContains(t, err, "unexpected field publisher")
+	})
+}
+
+func TestUnmarshalOptions_FailOnPrecisionLoss(t *testing.T) {
+	valueField := func(msg proto.Message) protoreflect.FieldDescriptor {
+		return msg.ProtoReflect().Descriptor().Fields().ByName("value")
+	}
+	for _, tt := range []struct {
+		name        string
+		opts        UnmarshalOptions
+		field       protoreflect.FieldDescriptor
+		data        interface{}
+		expected    interface{}
+		errContains string
+	}{
+		{
+			name:        "double to float",
+			field:       valueField(&wrapperspb.FloatValue{}),
+			data:        map[string]interface{}{"double": 0.1},
+			errContains: "field value: precision loss: 0.1 rounded to float",
+		},
+		{
+			name:     "exact double to float",
+			field:    valueField(&wrapperspb.FloatValue{}),
+			data:     0.5,
+			expected: float32(0.5),
+		},
+		{
+			name:        "numeric string to float",
+			opts:        UnmarshalOptions{NumericStrings: true},
+			field:       valueField(&wrapperspb.FloatValue{}),
+			data:        "0.1",
+			errContains: "precision loss: 0.1 rounded to float",
+		},
+		{
+			name:        "long to int",
+			field:       valueField(&wrapperspb.Int32Value{}),
+			data:        int64(math.MaxInt32 + 1),
+			errContains: "field value: precision loss: value 2147483648 truncated to int32",
+		},
+		{
+			name:        "long to uint",
+			field:       valueField(&wrapperspb.UInt32Value{}),
+			data:        int64(-1),
+			errContains: "precision loss: value -1 truncated to uint32",
+		},
+		{
+			name:        "negative long to uint64",
+			field:       valueField(&wrapperspb.UInt64Value{}),
+			data:        map[string]interface{}{"long": int64(-1)},
+			errContains: "precision loss: negative value -1 converted to uint64",
+		},
+		{
+			name:        "inexact double to long",
+			field:       valueField(&wrapperspb.Int64Value{}),
+			data:        float64(1<<53 + 2),
+			errContains: "precision loss: 9.007199254740994e+15 exceeds the range of exact integers in doubles",
+		},
+		{
+			name:        "inexact JSON number to long",
+			field:       valueField(&wrapperspb.Int64Value{}),
+			data:        map[string]interface{}{"long": json.Number("9.007199254740994e15")},
+			errContains: "precision loss",
+		},
+		{
+			name:     "exact double to long",
+			field:    valueField(&wrapperspb.Int64Value{}),
+			data:     float64(1 << 53),
+			expected: int64(1 << 53),
+		},
+		{
+			name:     "JSON integer to long",
+			field:    valueField(&wrapperspb.Int64Value{}),
+			data:     json.Number("9007199254740993"),
+			expected: int64(9007199254740993),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := tt.opts
+			opts.FailOnPrecisionLoss = true
+			got, err := opts.newDecoder().decodeFieldKind(tt.data, protoreflect.Value{}, tt.field)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				opts.FailOnPrecisionLoss = false
+				_, err := opts.newDecoder().decodeFieldKind(tt.data, protoreflect.Value{}, tt.field)
+				assert.NilError(t, err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, got.Interface())
+		})
+	}
+}
+
+func TestUnmarshalOptions_FloatOverflowPolicy(t *testing.T) {
+	field := (&wrapperspb.FloatValue{}).ProtoReflect().Descriptor().Fields().ByName("value")
+	for _, tt := range []struct {
+		name        string
+		policy      FloatOverflowPolicy
+		data        interface{}
+		expected    float32
+		errContains string
+	}{
+		{
+			name:     "double in range",
+			policy:   FloatOverflowError,
+			data:     map[string]interface{}{"double": 1.5},
+			expected: 1.5,
+		},
+		{
+			name:     "bare double in range",
+			policy:   FloatOverflowError,
+			data:     -2.25,
+			expected: -2.25,
+		},
+		{
+			name:     "overflow allowed",
+			data:     map[string]interface{}{"double": 1e39},
+			expected: float32(math.Inf(1)),
+		},
+		{
+			name:     "overflow saturated",
+			policy:   FloatOverflowSaturate,
+			data:     map[string]interface{}{"double": 1e39},
+			expected: math.MaxFloat32,
+		},
+		{
+			name:     "negative overflow saturated",
+			policy:   FloatOverflowSaturate,
+			data:     -1e39,
+			expected: -math.MaxFloat32,
+		},
+		{
+			name:        "overflow rejected",
+			policy:      FloatOverflowError,
+			data:        map[string]interface{}{"double": 1e39},
+			errContains: "field value: value 1e+39 overflows float",
+		},
+		{
+			name:     "infinity is not an overflow",
+			policy:   FloatOverflowError,
+			data:     map[string]interface{}{"double": math.Inf(-1)},
+			expected: float32(math.Inf(-1)),
+		},
+		{
+			name:     "float",
+			policy:   FloatOverflowError,
+			data:     map[string]interface{}{"float": float32(3.5)},
+			expected: 3.5,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			d := UnmarshalOptions{FloatOverflowPolicy: tt.policy}.newDecoder()
+			got, err := d.decodeFieldKind(tt.data, protoreflect.Value{}, field)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, float32(got.Float()))
+		})
+	}
+}
+
+func TestUnmarshalOptions_AcceptEnumObjects(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		data        interface{}
+		expected    examplev1.ExampleEnum_Enum
+		errContains string
+	}{
+		{
+			name:     "symbol and matching value",
+			data:     map[string]interface{}{"symbol": "ENUM_VALUE2", "value": 2},
+			expected: examplev1.ExampleEnum_ENUM_VALUE2,
+		},
+		{
+			name:     "symbol only",
+			data:     map[string]interface{}{"symbol": "ENUM_VALUE1"},
+			expected: examplev1.ExampleEnum_ENUM_VALUE1,
+		},
+		{
+			name:     "value only",
+			data:     map[string]interface{}{"value": json.Number("2")},
+			expected: examplev1.ExampleEnum_ENUM_VALUE2,
+		},
+		{
+			name: "union wrapped",
+			data: map[string]interface{}{
+				"einride.avro.example.v1.ExampleEnum.Enum": map[string]interface{}{"symbol": "ENUM_VALUE1", "value": 1},
+			},
+			expected: examplev1.ExampleEnum_ENUM_VALUE1,
+		},
+		{
+			name:     "symbol string",
+			data:     map[string]interface{}{"einride.avro.example.v1.ExampleEnum.Enum": "ENUM_VALUE2"},
+			expected: examplev1.ExampleEnum_ENUM_VALUE2,
+		},
+		{
+			name:        "conflicting symbol and value",
+			data:        map[string]interface{}{"symbol": "ENUM_VALUE1", "value": 2},
+			errContains: "field enum_value: enum symbol ENUM_VALUE1 does not match value 2",
+		},
+		{
+			name:        "unknown value",
+			data:        map[string]interface{}{"value": 7},
+			errContains: "field enum_value: unknown enum value 7",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleEnum
+			err := UnmarshalOptions{AcceptEnumObjects: true}.Unmarshal(map[string]interface{}{"enum_value": tt.data}, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, got.GetEnumValue())
+		})
+	}
+}
+
+func TestUnmarshalOptions_UnmarshalReader_DuplicateKeys(t *testing.T) {
+	// duplicate keys usually indicate producer bugs, and are rejected when the document is parsed.
+	const text = `{"google.example.library.v1.Book": {` +
+		`"name": {"string": "shelves/1/books/1"}, "author": {"string": "J. K. Rowling"}, ` +
+		`"title": {"string": "Harry Potter"}, "read": {"boolean": true}, ` +
+		`"title": {"string": "Lord of the Rings"}}}`
+	var got library.Book
+	err := UnmarshalOptions{}.UnmarshalReader(strings.NewReader(text), &got)
+	assert.ErrorContains(t, err, `duplicate key: "title"`)
+}
+
+func TestUnmarshalOptions_OnUnknownField(t *testing.T) {
+	data := map[string]interface{}{
+		"google.example.library.v1.Book": map[string]interface{}{
+			"title":     map[string]interface{}{"string": "Harry Potter"},
+			"publisher": map[string]interface{}{"string": "Bloomsbury"},
+		},
+	}
+	t.Run("skip", func(t *testing.T) {
+		type unknownField struct {
+			messageName protoreflect.FullName
+			fieldName   string
+			value       interface{}
+		}
+		var unknown []unknownField
+		opts := UnmarshalOptions{
+			OnUnknownField: func(messageName protoreflect.FullName, fieldName string, value interface{}) error {
+				unknown = append(unknown, unknownField{messageName: messageName, fieldName: fieldName, value: value})
+				return nil
+			},
+		}
+		var got library.Book
+		assert.NilError(t, opts.Unmarshal(data, &got))
+		assert.Equal(t, "Harry Potter", got.GetTitle())
+		assert.DeepEqual(t, []unknownField{
+			{
+				messageName: "google.example.library.v1.Book",
+				fieldName:   "publisher",
+				value:       map[string]interface{}{"string": "Bloomsbury"},
+			},
+		}, unknown, cmp.AllowUnexported(unknownField{}))
+	})
+	t.Run("error", func(t *testing.T) {
+		opts := UnmarshalOptions{
+			DiscardUnknownFields: true,
+			OnUnknownField: func(protoreflect.FullName, string, interface{}) error {
+				return errors.New("not allowed")
+			},
+		}
+		var got library.Book
+		err := opts.Unmarshal(data, &got)
+		assert.ErrorContains(t, err, "unknown field publisher: not allowed")
+	})
+}
+
+func TestUnmarshalOptions_AcceptEnumNumbers(t *testing.T) {
+	data := map[string]interface{}{
+		"enum_list": map[string]interface{}{
+			"array": []interface{}{
+				map[string]interface{}{"einride.avro.example.v1.ExampleList.Enum": "ENUM_VALUE1"},
+				json.Number("2"),
+				map[string]interface{}{"int": int32(7)},
+			},
+		},
+	}
+	t.Run("mixed symbols and numbers", func(t *testing.T) {
+		var warnings []string
+		var got examplev1.ExampleList
+		assert.NilError(t, UnmarshalOptions{AcceptEnumNumbers: true, Warnings: &warnings}.Unmarshal(data, &got))
+		assert.DeepEqual(t, []examplev1.ExampleList_Enum{
+			examplev1.ExampleList_ENUM_VALUE1,
+			examplev1.ExampleList_ENUM_VALUE2,
+			examplev1.ExampleList_ENUM_UNSPECIFIED,
+		}, got.GetEnumList())
+		assert.DeepEqual(t, []string{"enum_list[2]: unknown enum number 7 decoded as zero value"}, warnings)
+	})
+	t.Run("unknown number rejected", func(t *testing.T) {
+		var got examplev1.ExampleList
+		err := UnmarshalOptions{AcceptEnumNumbers: true, RejectUnknownEnums: true}.Unmarshal(data, &got)
+		assert.ErrorContains(t, err, "field enum_list: unknown enum number 7")
+	})
+	t.Run("numbers not accepted", func(t *testing.T) {
+		var got examplev1.ExampleList
+		err := UnmarshalOptions{}.Unmarshal(data, &got)
+		assert.ErrorContains(t, err, "field enum_list: expected string-like")
+	})
+}
+
+func TestUnmarshalOptions_RejectMultipleOneofFields(t *testing.T) {
+	data := map[string]interface{}{
+		"oneof_bool_1": map[string]interface{}{"boolean": true},
+		"oneof_empty_message_1": map[string]interface{}{
+			"einride.avro.example.v1.ExampleOneof.EmptyMessage": map[string]interface{}{},
+		},
+		"oneof_empty_message_2": nil,
+		"oneof_message": map[string]interface{}{
+			"einride.avro.example.v1.ExampleOneof.Message": map[string]interface{}{
+				"string_value": map[string]interface{}{"string": "value"},
+			},
+		},
+	}
+	opts := UnmarshalOptions{RejectMultipleOneofFields: true}
+	t.Run("nullable field per member", func(t *testing.T) {
+		schema, err := opts.InferSchema((&examplev1.ExampleOneof{}).ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		fields := map[string]avro.Schema{}
+		for _, field := range schema.(avro.Union)[1].(avro.Record).Fields {
+			fields[field.Name] = field.Type
+		}
+		for name := range data {
+			assert.Assert(t, fields[name] != nil, name)
+			assert.DeepEqual(t, avro.Null(), fields[name].(avro.Union)[0])
+		}
+	})
+	t.Run("one field set per oneof", func(t *testing.T) {
+		var got examplev1.ExampleOneof
+		assert.NilError(t, opts.Unmarshal(map[string]interface{}{
+			"oneof_bool_1":          data["oneof_bool_1"],
+			"oneof_empty_message_1": nil,
+			"oneof_empty_message_2": nil,
+			"oneof_message":         data["oneof_message"],
+		}, &got))
+		assert.Equal(t, true, got.GetOneofBool_1())
+		assert.Equal(t, "value", got.GetOneofMessage().GetStringValue())
+	})
+	t.Run("two fields set", func(t *testing.T) {
+		var got examplev1.ExampleOneof
+		err := opts.Unmarshal(data, &got)
+		assert.ErrorContains(t, err, "oneof oneof_fields_1: fields oneof_bool_1 and oneof_empty_message_1 are both set")
+	})
+}
+
+func TestUnmarshalOptions_TrimEnumSymbols(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		symbol           string
+		expectedWarnings []string
+	}{
+		{
+			name:   "unpadded",
+			symbol: "ENUM_VALUE1",
+		},
+		{
+			name:             "padded",
+			symbol:           " ENUM_VALUE1\t",
+			expectedWarnings: []string{`enum_value: trimmed whitespace around enum symbol " ENUM_VALUE1\t"`},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var warnings []string
+			opts := UnmarshalOptions{TrimEnumSymbols: true, RejectUnknownEnums: true, Warnings: &warnings}
+			var got examplev1.ExampleEnum
+			assert.NilError(t, opts.Unmarshal(map[string]interface{}{
+				"enum_value": map[string]interface{}{"einride.avro.example.v1.ExampleEnum.Enum": tt.symbol},
+			}, &got))
+			assert.Equal(t, examplev1.ExampleEnum_ENUM_VALUE1, got.GetEnumValue())
+			assert.DeepEqual(t, tt.expectedWarnings, warnings)
+		})
+	}
+	t.Run("padded without trimming", func(t *testing.T) {
+		var got examplev1.ExampleEnum
+		err := UnmarshalOptions{RejectUnknownEnums: true}.Unmarshal(map[string]interface{}{
+			"enum_value": map[string]interface{}{"einride.avro.example.v1.ExampleEnum.Enum": " ENUM_VALUE1"},
+		}, &got)
+		assert.ErrorContains(t, err, "unknown enum symbol  ENUM_VALUE1")
+	})
+}
+
+func TestUnmarshalOptions_MaxDuration(t *testing.T) {
+	slowTransform := func(fd protoreflect.FieldDescriptor, raw interface{}) (interface{}, error) {
+		time.Sleep(20 * time.Millisecond)
+		return raw, nil
+	}
+	data := map[string]interface{}{
+		"string_list": map[string]interface{}{"array": []interface{}{"a", "b", "c"}},
+	}
+	t.Run("exceeded", func(t *testing.T) {
+		var got examplev1.ExampleList
+		err := UnmarshalOptions{
+			MaxDuration:     10 * time.Millisecond,
+			ValueTransforms: []func(protoreflect.FieldDescriptor, interface{}) (interface{}, error){slowTransform},
+		}.Unmarshal(data, &got)
+		assert.ErrorContains(t, err, "string_list[1]: decode exceeded max duration 10ms")
+		assert.Assert(t, errors.Is(err, context.DeadlineExceeded))
+	})
+	t.Run("within budget", func(t *testing.T) {
+		var got examplev1.ExampleList
+		assert.NilError(t, UnmarshalOptions{
+			MaxDuration:     time.Minute,
+			ValueTransforms: []func(protoreflect.FieldDescriptor, interface{}) (interface{}, error){slowTransform},
+		}.Unmarshal(data, &got))
+		assert.DeepEqual(t, []string{"a", "b", "c"}, got.StringList)
+	})
+}
+
+func TestUnmarshalOptions_NullListElementPolicy(t *testing.T) {
+	data := map[string]interface{}{
+		"int64_list": map[string]interface{}{"array": []interface{}{
+			map[string]interface{}{"long": int64(1)},
+			nil,
+			map[string]interface{}{"long": int64(2)},
+		}},
+	}
+	for _, tt := range []struct {
+		name        string
+		policy      NullListElementPolicy
+		expected    []int64
+		errContains string
+	}{
+		{name: "zero", policy: NullListElementZero, expected: []int64{1, 0, 2}},
+		{name: "drop", policy: NullListElementDrop, expected: []int64{1, 2}},
+		{name: "error", policy: NullListElementError, errContains: "int64_list[1]: null list element"},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleList
+			err := UnmarshalOptions{NullListElementPolicy: tt.policy}.Unmarshal(data, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got.Int64List)
+		})
+	}
+}
+
+func TestUnmarshalOptions_FlattenNestedArrays(t *testing.T) {
+	long := func(v int64) interface{} {
+		return map[string]interface{}{"long": v}
+	}
+	for _, tt := range []struct {
+		name        string
+		flatten     bool
+		data        interface{}
+		expected    []int64
+		errContains string
+	}{
+		{
+			name:    "two levels",
+			flatten: true,
+			data: []interface{}{
+				[]interface{}{long(1), long(2)},
+				[]interface{}{long(3)},
+			},
+			expected: []int64{1, 2, 3},
+		},
+		{
+			name:    "union-wrapped inner arrays",
+			flatten: true,
+			data: map[string]interface{}{"array": []interface{}{
+				map[string]interface{}{"array": []interface{}{long(1)}},
+				map[string]interface{}{"array": []interface{}{}},
+				map[string]interface{}{"array": []interface{}{long(2), long(3)}},
+			}},
+			expected: []int64{1, 2, 3},
+		},
+		{
+			name:     "flat",
+			flatten:  true,
+			data:     []interface{}{long(1), long(2)},
+			expected: []int64{1, 2},
+		},
+		{
+			name:    "three levels",
+			flatten: true,
+			data: []interface{}{
+				[]interface{}{long(1)},
+				[]interface{}{[]interface{}{long(2)}},
+			},
+			errContains: "int64_list[1][0]: arrays nested more than one level deep",
+		},
+		{
+			name: "disabled",
+			data: []interface{}{
+				[]interface{}{long(1), long(2)},
+			},
+			errContains: "int64_list",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleList
+			err := UnmarshalOptions{FlattenNestedArrays: tt.flatten}.Unmarshal(
+				map[string]interface{}{"int64_list": tt.data},
+				&got,
+			)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got.Int64List)
+		})
+	}
+}
+
+func TestUnmarshalOptions_MessageHooks(t *testing.T) {
+	msg := &examplev1.ExampleNested{
+		Name: "a",
+		Level1: &examplev1.ExampleNested_Level1{
+			Level2: &examplev1.ExampleNested_Level2{
+				Level3: &examplev1.ExampleNested_Level3{Value: "b"},
+			},
+		},
+	}
+	data, err := MarshalOptions{}.Encode(msg)
+	assert.NilError(t, err)
+	var calls []string
+	opts := UnmarshalOptions{
+		BeforeMessage: func(desc protoreflect.MessageDescriptor, record map[string]interface{}) error {
+			calls = append(calls, "before "+string(desc.Name()))
+			return nil
+		},
+		AfterMessage: func(msg proto.Message) error {
+			calls = append(calls, "after "+string(msg.ProtoReflect().Descriptor().Name()))
+			return nil
+		},
+	}
+	var got examplev1.ExampleNested
+	assert.NilError(t, opts.Unmarshal(data, &got))
+	assert.DeepEqual(t, msg, &got, protocmp.Transform())
+	assert.DeepEqual(t, []string{
+		"before ExampleNested",
+		"before Level1",
+		"before Level2",
+		"before Level3",
+		"after Level3",
+		"after Level2",
+		"after Level1",
+		"after ExampleNested",
+	}, calls)
+
+	t.Run("before error", func(t *testing.T) {
+		opts := UnmarshalOptions{
+			BeforeMessage: func(desc protoreflect.MessageDescriptor, record map[string]interface{}) error {
+				if desc.Name() == "Level2" {
+					return errors.New("rejected")
+				}
+				return nil
+			},
+		}
+		var got examplev1.ExampleNested
+		err := opts.Unmarshal(data, &got)
+		assert.ErrorContains(t, err, "einride.avro.example.v1.ExampleNested.Level2: before message: rejected")
+	})
+
+	t.Run("after defaulting", func(t *testing.T) {
+		opts := UnmarshalOptions{
+			AfterMessage: func(msg proto.Message) error {
+				if level3, ok := msg.(*examplev1.ExampleNested_Level3); ok && level3.Value == "b" {
+					level3.Value = "c"
+				}
+				return nil
+			},
+		}
+		var got examplev1.ExampleNested
+		assert.NilError(t, opts.Unmarshal(data, &got))
+		assert.Equal(t, "c", got.GetLevel1().GetLevel2().GetLevel3().GetValue())
+	})
+
+	t.Run("after error", func(t *testing.T) {
+		opts := UnmarshalOptions{
+			AfterMessage: func(msg proto.Message) error {
+				return errors.New("invalid")
+			},
+		}
+		var got examplev1.ExampleNested
+		err := opts.Unmarshal(data, &got)
+		assert.ErrorContains(t, err, "einride.avro.example.v1.ExampleNested.Level3: after message: invalid")
+	})
+}
+
+func TestUnmarshalOptions_AbsentEnumMember(t *testing.T) {
+	opts := UnmarshalOptions{
+		AbsentEnumMember: map[protoreflect.FullName]string{
+			"einride.avro.example.v1.ExampleEnum.Enum": "ENUM_VALUE2",
+		},
+	}
+	for _, tt := range []struct {
+		name     string
+		data     map[string]interface{}
+		expected examplev1.ExampleEnum_Enum
+	}{
+		{name: "null", data: map[string]interface{}{"enum_value": nil}, expected: examplev1.ExampleEnum_ENUM_VALUE2},
+		{name: "absent", data: map[string]interface{}{}, expected: examplev1.ExampleEnum_ENUM_VALUE2},
+		{
+			name: "present",
+			data: map[string]interface{}{
+				"enum_value": map[string]interface{}{"einride.avro.example.v1.ExampleEnum.Enum": "ENUM_VALUE1"},
+			},
+			expected: examplev1.ExampleEnum_ENUM_VALUE1,
+		},
+		{
+			name:     "present zero value",
+			data:     map[string]interface{}{"enum_value": "ENUM_UNSPECIFIED"},
+			expected: examplev1.ExampleEnum_ENUM_UNSPECIFIED,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleEnum
+			assert.NilError(t, opts.Unmarshal(tt.data, &got))
+			assert.Equal(t, tt.expected, got.GetEnumValue())
+		})
+	}
+
+	t.Run("unknown member", func(t *testing.T) {
+		opts := UnmarshalOptions{
+			AbsentEnumMember: map[protoreflect.FullName]string{
+				"einride.avro.example.v1.ExampleEnum.Enum": "ENUM_VALUE3",
+			},
+		}
+		var got examplev1.ExampleEnum
+		err := opts.Unmarshal(map[string]interface{}{}, &got)
+		assert.ErrorContains(t, err, "field enum_value: absent enum member ENUM_VALUE3 is not a value of "+
+			"einride.avro.example.v1.ExampleEnum.Enum")
+	})
+}
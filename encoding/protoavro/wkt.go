@@ -1,7 +1,12 @@
 package protoavro
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
 	"time"
 
 	"cloud.google.com/go/civil"
@@ -12,6 +17,7 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/structpb"
@@ -41,7 +47,21 @@ func isWKT(name protoreflect.FullName) bool {
 	return false
 }
 
-func schemaWKT(message protoreflect.MessageDescriptor) (avro.Schema, error) {
+// isExpandedWKT returns true if name is a well-known type with a special representation, which is not
+// one of the OpaqueWKTs that are represented as records of their fields like other messages.
+func (o SchemaOptions) isExpandedWKT(name protoreflect.FullName) bool {
+	if !isWKT(name) {
+		return false
+	}
+	for _, opaque := range o.OpaqueWKTs {
+		if opaque == name {
+			return false
+		}
+	}
+	return true
+}
+
+func (o SchemaOptions) schemaWKT(message protoreflect.MessageDescriptor) (avro.Schema, error) {
 	switch message.FullName() {
 	case wkt.DoubleValue,
 		wkt.FloatValue,
@@ -62,7 +82,7 @@ func schemaWKT(message protoreflect.MessageDescriptor) (avro.Schema, error) {
 	case wkt.Any:
 		return schemaAny(), nil
 	case wkt.Timestamp:
-		return schemaTimestamp(), nil
+		return o.schemaTimestamp(), nil
 	case wkt.Duration:
 		return schemaDuration(), nil
 	case wkt.Date:
@@ -73,7 +93,7 @@ func schemaWKT(message protoreflect.MessageDescriptor) (avro.Schema, error) {
 	return nil, fmt.Errorf("uknown wellknown type %s", message.FullName())
 }
 
-func (o SchemaOptions) encodeWKT(message protoreflect.Message) (map[string]interface{}, error) {
+func (o MarshalOptions) encodeWKT(message protoreflect.Message) (map[string]interface{}, error) {
 	desc := message.Descriptor()
 	switch desc.FullName() {
 	case wkt.DoubleValue,
@@ -103,7 +123,11 @@ func (o SchemaOptions) encodeWKT(message protoreflect.Message) (map[string]inter
 		}
 		return value, nil
 	case wkt.Timestamp:
-		return o.encodeTimestamp(message.Interface().(*timestamppb.Timestamp)), nil
+		value, err := o.encodeTimestamp(message.Interface().(*timestamppb.Timestamp))
+		if err != nil {
+			return nil, err
+		}
+		return value, nil
 	case wkt.Duration:
 		return o.encodeDuration(message.Interface().(*durationpb.Duration)), nil
 	case wkt.Date:
@@ -115,23 +139,23 @@ func (o SchemaOptions) encodeWKT(message protoreflect.Message) (map[string]inter
 	}
 }
 
-func decodeWKT(data map[string]interface{}, msg protoreflect.Message) error {
+func (d *decoder) decodeWKT(data map[string]interface{}, msg protoreflect.Message) error {
 	desc := msg.Descriptor()
 	var value proto.Message
 	var err error
 	switch desc.FullName() {
 	case wkt.Any:
-		value, err = decodeAny(data)
+		value, err = d.decodeAny(data)
 	case wkt.Date:
 		value, err = decodeDate(data)
 	case wkt.Struct:
-		value, err = decodeStruct(data)
+		value, err = d.decodeStruct(data)
 	case wkt.TimeOfDay:
 		value, err = decodeTimeOfDay(data)
 	case wkt.Duration:
-		value, err = decodeDuration(data)
+		value, err = d.decodeDuration(data)
 	case wkt.Timestamp:
-		value, err = decodeTimestamp(data)
+		value, err = d.decodeTimestamp(data)
 	case wkt.FloatValue,
 		wkt.DoubleValue,
 		wkt.UInt32Value,
@@ -141,7 +165,7 @@ func decodeWKT(data map[string]interface{}, msg protoreflect.Message) error {
 		wkt.BytesValue,
 		wkt.StringValue,
 		wkt.BoolValue:
-		value, err = decodeWrapper(string(desc.FullName()), data)
+		value, err = d.decodeWrapper(desc, data)
 	default:
 		return fmt.Errorf("unknown wellknown type %s", desc.FullName())
 	}
@@ -173,15 +197,23 @@ func schemaWrapper(w string) (avro.Schema, error) {
 	}
 }
 
-func (o SchemaOptions) encodeWrapper(msg protoreflect.Message) (map[string]interface{}, error) {
+func (o MarshalOptions) encodeWrapper(msg protoreflect.Message) (map[string]interface{}, error) {
 	if msg == nil {
 		return nil, nil
 	}
 	switch msg.Descriptor().FullName() {
 	case wkt.DoubleValue:
-		return o.unionValue("double", msg.Interface().(*wrapperspb.DoubleValue).GetValue()), nil
+		value := msg.Interface().(*wrapperspb.DoubleValue).GetValue()
+		if o.isSpecialFloat(value) {
+			return nil, nil
+		}
+		return o.unionValue("double", o.roundFloat(value, 64)), nil
 	case wkt.FloatValue:
-		return o.unionValue("float", msg.Interface().(*wrapperspb.FloatValue).GetValue()), nil
+		value := float64(msg.Interface().(*wrapperspb.FloatValue).GetValue())
+		if o.isSpecialFloat(value) {
+			return nil, nil
+		}
+		return o.unionValue("float", float32(o.roundFloat(value, 32))), nil
 	case wkt.Int32Value:
 		return o.unionValue("int", msg.Interface().(*wrapperspb.Int32Value).GetValue()), nil
 	case wkt.UInt32Value:
@@ -201,11 +233,39 @@ func (o SchemaOptions) encodeWrapper(msg protoreflect.Message) (map[string]inter
 	}
 }
 
-func decodeWrapper(w string, v map[string]interface{}) (proto.Message, error) {
+// wrapperBranches are the union branches of the values of wrapper types.
+var wrapperBranches = map[protoreflect.FullName]string{
+	wkt.DoubleValue: "double",
+	wkt.FloatValue:  "float",
+	wkt.Int32Value:  "int",
+	wkt.UInt32Value: "int",
+	wkt.Int64Value:  "long",
+	wkt.UInt64Value: "long",
+	wkt.BoolValue:   "boolean",
+	wkt.StringValue: "string",
+	wkt.BytesValue:  "bytes",
+}
+
+// unionWrapperValue returns data wrapped in the union branch of the wrapper type name, if data is a bare value,
+// such as the elements of arrays of wrappers written by producers that do not wrap array items in unions.
+func unionWrapperValue(name protoreflect.FullName, data interface{}) interface{} {
+	branch, ok := wrapperBranches[name]
+	if !ok {
+		return data
+	}
+	if _, ok := data.(map[string]interface{}); ok {
+		return data
+	}
+	return map[string]interface{}{branch: data}
+}
+
+// decodeWrapper decodes a wrapper message, with the precision checks of the scalar fields that it wraps.
+func (d *decoder) decodeWrapper(desc protoreflect.MessageDescriptor, v map[string]interface{}) (proto.Message, error) {
 	if v == nil {
 		return nil, nil
 	}
-	switch w {
+	valueField := desc.Fields().ByName("value")
+	switch w := string(desc.FullName()); w {
 	case wkt.DoubleValue:
 		f, err := decodeFloatLike(v, "double")
 		if err != nil {
@@ -217,24 +277,43 @@ func decodeWrapper(w string, v map[string]interface{}) (proto.Message, error) {
 		if err != nil {
 			return nil, fmt.Errorf("google.protobuf.FloatValue: %w", err)
 		}
-		return wrapperspb.Float(float32(f)), nil
+		narrowed, err := d.narrowFloat(valueField, f)
+		if err != nil {
+			return nil, fmt.Errorf("google.protobuf.FloatValue: %w", err)
+		}
+		return wrapperspb.Float(float32(narrowed.Float())), nil
 	case wkt.UInt32Value:
 		i, err := decodeInt(v, "int")
 		if err != nil {
 			return nil, fmt.Errorf("google.protobuf.UInt32Value: %w", err)
 		}
+		if int64(uint32(i)) != i {
+			if err := d.lossyConversion(valueField, "value %d truncated to uint32", i); err != nil {
+				return nil, fmt.Errorf("google.protobuf.UInt32Value: %w", err)
+			}
+		}
 		return wrapperspb.UInt32(uint32(i)), nil
 	case wkt.UInt64Value:
 		i, err := decodeInt(v, "long")
 		if err != nil {
 			return nil, fmt.Errorf("google.protobuf.UInt32Value: %w", err)
 		}
+		if i < 0 {
+			if err := d.lossyConversion(valueField, "negative value %d converted to uint64", i); err != nil {
+				return nil, fmt.Errorf("google.protobuf.UInt64Value: %w", err)
+			}
+		}
 		return wrapperspb.UInt64(uint64(i)), nil
 	case wkt.Int32Value:
 		i, err := decodeInt(v, "int")
 		if err != nil {
 			return nil, fmt.Errorf("google.protobuf.Int32Value: %w", err)
 		}
+		if int64(int32(i)) != i {
+			if err := d.lossyConversion(valueField, "value %d truncated to int32", i); err != nil {
+				return nil, fmt.Errorf("google.protobuf.Int32Value: %w", err)
+			}
+		}
 		return wrapperspb.Int32(int32(i)), nil
 	case wkt.Int64Value:
 		i, err := decodeInt(v, "long")
@@ -269,7 +348,7 @@ func schemaDate() avro.Schema {
 	return avro.Nullable(avro.Date())
 }
 
-func (o SchemaOptions) encodeDate(d *date.Date) map[string]interface{} {
+func (o MarshalOptions) encodeDate(d *date.Date) map[string]interface{} {
 	civilDate := civil.Date{
 		Year:  int(d.Year),
 		Month: time.Month(d.Month),
@@ -311,7 +390,7 @@ func schemaAny() avro.Schema {
 	return avro.Nullable(avro.String()) // EncodeJSON string
 }
 
-func (o SchemaOptions) encodeAny(a *anypb.Any) (map[string]interface{}, error) {
+func (o MarshalOptions) encodeAny(a *anypb.Any) (map[string]interface{}, error) {
 	data, err := protojson.Marshal(a)
 	if err != nil {
 		return nil, fmt.Errorf("google.protobuf.Any: marshal: %w", err)
@@ -319,14 +398,23 @@ func (o SchemaOptions) encodeAny(a *anypb.Any) (map[string]interface{}, error) {
 	return o.unionValue("string", string(data)), nil
 }
 
-func decodeAny(v map[string]interface{}) (*anypb.Any, error) {
+func (d *decoder) decodeAny(v map[string]interface{}) (*anypb.Any, error) {
 	if v == nil {
 		return nil, nil
 	}
+	if d.opts.ExpandAny {
+		if record, ok := inlineAnyRecord(v); ok {
+			return d.decodeInlineAny(record)
+		}
+	}
 	str, err := decodeString(v, "string")
 	if err != nil {
 		return nil, fmt.Errorf("google.protobuf.Any: %w", err)
 	}
+	// the type of the value is resolved from its type URL.
+	if err := d.countResolution(); err != nil {
+		return nil, fmt.Errorf("google.protobuf.Any: %w", err)
+	}
 	var value anypb.Any
 	if err := protojson.Unmarshal([]byte(str), &value); err != nil {
 		return nil, fmt.Errorf("google.protobuf.Any: unmarshal: %w", err)
@@ -334,11 +422,54 @@ func decodeAny(v map[string]interface{}) (*anypb.Any, error) {
 	return &value, nil
 }
 
+// inlineAnyRecord returns the record of v if it is an Any value with an inline Avro payload,
+// optionally in a union.
+func inlineAnyRecord(v map[string]interface{}) (map[string]interface{}, bool) {
+	if _, ok := v["type_url"]; ok {
+		return v, true
+	}
+	if len(v) != 1 {
+		return nil, false
+	}
+	for _, member := range v {
+		if record, ok := member.(map[string]interface{}); ok {
+			if _, ok := record["type_url"]; ok {
+				return record, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// decodeInlineAny decodes an Any value whose payload is the Avro record of the type named by its type URL.
+func (d *decoder) decodeInlineAny(record map[string]interface{}) (*anypb.Any, error) {
+	typeURL, err := decodeStringLike(record["type_url"], "string")
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Any: type_url: %w", err)
+	}
+	if err := d.countResolution(); err != nil {
+		return nil, fmt.Errorf("google.protobuf.Any: %w", err)
+	}
+	messageType, err := protoregistry.GlobalTypes.FindMessageByURL(typeURL)
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Any: resolve '%s': %w", typeURL, err)
+	}
+	value := messageType.New()
+	if err := d.decodeMessage(record["value"], value); err != nil {
+		return nil, fmt.Errorf("google.protobuf.Any: value: %w", err)
+	}
+	data, err := proto.Marshal(value.Interface())
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Any: marshal: %w", err)
+	}
+	return &anypb.Any{TypeUrl: typeURL, Value: data}, nil
+}
+
 func schemaStruct() avro.Schema {
 	return avro.Nullable(avro.String()) // EncodeJSON string
 }
 
-func (o *SchemaOptions) encodeStruct(a *structpb.Struct) (map[string]interface{}, error) {
+func (o *MarshalOptions) encodeStruct(a *structpb.Struct) (map[string]interface{}, error) {
 	data, err := protojson.Marshal(a)
 	if err != nil {
 		return nil, fmt.Errorf("google.protobuf.Struct: marshal: %w", err)
@@ -346,7 +477,7 @@ func (o *SchemaOptions) encodeStruct(a *structpb.Struct) (map[string]interface{}
 	return o.unionValue("string", string(data)), nil
 }
 
-func decodeStruct(v map[string]interface{}) (*structpb.Struct, error) {
+func (d *decoder) decodeStruct(v map[string]interface{}) (*structpb.Struct, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -354,6 +485,11 @@ func decodeStruct(v map[string]interface{}) (*structpb.Struct, error) {
 	if err != nil {
 		return nil, fmt.Errorf("google.protobuf.Struct: %w", err)
 	}
+	if d.opts.StructNumberAsString {
+		return decodeStructNumberAsString(str)
+	}
+	// Struct values have no type information, so values such as strings that look like
+	// timestamps are decoded as plain JSON and never interpreted as other well-known types.
 	var strct structpb.Struct
 	if err := protojson.Unmarshal([]byte(str), &strct); err != nil {
 		return nil, fmt.Errorf("google.protobuf.Struct: unmarshal: %w", err)
@@ -361,11 +497,52 @@ func decodeStruct(v map[string]interface{}) (*structpb.Struct, error) {
 	return &strct, nil
 }
 
+// decodeStructNumberAsString decodes the JSON object str as a Struct where integral numbers,
+// written without a fraction or exponent, are string values.
+func decodeStructNumberAsString(str string) (*structpb.Struct, error) {
+	decoder := json.NewDecoder(strings.NewReader(str))
+	decoder.UseNumber()
+	var fields map[string]interface{}
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, fmt.Errorf("google.protobuf.Struct: unmarshal: %w", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("google.protobuf.Struct: unmarshal: unexpected data after object")
+	}
+	strct, err := structpb.NewStruct(structNumbersAsString(fields).(map[string]interface{}))
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Struct: %w", err)
+	}
+	return strct, nil
+}
+
+// structNumbersAsString replaces the numbers in v with values accepted by structpb.NewValue:
+// integral numbers with their literal strings and other numbers with their float64 values.
+func structNumbersAsString(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if !strings.ContainsAny(string(v), ".eE") {
+			return string(v)
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = structNumbersAsString(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = structNumbersAsString(value)
+		}
+	}
+	return v
+}
+
 func schemaTimeOfDay() avro.Schema {
 	return avro.Nullable(avro.TimeMicros())
 }
 
-func (o *SchemaOptions) encodeTimeOfDay(t *timeofday.TimeOfDay) map[string]interface{} {
+func (o *MarshalOptions) encodeTimeOfDay(t *timeofday.TimeOfDay) map[string]interface{} {
 	d := time.Hour*time.Duration(t.Hours) +
 		time.Minute*time.Duration(t.Minutes) +
 		time.Second*time.Duration(t.Seconds) +
@@ -408,48 +585,262 @@ func schemaDuration() avro.Schema {
 	return avro.Nullable(avro.Float())
 }
 
-func (o *SchemaOptions) encodeDuration(dur *durationpb.Duration) map[string]interface{} {
-	return o.unionValue("float", dur.AsDuration().Seconds())
+// maxDurationSeconds is the largest number of seconds allowed in a google.protobuf.Duration,
+// which is approximately 10000 years.
+const maxDurationSeconds = 315576000000
+
+func (o *MarshalOptions) encodeDuration(dur *durationpb.Duration) map[string]interface{} {
+	// durations are not converted to time.Duration, which saturates at approximately 292 years.
+	return o.unionValue("float", float64(dur.GetSeconds())+float64(dur.GetNanos())/1e9)
 }
 
-func decodeDuration(v map[string]interface{}) (*durationpb.Duration, error) {
+func (d *decoder) decodeDuration(v map[string]interface{}) (*durationpb.Duration, error) {
+	if record, ok := secondsNanosRecord(v); ok {
+		return d.decodeDurationRecord(record)
+	}
 	seconds, err := decodeFloatLike(v, "float")
 	if err != nil {
 		return nil, fmt.Errorf("google.protobuf.Duration: %w", err)
 	}
-	// prevent downcasting float64 to int64 when passing to time.Duration
-	micros := seconds / time.Microsecond.Seconds()
-	return durationpb.New(time.Microsecond * time.Duration(micros)), nil
+	if math.IsNaN(seconds) || math.Abs(seconds) > maxDurationSeconds {
+		return nil, fmt.Errorf("google.protobuf.Duration: %v seconds out of range", seconds)
+	}
+	// split into whole seconds and micros, since nanos of large durations overflow int64.
+	whole, frac := math.Modf(seconds)
+	micros := int64(frac / time.Microsecond.Seconds())
+	return &durationpb.Duration{
+		Seconds: int64(whole),
+		Nanos:   int32(micros * int64(time.Microsecond)),
+	}, nil
 }
 
-func schemaTimestamp() avro.Schema {
+// schemaTimestamp returns the schema of timestamps, which are encoded as microseconds, or milliseconds
+// with TimestampMillis, since the epoch. The longs sort in time order, also before the epoch, so that
+// records can be sorted by timestamp fields. The TimestampDual record does not sort in time order.
+func (o SchemaOptions) schemaTimestamp() avro.Schema {
+	if o.TimestampDual {
+		return schemaDualTimestamp()
+	}
+	if !o.TimestampEpoch.IsZero() {
+		// the logical types are relative to the Unix epoch, so offsets from other epochs are plain longs.
+		return avro.Nullable(avro.Long())
+	}
+	if o.TimestampMillis {
+		return avro.Nullable(avro.TimestampMillis())
+	}
 	return avro.Nullable(avro.TimestampMicros())
 }
 
-func (o *SchemaOptions) encodeTimestamp(t *timestamppb.Timestamp) map[string]interface{} {
-	return o.unionValue("long.timestamp-micros", t.AsTime().UnixNano()/1e3)
+// Bounds of valid google.protobuf.Timestamp values, 0001-01-01T00:00:00Z and 9999-12-31T23:59:59.999999999Z.
+const (
+	minTimestampSeconds = -62135596800
+	maxTimestampSeconds = 253402300799
+	maxTimestampNanos   = 999999999
+)
+
+func (o *MarshalOptions) encodeTimestamp(t *timestamppb.Timestamp) (map[string]interface{}, error) {
+	seconds, nanos := t.GetSeconds(), int64(t.GetNanos())
+	var clamped bool
+	if err := t.CheckValid(); err != nil {
+		if !o.ClampTimestampToRange {
+			return nil, fmt.Errorf("google.protobuf.Timestamp: %w", err)
+		}
+		seconds, nanos = clampTimestamp(seconds, nanos)
+		clamped = true
+	}
+	if o.TimestampDual {
+		return o.encodeDualTimestamp(seconds, nanos), nil
+	}
+	millisKey, microsKey := "long.timestamp-millis", "long.timestamp-micros"
+	if !o.TimestampEpoch.IsZero() {
+		seconds, nanos = epochOffset(seconds, nanos, o.TimestampEpoch)
+		millisKey, microsKey = string(avro.LongType), string(avro.LongType)
+	}
+	if o.TimestampMillis {
+		if nanos%1e6 != 0 && !o.TruncateTimestampsToMillis && !clamped {
+			return nil, fmt.Errorf("google.protobuf.Timestamp: sub-millisecond precision of %d nanos would be lost", nanos)
+		}
+		return o.unionValue(millisKey, seconds*1e3+nanos/1e6), nil
+	}
+	// micros are computed from seconds, since nanos since the epoch overflow int64 outside 1678-2262.
+	// nanos of valid timestamps are never negative, so sub-microsecond precision is truncated towards
+	// earlier times, also before the epoch, and encoded timestamps sort correctly as signed longs.
+	return o.unionValue(microsKey, seconds*1e6+nanos/1e3), nil
+}
+
+// clampTimestamp returns the valid timestamp closest to seconds and nanos.
+func clampTimestamp(seconds, nanos int64) (int64, int64) {
+	switch {
+	case seconds < minTimestampSeconds:
+		return minTimestampSeconds, 0
+	case seconds > maxTimestampSeconds:
+		return maxTimestampSeconds, maxTimestampNanos
+	case nanos < 0:
+		return seconds, 0
+	case nanos > maxTimestampNanos:
+		return seconds, maxTimestampNanos
+	}
+	return seconds, nanos
 }
 
-func decodeTimestamp(v map[string]interface{}) (*timestamppb.Timestamp, error) {
+func (d *decoder) decodeTimestamp(v map[string]interface{}) (*timestamppb.Timestamp, error) {
+	if d.opts.TimestampDual {
+		if record, ok := dualTimestampRecord(v); ok {
+			return d.decodeDualTimestamp(record)
+		}
+	}
+	if record, ok := secondsNanosRecord(v); ok {
+		return d.decodeTimestampRecord(record)
+	}
+	if d.opts.TimestampInput == TimestampInputFloatSeconds {
+		if seconds, ok := floatSeconds(v); ok {
+			return d.decodeFloatSeconds(seconds)
+		}
+	}
+	if s, ok := isoTimestamp(v); ok {
+		return d.decodeISOTimestamp(s)
+	}
+	if !d.opts.TimestampEpoch.IsZero() {
+		return d.decodeEpochTimestamp(v)
+	}
 	if tm, ok := tryDecodeTime(v, "long.timestamp-micros"); ok {
 		return timestamppb.New(tm), nil
 	}
+	if tm, ok := tryDecodeTime(v, "long.timestamp-millis"); ok {
+		return timestamppb.New(tm), nil
+	}
+	if d.opts.TimestampMillis {
+		millis, err := decodeInt(v, "long.timestamp-millis")
+		if err != nil {
+			return nil, fmt.Errorf("google.protobuf.Timestamp: %w", err)
+		}
+		return timestamppb.New(time.Unix(millis/1e3, (millis%1e3)*1e6)), nil
+	}
 	micros, err := decodeInt(v, "long.timestamp-micros")
 	if err != nil {
 		return nil, fmt.Errorf("google.protobuf.Timestamp: %w", err)
 	}
-	t := time.Unix(0, 0).Add(time.Microsecond * time.Duration(micros))
-	return timestamppb.New(t), nil
+	if d.opts.AutoDetectTimestampPrecision {
+		return timestamppb.New(timeFromEpochAutoDetect(micros)), nil
+	}
+	return timestamppb.New(time.Unix(micros/1e6, (micros%1e6)*1e3)), nil
 }
 
-func decodeIntLike(v interface{}, key string) (int64, error) {
-	if i, ok := v.(int); ok {
-		return int64(i), nil
+// secondsNanosRecord returns the record of a timestamp or duration written by legacy schemas
+// as separate seconds and nanos fields, optionally wrapped in a union.
+func secondsNanosRecord(v map[string]interface{}) (map[string]interface{}, bool) {
+	if _, ok := v["seconds"]; ok {
+		return v, true
+	}
+	if len(v) != 1 {
+		return nil, false
+	}
+	for _, value := range v {
+		if record, ok := value.(map[string]interface{}); ok {
+			if _, ok := record["seconds"]; ok {
+				return record, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (d *decoder) decodeTimestampRecord(record map[string]interface{}) (*timestamppb.Timestamp, error) {
+	seconds, err := d.decodeRecordInt(record, "seconds")
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Timestamp: %w", err)
+	}
+	nanos, err := d.decodeRecordInt(record, "nanos")
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Timestamp: %w", err)
+	}
+	if nanos < 0 || nanos > 999_999_999 {
+		return nil, fmt.Errorf("google.protobuf.Timestamp: nanos %d out of range 0-999999999", nanos)
+	}
+	return &timestamppb.Timestamp{Seconds: seconds, Nanos: int32(nanos)}, nil
+}
+
+// decodeDurationRecord decodes a duration written as separate seconds and nanos fields.
+func (d *decoder) decodeDurationRecord(record map[string]interface{}) (*durationpb.Duration, error) {
+	seconds, err := d.decodeRecordInt(record, "seconds")
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Duration: %w", err)
+	}
+	nanos, err := d.decodeRecordInt(record, "nanos")
+	if err != nil {
+		return nil, fmt.Errorf("google.protobuf.Duration: %w", err)
+	}
+	if nanos < -999_999_999 || nanos > 999_999_999 {
+		return nil, fmt.Errorf("google.protobuf.Duration: nanos %d out of range -999999999-999999999", nanos)
+	}
+	if seconds < 0 && nanos > 0 || seconds > 0 && nanos < 0 {
+		return nil, fmt.Errorf("google.protobuf.Duration: seconds %d and nanos %d have different signs", seconds, nanos)
+	}
+	return &durationpb.Duration{Seconds: seconds, Nanos: int32(nanos)}, nil
+}
+
+// decodeRecordInt decodes the int or long record field name, which may be wrapped in a union.
+// Missing and null fields are decoded as zero. With NumericStrings, the field may also be a string,
+// for producers that stringify all numbers.
+func (d *decoder) decodeRecordInt(record map[string]interface{}, name string) (int64, error) {
+	value := record[name]
+	if value == nil {
+		return 0, nil
+	}
+	if union, ok := value.(map[string]interface{}); ok && len(union) == 1 {
+		for _, v := range union {
+			value = v
+		}
+	}
+	if str, ok := value.(string); ok && d.opts.NumericStrings {
+		i, err := strconv.ParseInt(strings.TrimSpace(str), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", name, err)
+		}
+		return i, nil
 	}
+	i, err := decodeIntValue(value)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	return i, nil
+}
+
+// timeFromEpochAutoDetect interprets i as seconds, milliseconds, microseconds or nanoseconds
+// since the Unix epoch, depending on its order of magnitude.
+func timeFromEpochAutoDetect(i int64) time.Time {
+	abs := i
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs < 1e11:
+		return time.Unix(i, 0)
+	case abs < 1e14:
+		return time.Unix(i/1e3, (i%1e3)*1e6)
+	case abs < 1e17:
+		return time.Unix(i/1e6, (i%1e6)*1e3)
+	default:
+		return time.Unix(0, i)
+	}
+}
+
+func decodeIntLike(v interface{}, key string) (int64, error) {
 	if m, ok := v.(map[string]interface{}); ok {
 		return decodeInt(m, key)
 	}
-	return 0, fmt.Errorf("expected int-like, got %v", v)
+	return decodeIntValue(v)
+}
+
+// decodeLongLike decodes a long value, promoting values of int union branches
+// written by schemas where the field was an int.
+func decodeLongLike(v interface{}) (int64, error) {
+	if m, ok := v.(map[string]interface{}); ok {
+		if _, ok := m[string(avro.IntType)]; ok && len(m) == 1 {
+			return decodeInt(m, string(avro.IntType))
+		}
+	}
+	return decodeIntLike(v, string(avro.LongType))
 }
 
 func decodeInt(v map[string]interface{}, key string) (int64, error) {
@@ -457,18 +848,45 @@ func decodeInt(v map[string]interface{}, key string) (int64, error) {
 	if !ok {
 		return 0, fmt.Errorf("expected key '%s'", key)
 	}
-	switch i := maybeInt.(type) {
+	return decodeIntValue(maybeInt)
+}
+
+func decodeIntValue(v interface{}) (int64, error) {
+	switch i := v.(type) {
 	case int:
 		return int64(i), nil
 	case int32:
 		return int64(i), nil
 	case int64:
 		return i, nil
+	case float64:
+		return decodeIntegralFloat(i)
+	case json.Number:
+		// JSON producers may write integers in scientific notation, such as 1e3.
+		if n, err := i.Int64(); err == nil {
+			return n, nil
+		}
+		f, err := i.Float64()
+		if err != nil {
+			return 0, fmt.Errorf("expected int-like, got %s", i)
+		}
+		return decodeIntegralFloat(f)
 	default:
-		return 0, fmt.Errorf("expected int-like, got %T", maybeInt)
+		return 0, fmt.Errorf("expected int-like, got %T", v)
 	}
 }
 
+// decodeIntegralFloat returns f as an integer, if it has no fractional part and fits in an int64.
+func decodeIntegralFloat(f float64) (int64, error) {
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("expected int-like, got non-integer %v", f)
+	}
+	if f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, fmt.Errorf("expected int-like, got out of range %v", f)
+	}
+	return int64(f), nil
+}
+
 func tryDecodeTime(v map[string]interface{}, key string) (time.Time, bool) {
 	maybeTime, ok := v[key]
 	if !ok {
@@ -491,6 +909,15 @@ func tryDecodeDuration(v map[string]interface{}, key string) (time.Duration, boo
 	return time.Duration(0), false
 }
 
+// decodeDoubleLike decodes a union wrapped double value, promoting values of float union branches
+// written by schemas where the field was a float.
+func decodeDoubleLike(v map[string]interface{}) (float64, error) {
+	if _, ok := v[string(avro.FloatType)]; ok && len(v) == 1 {
+		return decodeFloatLike(v, string(avro.FloatType))
+	}
+	return decodeFloatLike(v, string(avro.DoubleType))
+}
+
 func decodeFloatLike(v map[string]interface{}, key string) (float64, error) {
 	maybeFloat, ok := v[key]
 	if !ok {
@@ -530,11 +957,13 @@ func decodeString(v map[string]interface{}, key string) (string, error) {
 }
 
 func decodeBytesLike(v interface{}, key string) ([]byte, error) {
-	if bs, ok := v.([]byte); ok {
-		return bs, nil
-	}
-	if m, ok := v.(map[string]interface{}); ok {
-		return decodeBytes(m, key)
+	switch v := v.(type) {
+	case []byte:
+		return nonNilBytes(v), nil
+	case string:
+		return decodeJSONBytes(v)
+	case map[string]interface{}:
+		return decodeBytes(v, key)
 	}
 	return nil, fmt.Errorf("expected bytes-like, got %v", v)
 }
@@ -546,7 +975,9 @@ func decodeBytes(v map[string]interface{}, key string) ([]byte, error) {
 	}
 	switch b := maybeByte.(type) {
 	case []byte:
-		return b, nil
+		return nonNilBytes(b), nil
+	case string:
+		return decodeJSONBytes(b)
 	default:
 		return nil, fmt.Errorf("expected []byte, got %T", maybeByte)
 	}
@@ -597,3 +1028,31 @@ func decodeList(v map[string]interface{}, key string) ([]interface{}, error) {
 		return nil, fmt.Errorf("expected list, got %T", maybeList)
 	}
 }
+
+// withLogicalTypeProps returns schema with the LogicalTypeProps of the logical types it uses.
+func (o SchemaOptions) withLogicalTypeProps(schema avro.Schema) avro.Schema {
+	if len(o.LogicalTypeProps) == 0 {
+		return schema
+	}
+	switch schema := schema.(type) {
+	case avro.Primitive:
+		if props, ok := o.LogicalTypeProps[string(schema.LogicalType)]; ok && schema.LogicalType != "" {
+			return avro.AnnotatedPrimitive{Primitive: schema, Props: props}
+		}
+	case avro.Union:
+		union := make(avro.Union, 0, len(schema))
+		for _, member := range schema {
+			union = append(union, o.withLogicalTypeProps(member))
+		}
+		return union
+	case avro.Record:
+		fields := make([]avro.Field, 0, len(schema.Fields))
+		for _, field := range schema.Fields {
+			field.Type = o.withLogicalTypeProps(field.Type)
+			fields = append(fields, field)
+		}
+		schema.Fields = fields
+		return schema
+	}
+	return schema
+}
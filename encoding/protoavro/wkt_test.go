@@ -1,13 +1,23 @@
 package protoavro
 
 import (
+	"encoding/json"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+	examplev1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/avro/example/v1"
+	publicv1 "go.einride.tech/protobuf-avro/internal/examples/proto/gen/einride/bigquery/public/v1"
 	"google.golang.org/genproto/googleapis/example/library/v1"
 	"google.golang.org/genproto/googleapis/type/date"
 	"google.golang.org/genproto/googleapis/type/timeofday"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/durationpb"
@@ -63,16 +73,278 @@ func Test_WKT(t *testing.T) {
 	} {
 		tt := tt
 		t.Run(string(tt.ProtoReflect().Descriptor().FullName()), func(t *testing.T) {
-			encoded, err := SchemaOptions{}.encodeWKT(tt.ProtoReflect())
+			encoded, err := MarshalOptions{}.encodeWKT(tt.ProtoReflect())
 			assert.NilError(t, err)
 			t.Log(encoded)
 			decoded := tt.ProtoReflect().New()
-			assert.NilError(t, decodeWKT(encoded, decoded))
+			assert.NilError(t, UnmarshalOptions{}.newDecoder().decodeWKT(encoded, decoded))
 			assert.DeepEqual(t, tt, decoded.Interface(), protocmp.Transform())
 		})
 	}
 }
 
+func Test_WKT_SpecialFloats(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		msg  proto.Message
+	}{
+		{name: "float NaN", msg: wrapperspb.Float(float32(math.NaN()))},
+		{name: "float -Inf", msg: wrapperspb.Float(float32(math.Inf(-1)))},
+		{name: "double +Inf", msg: wrapperspb.Double(math.Inf(1))},
+		{name: "double NaN", msg: wrapperspb.Double(math.NaN())},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := MarshalOptions{}.encodeWKT(tt.msg.ProtoReflect())
+			assert.NilError(t, err)
+			decoded := tt.msg.ProtoReflect().New()
+			assert.NilError(t, UnmarshalOptions{}.newDecoder().decodeWKT(encoded, decoded))
+			assert.DeepEqual(t, tt.msg, decoded.Interface(), protocmp.Transform(), cmpopts.EquateNaNs())
+		})
+	}
+}
+
+func Test_WKT_DurationBounds(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		duration *durationpb.Duration
+		expected float64
+	}{
+		{
+			name:     "max",
+			duration: &durationpb.Duration{Seconds: maxDurationSeconds},
+			expected: 315576000000,
+		},
+		{
+			name:     "min",
+			duration: &durationpb.Duration{Seconds: -maxDurationSeconds},
+			expected: -315576000000,
+		},
+		{
+			name:     "beyond time.Duration",
+			duration: &durationpb.Duration{Seconds: 300 * 365 * 24 * 60 * 60, Nanos: 500000000},
+			expected: 9460800000.5,
+		},
+		{
+			name:     "negative fraction",
+			duration: &durationpb.Duration{Seconds: -1, Nanos: -250000000},
+			expected: -1.25,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := MarshalOptions{}.encodeWKT(tt.duration.ProtoReflect())
+			assert.NilError(t, err)
+			assert.DeepEqual(t, map[string]interface{}{"float": tt.expected}, encoded)
+			decoded := &durationpb.Duration{}
+			assert.NilError(t, UnmarshalOptions{}.newDecoder().decodeWKT(encoded, decoded.ProtoReflect()))
+			assert.DeepEqual(t, tt.duration, decoded, protocmp.Transform())
+		})
+	}
+}
+
+func Test_WKT_TimestampBounds(t *testing.T) {
+	const (
+		maxMicros = int64(253402300799999999)
+		minMicros = int64(-62135596800000000)
+	)
+	for _, tt := range []struct {
+		name        string
+		opts        MarshalOptions
+		timestamp   *timestamppb.Timestamp
+		expected    int64
+		decoded     *timestamppb.Timestamp
+		errContains string
+	}{
+		{
+			name:      "max",
+			timestamp: &timestamppb.Timestamp{Seconds: 253402300799, Nanos: 999999999},
+			expected:  maxMicros,
+			decoded:   &timestamppb.Timestamp{Seconds: 253402300799, Nanos: 999999000},
+		},
+		{
+			name:      "min",
+			timestamp: &timestamppb.Timestamp{Seconds: -62135596800},
+			expected:  minMicros,
+			decoded:   &timestamppb.Timestamp{Seconds: -62135596800},
+		},
+		{
+			name:        "above max",
+			timestamp:   &timestamppb.Timestamp{Seconds: 253402300800},
+			errContains: "after 9999-12-31",
+		},
+		{
+			name:      "above max clamped",
+			opts:      MarshalOptions{ClampTimestampToRange: true},
+			timestamp: &timestamppb.Timestamp{Seconds: math.MaxInt64},
+			expected:  maxMicros,
+			decoded:   &timestamppb.Timestamp{Seconds: 253402300799, Nanos: 999999000},
+		},
+		{
+			name:        "below min",
+			timestamp:   &timestamppb.Timestamp{Seconds: -62135596801},
+			errContains: "before 0001-01-01",
+		},
+		{
+			name:      "below min clamped",
+			opts:      MarshalOptions{ClampTimestampToRange: true},
+			timestamp: &timestamppb.Timestamp{Seconds: math.MinInt64},
+			expected:  minMicros,
+			decoded:   &timestamppb.Timestamp{Seconds: -62135596800},
+		},
+		{
+			name:      "negative nanos clamped",
+			opts:      MarshalOptions{ClampTimestampToRange: true},
+			timestamp: &timestamppb.Timestamp{Seconds: 1, Nanos: -1},
+			expected:  1000000,
+			decoded:   &timestamppb.Timestamp{Seconds: 1},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.opts.encodeWKT(tt.timestamp.ProtoReflect())
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, map[string]interface{}{"long.timestamp-micros": tt.expected}, encoded)
+			decoded := &timestamppb.Timestamp{}
+			assert.NilError(t, UnmarshalOptions{}.newDecoder().decodeWKT(encoded, decoded.ProtoReflect()))
+			assert.DeepEqual(t, tt.decoded, decoded, protocmp.Transform())
+		})
+	}
+}
+
+func Test_WKT_TimestampMillis(t *testing.T) {
+	millis := SchemaOptions{TimestampMillis: true}
+	for _, tt := range []struct {
+		name        string
+		opts        MarshalOptions
+		timestamp   *timestamppb.Timestamp
+		expected    int64
+		decoded     *timestamppb.Timestamp
+		errContains string
+	}{
+		{
+			name:      "millisecond precision",
+			opts:      MarshalOptions{SchemaOptions: millis},
+			timestamp: &timestamppb.Timestamp{Seconds: 1700000000, Nanos: 123000000},
+			expected:  1700000000123,
+			decoded:   &timestamppb.Timestamp{Seconds: 1700000000, Nanos: 123000000},
+		},
+		{
+			name:      "before epoch",
+			opts:      MarshalOptions{SchemaOptions: millis},
+			timestamp: &timestamppb.Timestamp{Seconds: -2, Nanos: 500000000},
+			expected:  -1500,
+			decoded:   &timestamppb.Timestamp{Seconds: -2, Nanos: 500000000},
+		},
+		{
+			name:        "sub-millisecond precision",
+			opts:        MarshalOptions{SchemaOptions: millis},
+			timestamp:   &timestamppb.Timestamp{Seconds: 1700000000, Nanos: 123456789},
+			errContains: "google.protobuf.Timestamp: sub-millisecond precision of 123456789 nanos would be lost",
+		},
+		{
+			name:      "sub-millisecond precision truncated",
+			opts:      MarshalOptions{SchemaOptions: millis, TruncateTimestampsToMillis: true},
+			timestamp: &timestamppb.Timestamp{Seconds: 1700000000, Nanos: 123456789},
+			expected:  1700000000123,
+			decoded:   &timestamppb.Timestamp{Seconds: 1700000000, Nanos: 123000000},
+		},
+		{
+			name:      "clamped",
+			opts:      MarshalOptions{SchemaOptions: millis, ClampTimestampToRange: true},
+			timestamp: &timestamppb.Timestamp{Seconds: math.MaxInt64},
+			expected:  253402300799999,
+			decoded:   &timestamppb.Timestamp{Seconds: 253402300799, Nanos: 999000000},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			encoded, err := tt.opts.encodeWKT(tt.timestamp.ProtoReflect())
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, map[string]interface{}{"long.timestamp-millis": tt.expected}, encoded)
+			decoded := &timestamppb.Timestamp{}
+			opts := UnmarshalOptions{SchemaOptions: millis}
+			assert.NilError(t, opts.newDecoder().decodeWKT(encoded, decoded.ProtoReflect()))
+			assert.DeepEqual(t, tt.decoded, decoded, protocmp.Transform())
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		msg := &examplev1.ExampleTimestamp{Timestamp: &timestamppb.Timestamp{Seconds: 1700000000, Nanos: 5000000}}
+		schema, err := millis.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		record, ok := schema.(avro.Union)[1].(avro.Record)
+		assert.Assert(t, ok)
+		assert.DeepEqual(t, avro.Nullable(avro.TimestampMillis()), record.Fields[0].Type)
+		assertRoundTrip(t, millis, schema, msg)
+	})
+}
+
+func Test_WKT_TimestampOrder(t *testing.T) {
+	// timestamps in ascending order, around and before the epoch.
+	timestamps := []*timestamppb.Timestamp{
+		{Seconds: -62135596800},
+		{Seconds: -1000, Nanos: 999999999},
+		{Seconds: -2, Nanos: 500000000},
+		{Seconds: -1},
+		{Seconds: -1, Nanos: 1000},
+		{Seconds: -1, Nanos: 999999000},
+		{Seconds: 0},
+		{Seconds: 0, Nanos: 1000},
+		{Seconds: 1},
+		{Seconds: 1700000000, Nanos: 123456000},
+		{Seconds: 253402300799, Nanos: 999999999},
+	}
+	var previous int64
+	for i, timestamp := range timestamps {
+		encoded, err := MarshalOptions{}.encodeWKT(timestamp.ProtoReflect())
+		assert.NilError(t, err)
+		micros := encoded["long.timestamp-micros"].(int64)
+		if i > 0 {
+			assert.Assert(t, previous < micros, "%v encoded as %d, not after %d", timestamp, micros, previous)
+		}
+		previous = micros
+	}
+
+	t.Run("sub-microsecond precision", func(t *testing.T) {
+		for _, tt := range []struct {
+			timestamp *timestamppb.Timestamp
+			expected  int64
+		}{
+			{timestamp: &timestamppb.Timestamp{Seconds: -1, Nanos: 999}, expected: -1000000},
+			{timestamp: &timestamppb.Timestamp{Seconds: -1, Nanos: 1999}, expected: -999999},
+			{timestamp: &timestamppb.Timestamp{Seconds: 0, Nanos: 999}, expected: 0},
+		} {
+			encoded, err := MarshalOptions{}.encodeWKT(tt.timestamp.ProtoReflect())
+			assert.NilError(t, err)
+			assert.DeepEqual(t, map[string]interface{}{"long.timestamp-micros": tt.expected}, encoded)
+		}
+	})
+
+	t.Run("dual", func(t *testing.T) {
+		// the ISO-8601 strings, which records are compared by first, do not sort in time order.
+		opts := MarshalOptions{SchemaOptions: SchemaOptions{TimestampDual: true}}
+		iso := func(timestamp *timestamppb.Timestamp) string {
+			encoded, err := opts.encodeWKT(timestamp.ProtoReflect())
+			assert.NilError(t, err)
+			record := encoded["google.protobuf.Timestamp"].(map[string]interface{})
+			return record["iso"].(map[string]interface{})["string"].(string)
+		}
+		earlier, later := iso(&timestamppb.Timestamp{Seconds: 0}), iso(&timestamppb.Timestamp{Seconds: 0, Nanos: 5e8})
+		assert.Equal(t, "1970-01-01T00:00:00Z", earlier)
+		assert.Equal(t, "1970-01-01T00:00:00.5Z", later)
+		assert.Assert(t, later < earlier)
+	})
+}
+
 func Test_DecodeWKTErr(t *testing.T) {
 	for _, tt := range []struct {
 		name        string
@@ -122,6 +394,22 @@ func Test_DecodeWKTErr(t *testing.T) {
 			},
 			errContains: "google.protobuf.Duration: expected key 'float'",
 		},
+		{
+			name: "duration out of range",
+			msg:  &durationpb.Duration{},
+			data: map[string]interface{}{
+				"float": float64(maxDurationSeconds + 1),
+			},
+			errContains: "google.protobuf.Duration: 3.15576000001e+11 seconds out of range",
+		},
+		{
+			name: "duration NaN",
+			msg:  &durationpb.Duration{},
+			data: map[string]interface{}{
+				"float": math.NaN(),
+			},
+			errContains: "google.protobuf.Duration: NaN seconds out of range",
+		},
 		{
 			name: "not timestamp",
 			msg:  &timestamppb.Timestamp{},
@@ -141,8 +429,844 @@ func Test_DecodeWKTErr(t *testing.T) {
 	} {
 		tt := tt
 		t.Run(tt.name, func(t *testing.T) {
-			err := decodeWKT(tt.data, tt.msg.ProtoReflect())
+			err := UnmarshalOptions{}.newDecoder().decodeWKT(tt.data, tt.msg.ProtoReflect())
 			assert.ErrorContains(t, err, tt.errContains)
 		})
 	}
 }
+
+func Test_DecodeTimestamp_AutoDetectPrecision(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		value    int64
+		expected time.Time
+	}{
+		{
+			name:     "seconds",
+			value:    1712345678,
+			expected: time.Date(2024, 4, 5, 19, 34, 38, 0, time.UTC),
+		},
+		{
+			name:     "milliseconds",
+			value:    1712345678123,
+			expected: time.Date(2024, 4, 5, 19, 34, 38, 123000000, time.UTC),
+		},
+		{
+			name:     "microseconds",
+			value:    1712345678123456,
+			expected: time.Date(2024, 4, 5, 19, 34, 38, 123456000, time.UTC),
+		},
+		{
+			name:     "nanoseconds",
+			value:    1712345678123456789,
+			expected: time.Date(2024, 4, 5, 19, 34, 38, 123456789, time.UTC),
+		},
+		{
+			name:     "negative milliseconds",
+			value:    -1712345678123,
+			expected: time.Date(1915, 9, 28, 4, 25, 21, 877000000, time.UTC),
+		},
+		{
+			name:     "largest seconds",
+			value:    1e11 - 1,
+			expected: time.Unix(1e11-1, 0),
+		},
+		{
+			name:     "smallest milliseconds",
+			value:    1e11,
+			expected: time.Unix(1e8, 0),
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := UnmarshalOptions{AutoDetectTimestampPrecision: true}
+			got, err := opts.newDecoder().decodeTimestamp(map[string]interface{}{"long.timestamp-micros": tt.value})
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected.UTC(), got.AsTime())
+		})
+	}
+	t.Run("disabled", func(t *testing.T) {
+		got, err := UnmarshalOptions{}.newDecoder().decodeTimestamp(map[string]interface{}{
+			"long.timestamp-micros": int64(1712345678),
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, time.Date(1970, 1, 1, 0, 28, 32, 345678000, time.UTC), got.AsTime())
+	})
+}
+
+func Test_DecodeTimestamp_FloatSeconds(t *testing.T) {
+	for _, tt := range []struct {
+		name             string
+		data             interface{}
+		expected         *timestamppb.Timestamp
+		expectedWarnings []string
+		errContains      string
+	}{
+		{
+			name:     "float seconds",
+			data:     1712345678.123456,
+			expected: &timestamppb.Timestamp{Seconds: 1712345678, Nanos: 123456000},
+		},
+		{
+			name:     "union wrapped double",
+			data:     map[string]interface{}{"double": 1712345678.5},
+			expected: &timestamppb.Timestamp{Seconds: 1712345678, Nanos: 500000000},
+		},
+		{
+			name:     "JSON number",
+			data:     json.Number("1712345678.123456"),
+			expected: &timestamppb.Timestamp{Seconds: 1712345678, Nanos: 123456000},
+		},
+		{
+			name:     "before epoch",
+			data:     -1.25,
+			expected: &timestamppb.Timestamp{Seconds: -2, Nanos: 750000000},
+		},
+		{
+			name:     "integer micros",
+			data:     map[string]interface{}{"long.timestamp-micros": int64(1712345678123456)},
+			expected: &timestamppb.Timestamp{Seconds: 1712345678, Nanos: 123456000},
+		},
+		{
+			name:     "precision loss",
+			data:     10000000000.000001,
+			expected: &timestamppb.Timestamp{Seconds: 10000000000, Nanos: 2000},
+			expectedWarnings: []string{
+				"timestamp: float seconds 1.0000000000000002e+10 only have a precision of 1.9073486328125e-06 seconds",
+			},
+		},
+		{
+			name:        "out of range",
+			data:        1e12,
+			errContains: "google.protobuf.Timestamp: float seconds 1e+12 out of range",
+		},
+		{
+			name:        "NaN",
+			data:        math.NaN(),
+			errContains: "google.protobuf.Timestamp: float seconds NaN out of range",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var warnings []string
+			opts := UnmarshalOptions{TimestampInput: TimestampInputFloatSeconds, Warnings: &warnings}
+			var got examplev1.ExampleTimestamp
+			err := opts.Unmarshal(map[string]interface{}{"timestamp": tt.data}, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got.GetTimestamp(), protocmp.Transform())
+			assert.DeepEqual(t, tt.expectedWarnings, warnings)
+		})
+	}
+	t.Run("disabled", func(t *testing.T) {
+		var got examplev1.ExampleTimestamp
+		err := UnmarshalOptions{}.Unmarshal(map[string]interface{}{"timestamp": 1712345678.5}, &got)
+		assert.ErrorContains(t, err, "expected message encoded as map[string]interface{}, got float64")
+	})
+}
+
+func Test_DecodeTimestamp_SecondsNanos(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		data        map[string]interface{}
+		expected    *timestamppb.Timestamp
+		errContains string
+	}{
+		{
+			name: "record",
+			data: map[string]interface{}{
+				"seconds": int64(1712345678),
+				"nanos":   int32(123456789),
+			},
+			expected: &timestamppb.Timestamp{Seconds: 1712345678, Nanos: 123456789},
+		},
+		{
+			name: "union wrapped record",
+			data: map[string]interface{}{
+				"legacy.Timestamp": map[string]interface{}{
+					"seconds": map[string]interface{}{"long": int64(1712345678)},
+					"nanos":   map[string]interface{}{"long": int64(5)},
+				},
+			},
+			expected: &timestamppb.Timestamp{Seconds: 1712345678, Nanos: 5},
+		},
+		{
+			name: "null nanos",
+			data: map[string]interface{}{
+				"seconds": int64(-1),
+				"nanos":   nil,
+			},
+			expected: &timestamppb.Timestamp{Seconds: -1},
+		},
+		{
+			name: "largest nanos",
+			data: map[string]interface{}{
+				"seconds": int64(0),
+				"nanos":   int64(999999999),
+			},
+			expected: &timestamppb.Timestamp{Nanos: 999999999},
+		},
+		{
+			name: "nanos too large",
+			data: map[string]interface{}{
+				"seconds": int64(0),
+				"nanos":   int64(1000000000),
+			},
+			errContains: "nanos 1000000000 out of range 0-999999999",
+		},
+		{
+			name: "negative nanos",
+			data: map[string]interface{}{
+				"seconds": int64(0),
+				"nanos":   int32(-1),
+			},
+			errContains: "nanos -1 out of range 0-999999999",
+		},
+		{
+			name: "invalid seconds",
+			data: map[string]interface{}{
+				"seconds": "0",
+			},
+			errContains: "seconds: expected int-like, got string",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UnmarshalOptions{}.newDecoder().decodeTimestamp(tt.data)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got, protocmp.Transform())
+		})
+	}
+}
+
+func Test_DecodeWKT_NumericStringSecondsNanos(t *testing.T) {
+	opts := UnmarshalOptions{NumericStrings: true}
+	t.Run("timestamp", func(t *testing.T) {
+		got, err := opts.newDecoder().decodeTimestamp(map[string]interface{}{
+			"seconds": "1712345678",
+			"nanos":   map[string]interface{}{"string": " 5 "},
+		})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, &timestamppb.Timestamp{Seconds: 1712345678, Nanos: 5}, got, protocmp.Transform())
+	})
+	for _, tt := range []struct {
+		name        string
+		data        map[string]interface{}
+		expected    *durationpb.Duration
+		errContains string
+	}{
+		{
+			name:     "duration",
+			data:     map[string]interface{}{"seconds": "90", "nanos": "500"},
+			expected: &durationpb.Duration{Seconds: 90, Nanos: 500},
+		},
+		{
+			name:     "negative duration",
+			data:     map[string]interface{}{"seconds": "-1", "nanos": int64(-5)},
+			expected: &durationpb.Duration{Seconds: -1, Nanos: -5},
+		},
+		{
+			name:        "different signs",
+			data:        map[string]interface{}{"seconds": "1", "nanos": "-5"},
+			errContains: "seconds 1 and nanos -5 have different signs",
+		},
+		{
+			name:        "not a number",
+			data:        map[string]interface{}{"seconds": "a"},
+			errContains: "google.protobuf.Duration: seconds: strconv.ParseInt",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := opts.newDecoder().decodeDuration(tt.data)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got, protocmp.Transform())
+		})
+	}
+	t.Run("message", func(t *testing.T) {
+		var got examplev1.ExampleDuration
+		assert.NilError(t, opts.Unmarshal(map[string]interface{}{
+			"duration": map[string]interface{}{"seconds": "90", "nanos": "0"},
+		}, &got))
+		assert.DeepEqual(t, durationpb.New(90*time.Second), got.Duration, protocmp.Transform())
+	})
+	t.Run("without numeric strings", func(t *testing.T) {
+		_, err := UnmarshalOptions{}.newDecoder().decodeDuration(map[string]interface{}{"seconds": "90"})
+		assert.ErrorContains(t, err, "seconds: expected int-like, got string")
+	})
+}
+
+func Test_DecodeIntLike_ScientificNotation(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		value       interface{}
+		expected    int64
+		errContains string
+	}{
+		{name: "json number exponent", value: json.Number("1e3"), expected: 1000},
+		{name: "json number fraction and exponent", value: json.Number("1.0e2"), expected: 100},
+		{name: "json number negative exponent", value: json.Number("-2.5e1"), expected: -25},
+		{name: "json number integer", value: json.Number("9007199254740993"), expected: 9007199254740993},
+		{name: "float", value: 1e3, expected: 1000},
+		{name: "union wrapped", value: map[string]interface{}{"long": json.Number("1e3")}, expected: 1000},
+		{
+			name:        "json number fractional",
+			value:       json.Number("1.5e0"),
+			errContains: "expected int-like, got non-integer 1.5",
+		},
+		{
+			name:        "float fractional",
+			value:       1.5,
+			errContains: "expected int-like, got non-integer 1.5",
+		},
+		{
+			name:        "out of range",
+			value:       json.Number("1e19"),
+			errContains: "expected int-like, got out of range 1e+19",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeIntLike(tt.value, "long")
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func Test_DecodeStruct_TimestampLikeString(t *testing.T) {
+	const timestamp = "2024-04-05T19:34:38Z"
+	expected, err := structpb.NewStruct(map[string]interface{}{
+		"created_time": timestamp,
+		"nested": map[string]interface{}{
+			"update_time": timestamp,
+			"duration":    "3.5s",
+		},
+		"list": []interface{}{timestamp},
+	})
+	assert.NilError(t, err)
+	encoded, err := MarshalOptions{}.encodeWKT(expected.ProtoReflect())
+	assert.NilError(t, err)
+	got := &structpb.Struct{}
+	assert.NilError(t, UnmarshalOptions{}.newDecoder().decodeWKT(encoded, got.ProtoReflect()))
+	assert.DeepEqual(t, expected, got, protocmp.Transform())
+	assert.Equal(t, got.GetFields()["created_time"].GetStringValue(), timestamp)
+	assert.Equal(t, got.GetFields()["nested"].GetStructValue().GetFields()["update_time"].GetStringValue(), timestamp)
+	assert.Equal(t, got.GetFields()["list"].GetListValue().GetValues()[0].GetStringValue(), timestamp)
+}
+
+func Test_DecodeTimestamp_ISOString(t *testing.T) {
+	cet := time.FixedZone("CET", 60*60)
+	for _, tt := range []struct {
+		name        string
+		zone        *time.Location
+		data        interface{}
+		expected    *timestamppb.Timestamp
+		errContains string
+	}{
+		{
+			name:     "zone-less in UTC",
+			data:     "2024-01-02T03:04:05",
+			expected: timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		},
+		{
+			name:     "zone-less with fraction",
+			data:     map[string]interface{}{"string": "2024-01-02T03:04:05.123456"},
+			expected: timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC)),
+		},
+		{
+			name:     "zone-less in default time zone",
+			zone:     cet,
+			data:     "2024-01-02T03:04:05",
+			expected: timestamppb.New(time.Date(2024, 1, 2, 2, 4, 5, 0, time.UTC)),
+		},
+		{
+			name:     "explicit zone takes precedence",
+			zone:     cet,
+			data:     "2024-01-02T03:04:05Z",
+			expected: timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		},
+		{
+			name:        "invalid",
+			data:        "2024-01-02",
+			errContains: "google.protobuf.Timestamp: parsing time",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := UnmarshalOptions{DefaultTimeZone: tt.zone}
+			var got examplev1.ExampleTimestamp
+			err := opts.Unmarshal(map[string]interface{}{"timestamp": tt.data}, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got.GetTimestamp(), protocmp.Transform())
+		})
+	}
+}
+
+func Test_DecodeTimestamp_Layouts(t *testing.T) {
+	cet := time.FixedZone("CET", 60*60)
+	layouts := []string{"2006-01-02 15:04:05", "02/01/2006 15:04 -0700"}
+	for _, tt := range []struct {
+		name        string
+		zone        *time.Location
+		data        interface{}
+		expected    *timestamppb.Timestamp
+		errContains string
+	}{
+		{
+			name:     "first layout",
+			data:     map[string]interface{}{"string": "2024-01-02 03:04:05"},
+			expected: timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		},
+		{
+			name:     "first layout in default time zone",
+			zone:     cet,
+			data:     "2024-01-02 03:04:05",
+			expected: timestamppb.New(time.Date(2024, 1, 2, 2, 4, 5, 0, time.UTC)),
+		},
+		{
+			name:     "second layout with zone",
+			zone:     cet,
+			data:     "02/01/2024 03:04 +0200",
+			expected: timestamppb.New(time.Date(2024, 1, 2, 1, 4, 0, 0, time.UTC)),
+		},
+		{
+			name:     "ISO-8601 takes precedence",
+			data:     "2024-01-02T03:04:05Z",
+			expected: timestamppb.New(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+		},
+		{
+			name:        "unparseable",
+			data:        "Jan 2 2024",
+			errContains: `google.protobuf.Timestamp: "Jan 2 2024" matches neither RFC 3339 nor TimestampLayouts`,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := UnmarshalOptions{DefaultTimeZone: tt.zone, TimestampLayouts: layouts}
+			var got examplev1.ExampleTimestamp
+			err := opts.Unmarshal(map[string]interface{}{"timestamp": tt.data}, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got.GetTimestamp(), protocmp.Transform())
+		})
+	}
+}
+
+func Test_DecodeStruct_NumberAsString(t *testing.T) {
+	encoded := map[string]interface{}{
+		"string": `{"count":1,"ratio":1.5,"whole":1.0,"big":12345678901234567890,"list":[2,2.5],"nested":{"n":-3}}`,
+	}
+	t.Run("default", func(t *testing.T) {
+		got := &structpb.Struct{}
+		assert.NilError(t, UnmarshalOptions{}.newDecoder().decodeWKT(encoded, got.ProtoReflect()))
+		assert.Equal(t, 1.0, got.GetFields()["count"].GetNumberValue())
+		assert.Equal(t, 1.5, got.GetFields()["ratio"].GetNumberValue())
+	})
+	t.Run("number as string", func(t *testing.T) {
+		got := &structpb.Struct{}
+		opts := UnmarshalOptions{StructNumberAsString: true}
+		assert.NilError(t, opts.newDecoder().decodeWKT(encoded, got.ProtoReflect()))
+		expected, err := structpb.NewStruct(map[string]interface{}{
+			"count":  "1",
+			"ratio":  1.5,
+			"whole":  1.0,
+			"big":    "12345678901234567890",
+			"list":   []interface{}{"2", 2.5},
+			"nested": map[string]interface{}{"n": "-3"},
+		})
+		assert.NilError(t, err)
+		assert.DeepEqual(t, expected, got, protocmp.Transform())
+	})
+	t.Run("trailing data", func(t *testing.T) {
+		got := &structpb.Struct{}
+		opts := UnmarshalOptions{StructNumberAsString: true}
+		err := opts.newDecoder().decodeWKT(map[string]interface{}{"string": `{"a":1} {}`}, got.ProtoReflect())
+		assert.ErrorContains(t, err, "google.protobuf.Struct: unmarshal: unexpected data after object")
+	})
+}
+
+func Test_WKT_TimestampDual(t *testing.T) {
+	dual := SchemaOptions{TimestampDual: true}
+	t.Run("round trip", func(t *testing.T) {
+		msg := &examplev1.ExampleTimestamp{Timestamp: &timestamppb.Timestamp{Seconds: 1700000000, Nanos: 5000}}
+		schema, err := dual.InferSchema(msg.ProtoReflect().Descriptor())
+		assert.NilError(t, err)
+		record, ok := schema.(avro.Union)[1].(avro.Record)
+		assert.Assert(t, ok)
+		assert.DeepEqual(t, schemaDualTimestamp(), record.Fields[0].Type)
+		assertRoundTrip(t, dual, schema, msg)
+	})
+
+	for _, tt := range []struct {
+		name        string
+		data        interface{}
+		expected    *timestamppb.Timestamp
+		errContains string
+	}{
+		{
+			name: "iso only",
+			data: map[string]interface{}{
+				"google.protobuf.Timestamp": map[string]interface{}{
+					"iso":    map[string]interface{}{"string": "2024-01-02T03:04:05.123456789Z"},
+					"micros": nil,
+				},
+			},
+			expected: &timestamppb.Timestamp{Seconds: 1704164645, Nanos: 123456789},
+		},
+		{
+			name: "micros only",
+			data: map[string]interface{}{
+				"google.protobuf.Timestamp": map[string]interface{}{
+					"iso":    nil,
+					"micros": map[string]interface{}{"long.timestamp-micros": int64(1704164645123456)},
+				},
+			},
+			expected: &timestamppb.Timestamp{Seconds: 1704164645, Nanos: 123456000},
+		},
+		{
+			name: "micros preferred",
+			data: map[string]interface{}{
+				"iso":    "2000-01-01T00:00:00Z",
+				"micros": time.Unix(1704164645, 0),
+			},
+			expected: &timestamppb.Timestamp{Seconds: 1704164645},
+		},
+		{
+			name: "neither",
+			data: map[string]interface{}{
+				"google.protobuf.Timestamp": map[string]interface{}{"iso": nil, "micros": nil},
+			},
+			errContains: "google.protobuf.Timestamp: neither iso nor micros is set",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			opts := UnmarshalOptions{SchemaOptions: dual}
+			var got examplev1.ExampleTimestamp
+			err := opts.Unmarshal(map[string]interface{}{"timestamp": tt.data}, &got)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got.GetTimestamp(), protocmp.Transform())
+		})
+	}
+}
+
+func Test_WKT_TimestampEpochVersusUnset(t *testing.T) {
+	schema, err := InferSchema((&examplev1.ExampleTimestamp{}).ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	codec, err := goavro.NewCodec(string(schemaBytes))
+	assert.NilError(t, err)
+	for _, tt := range []struct {
+		name     string
+		msg      *examplev1.ExampleTimestamp
+		expected interface{}
+	}{
+		{
+			name:     "epoch",
+			msg:      &examplev1.ExampleTimestamp{Timestamp: &timestamppb.Timestamp{}},
+			expected: map[string]interface{}{"long.timestamp-micros": int64(0)},
+		},
+		{
+			name: "unset",
+			msg:  &examplev1.ExampleTimestamp{},
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			for _, opts := range []MarshalOptions{{}, {OmitEmptyMessages: true}} {
+				encoded, err := opts.Encode(tt.msg)
+				assert.NilError(t, err)
+				record := encoded.(map[string]interface{})["einride.avro.example.v1.ExampleTimestamp"]
+				assert.DeepEqual(t, tt.expected, record.(map[string]interface{})["timestamp"])
+				binary, err := codec.BinaryFromNative(nil, encoded)
+				assert.NilError(t, err)
+				native, _, err := codec.NativeFromBinary(binary)
+				assert.NilError(t, err)
+				var got examplev1.ExampleTimestamp
+				assert.NilError(t, UnmarshalOptions{}.Unmarshal(native, &got))
+				assert.Equal(t, tt.msg.Timestamp != nil, got.Timestamp != nil)
+				assert.DeepEqual(t, tt.msg, &got, protocmp.Transform())
+			}
+		})
+	}
+}
+
+func Test_WKT_TimestampEpoch(t *testing.T) {
+	gps := time.Date(1980, 1, 6, 0, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		name      string
+		opts      SchemaOptions
+		timestamp time.Time
+		expected  int64
+	}{
+		{
+			name:      "micros",
+			opts:      SchemaOptions{TimestampEpoch: gps},
+			timestamp: time.Date(1980, 1, 7, 0, 0, 0, 1000, time.UTC),
+			expected:  86400000001,
+		},
+		{
+			name:      "millis",
+			opts:      SchemaOptions{TimestampEpoch: gps, TimestampMillis: true},
+			timestamp: time.Date(1980, 1, 7, 0, 0, 0, 1e6, time.UTC),
+			expected:  86400001,
+		},
+		{
+			name:      "before epoch",
+			opts:      SchemaOptions{TimestampEpoch: gps},
+			timestamp: time.Date(1980, 1, 5, 23, 59, 59, 999999000, time.UTC),
+			expected:  -1,
+		},
+		{
+			name:      "epoch with fraction",
+			opts:      SchemaOptions{TimestampEpoch: gps.Add(500 * time.Millisecond)},
+			timestamp: time.Date(1980, 1, 6, 0, 0, 1, 0, time.UTC),
+			expected:  500000,
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			msg := &examplev1.ExampleTimestamp{Timestamp: timestamppb.New(tt.timestamp)}
+			encoded, err := MarshalOptions{SchemaOptions: tt.opts}.encodeWKT(msg.GetTimestamp().ProtoReflect())
+			assert.NilError(t, err)
+			assert.DeepEqual(t, map[string]interface{}{"long": tt.expected}, encoded)
+
+			schema, err := tt.opts.InferSchema(msg.ProtoReflect().Descriptor())
+			assert.NilError(t, err)
+			record, ok := schema.(avro.Union)[1].(avro.Record)
+			assert.Assert(t, ok)
+			assert.DeepEqual(t, avro.Nullable(avro.Long()), record.Fields[0].Type)
+			assert.DeepEqual(
+				t,
+				map[string]interface{}{"proto.timestampEpoch": tt.opts.TimestampEpoch.Format(time.RFC3339Nano)},
+				record.Fields[0].Props,
+			)
+			assertRoundTrip(t, tt.opts, schema, msg)
+		})
+	}
+}
+
+func Test_WKT_ExpandAny(t *testing.T) {
+	book := &library.Book{Name: "shelves/1/books/1", Title: "Dune"}
+	inline := map[string]interface{}{
+		"type_url": "type.googleapis.com/google.example.library.v1.Book",
+		"value": map[string]interface{}{
+			"google.example.library.v1.Book": map[string]interface{}{
+				"name":  map[string]interface{}{"string": "shelves/1/books/1"},
+				"title": map[string]interface{}{"string": "Dune"},
+			},
+		},
+	}
+	opts := UnmarshalOptions{ExpandAny: true}
+	t.Run("inline", func(t *testing.T) {
+		var got examplev1.ExampleAny
+		assert.NilError(t, opts.Unmarshal(map[string]interface{}{"any": inline}, &got))
+		value, err := got.GetAny().UnmarshalNew()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, book, value, protocmp.Transform())
+	})
+	t.Run("inline in union", func(t *testing.T) {
+		var got examplev1.ExampleAny
+		data := map[string]interface{}{"any": map[string]interface{}{"google.protobuf.Any": inline}}
+		assert.NilError(t, opts.Unmarshal(data, &got))
+		value, err := got.GetAny().UnmarshalNew()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, book, value, protocmp.Transform())
+	})
+	t.Run("JSON string", func(t *testing.T) {
+		encoded, err := protojson.Marshal(mustAny(t, book))
+		assert.NilError(t, err)
+		var got examplev1.ExampleAny
+		data := map[string]interface{}{"any": map[string]interface{}{"string": string(encoded)}}
+		assert.NilError(t, opts.Unmarshal(data, &got))
+		value, err := got.GetAny().UnmarshalNew()
+		assert.NilError(t, err)
+		assert.DeepEqual(t, book, value, protocmp.Transform())
+	})
+	t.Run("unknown type", func(t *testing.T) {
+		var got examplev1.ExampleAny
+		data := map[string]interface{}{
+			"any": map[string]interface{}{"type_url": "type.googleapis.com/unknown.Message", "value": nil},
+		}
+		assert.ErrorContains(t, opts.Unmarshal(data, &got), "resolve 'type.googleapis.com/unknown.Message'")
+	})
+	t.Run("without option", func(t *testing.T) {
+		var got examplev1.ExampleAny
+		assert.ErrorContains(
+			t,
+			UnmarshalOptions{}.Unmarshal(map[string]interface{}{"any": inline}, &got),
+			"google.protobuf.Any: expected key 'string'",
+		)
+	})
+}
+
+func Test_WKT_LogicalTypeProps(t *testing.T) {
+	opts := SchemaOptions{
+		LogicalTypeProps: map[string]map[string]interface{}{
+			"timestamp-micros": {"java-class": "java.time.Instant", "connect.name": "timestamp"},
+		},
+	}
+	msg := &examplev1.ExampleTimestamp{Timestamp: timestamppb.New(time.Unix(1700000000, 0))}
+	schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(
+		string(schemaBytes),
+		`{"type":"long","logicalType":"timestamp-micros","connect.name":"timestamp","java-class":"java.time.Instant"}`,
+	))
+	assertRoundTrip(t, opts, schema, msg)
+
+	marshalOpts := MarshalOptions{SchemaOptions: opts}
+	size, err := marshalOpts.EncodedSize(msg)
+	assert.NilError(t, err)
+	assert.Assert(t, size > 0)
+	_, canonical, _, err := MarshalWithCanonicalSchema(msg, marshalOpts)
+	assert.NilError(t, err)
+	assert.Assert(t, !strings.Contains(string(canonical), "java-class"))
+	_, plainCanonical, _, err := MarshalWithCanonicalSchema(msg, MarshalOptions{})
+	assert.NilError(t, err)
+	assert.Equal(t, string(plainCanonical), string(canonical))
+}
+
+func Test_WKT_LogicalTypeProps_KafkaConnect(t *testing.T) {
+	// Kafka Connect reads timestamp-millis fields as its Timestamp type when annotated with its type name.
+	opts := SchemaOptions{
+		TimestampMillis: true,
+		LogicalTypeProps: map[string]map[string]interface{}{
+			"timestamp-millis": {"connect.name": "org.apache.kafka.connect.data.Timestamp", "connect.version": 1},
+		},
+	}
+	msg := &examplev1.ExampleTimestamp{Timestamp: timestamppb.New(time.Unix(1700000000, 0))}
+	schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	schemaBytes, err := json.Marshal(schema)
+	assert.NilError(t, err)
+	assert.Assert(t, strings.Contains(
+		string(schemaBytes),
+		`{"type":"long","logicalType":"timestamp-millis",`+
+			`"connect.name":"org.apache.kafka.connect.data.Timestamp","connect.version":1}`,
+	))
+	assertRoundTrip(t, opts, schema, msg)
+}
+
+func Test_WKT_OpaqueWKTs(t *testing.T) {
+	opts := SchemaOptions{OpaqueWKTs: []protoreflect.FullName{"google.protobuf.Timestamp"}}
+	msg := &publicv1.LondonBicycleRental{
+		Duration:  durationpb.New(90 * time.Second),
+		StartDate: timestamppb.New(time.Unix(1700000000, 5)),
+		EndDate:   timestamppb.New(time.Unix(1700000090, 0)),
+	}
+	schema, err := opts.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	fields := make(map[string]avro.Schema)
+	for _, field := range schema.(avro.Union)[1].(avro.Record).Fields {
+		fields[field.Name] = field.Type
+	}
+	assert.DeepEqual(t, avro.Nullable(avro.Record{
+		Type:      avro.RecordType,
+		Name:      "Timestamp",
+		Namespace: "google.protobuf",
+		Fields: []avro.Field{
+			{Name: "seconds", Type: avro.Nullable(avro.Long())},
+			{Name: "nanos", Type: avro.Nullable(avro.Integer())},
+		},
+	}), fields["end_date"], cmpopts.IgnoreFields(avro.Record{}, "Doc"), cmpopts.IgnoreFields(avro.Field{}, "Doc"))
+	assert.DeepEqual(t, avro.Nullable(avro.Reference("google.protobuf.Timestamp")), fields["start_date"])
+	durationSchema, err := SchemaOptions{}.InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	for _, field := range durationSchema.(avro.Union)[1].(avro.Record).Fields {
+		if field.Name == "duration" {
+			assert.DeepEqual(t, field.Type, fields["duration"])
+		}
+	}
+	native := assertRoundTrip(t, opts, schema, msg)
+	record := native.(map[string]interface{})["einride.bigquery.public.v1.LondonBicycleRental"].(map[string]interface{})
+	assert.DeepEqual(t, map[string]interface{}{
+		"google.protobuf.Timestamp": map[string]interface{}{
+			"seconds": map[string]interface{}{"long": int64(1700000000)},
+			"nanos":   map[string]interface{}{"int": int32(5)},
+		},
+	}, record["start_date"])
+}
+
+func Test_DecodeWKT_WrapperList(t *testing.T) {
+	msg := &examplev1.ExampleWrapperList{
+		StringValueList: []*wrapperspb.StringValue{wrapperspb.String("a"), wrapperspb.String("")},
+	}
+	schema, err := InferSchema(msg.ProtoReflect().Descriptor())
+	assert.NilError(t, err)
+	assertRoundTrip(t, SchemaOptions{}, schema, msg)
+	for _, tt := range []struct {
+		name        string
+		data        interface{}
+		policy      NullListElementPolicy
+		expected    []*wrapperspb.StringValue
+		errContains string
+	}{
+		{
+			name:     "union wrapped",
+			data:     map[string]interface{}{"array": []interface{}{nil, map[string]interface{}{"string": "a"}}},
+			expected: []*wrapperspb.StringValue{{}, wrapperspb.String("a")},
+		},
+		{
+			name:     "bare",
+			data:     []interface{}{nil, "a", ""},
+			expected: []*wrapperspb.StringValue{{}, wrapperspb.String("a"), wrapperspb.String("")},
+		},
+		{
+			name:     "drop null",
+			data:     []interface{}{nil, "a"},
+			policy:   NullListElementDrop,
+			expected: []*wrapperspb.StringValue{wrapperspb.String("a")},
+		},
+		{
+			name:        "wrong type",
+			data:        []interface{}{int64(1)},
+			errContains: "google.protobuf.StringValue: expected string, got int64",
+		},
+	} {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var got examplev1.ExampleWrapperList
+			err := UnmarshalOptions{NullListElementPolicy: tt.policy}.Unmarshal(
+				map[string]interface{}{"string_value_list": tt.data},
+				&got,
+			)
+			if tt.errContains != "" {
+				assert.ErrorContains(t, err, tt.errContains)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.expected, got.StringValueList, protocmp.Transform())
+		})
+	}
+}
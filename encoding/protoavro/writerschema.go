@@ -0,0 +1,352 @@
+package protoavro
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/linkedin/goavro/v2"
+	"go.einride.tech/protobuf-avro/avro"
+)
+
+// maxWriterSchemas is the number of compiled writer schemas that are cached.
+const maxWriterSchemas = 64
+
+// compiledWriterSchema is a compiled writer schema, or the error of compiling it.
+type compiledWriterSchema struct {
+	schema *writerSchema
+	err    error
+}
+
+// writerSchemaCache caches compiled writer schemas by schema text, so that each schema is compiled once.
+// When the cache is full, an arbitrary schema is evicted.
+type writerSchemaCache struct {
+	mu      sync.Mutex
+	schemas map[string]compiledWriterSchema
+}
+
+var writerSchemas = writerSchemaCache{schemas: make(map[string]compiledWriterSchema)}
+
+// compile returns the compiled writer schema.
+func (c *writerSchemaCache) compile(schema json.RawMessage) (*writerSchema, error) {
+	c.mu.Lock()
+	cached, ok := c.schemas[string(schema)]
+	c.mu.Unlock()
+	if ok {
+		return cached.schema, cached.err
+	}
+	var compiled compiledWriterSchema
+	compiled.schema, compiled.err = compileWriterSchema(schema)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.schemas[string(schema)]; ok {
+		return cached.schema, cached.err
+	}
+	for key := range c.schemas {
+		if len(c.schemas) < maxWriterSchemas {
+			break
+		}
+		delete(c.schemas, key)
+	}
+	c.schemas[string(schema)] = compiled
+	return compiled.schema, compiled.err
+}
+
+// validateWriterSchema returns an error if data does not conform to schema.
+func validateWriterSchema(schema json.RawMessage, data interface{}) error {
+	compiled, err := writerSchemas.compile(schema)
+	if err != nil {
+		return fmt.Errorf("writer schema: %w", err)
+	}
+	if err := compiled.validate(compiled.root, "", data, ""); err != nil {
+		return fmt.Errorf("writer schema: invalid data: %w", err)
+	}
+	return nil
+}
+
+// writerSchema validates data against a parsed writer schema.
+// Unlike goavro, which coerces numbers between types, it requires the native Go types
+// that goavro decodes each Avro type into.
+type writerSchema struct {
+	root interface{}
+	// named holds the definitions of named types by full name.
+	named map[string]map[string]interface{}
+}
+
+func compileWriterSchema(schema json.RawMessage) (*writerSchema, error) {
+	// goavro reports invalid schemas, such as unknown types and missing attributes.
+	if _, err := goavro.NewCodec(string(schema)); err != nil {
+		return nil, err
+	}
+	w := writerSchema{named: make(map[string]map[string]interface{})}
+	if err := json.Unmarshal(schema, &w.root); err != nil {
+		return nil, err
+	}
+	w.register(w.root, "")
+	return &w, nil
+}
+
+// register adds the named types defined in node to the named types of w.
+func (w *writerSchema) register(node interface{}, namespace string) {
+	switch node := node.(type) {
+	case []interface{}:
+		for _, member := range node {
+			w.register(member, namespace)
+		}
+	case map[string]interface{}:
+		switch node["type"] {
+		case string(avro.RecordType), "error":
+			name := namedTypeName(node, namespace)
+			w.named[name] = node
+			fields, _ := node["fields"].([]interface{})
+			for _, field := range fields {
+				if field, ok := field.(map[string]interface{}); ok {
+					w.register(field["type"], avro.NamespaceOf(name))
+				}
+			}
+		case string(avro.EnumType), "fixed":
+			w.named[namedTypeName(node, namespace)] = node
+		case string(avro.ArrayType):
+			w.register(node["items"], namespace)
+		case string(avro.MapType):
+			w.register(node["values"], namespace)
+		default:
+			if _, ok := node["type"].(string); !ok {
+				w.register(node["type"], namespace)
+			}
+		}
+	}
+}
+
+// namedTypeName returns the full name of the named type defined by node.
+func namedTypeName(node map[string]interface{}, namespace string) string {
+	name, _ := node["name"].(string)
+	explicitNamespace, _ := node["namespace"].(string)
+	return avro.FullName(name, explicitNamespace, namespace)
+}
+
+// validate returns an error if data is not the native form of node.
+// The path of data is used in errors.
+func (w *writerSchema) validate(node interface{}, namespace string, data interface{}, path string) error {
+	switch node := node.(type) {
+	case string:
+		if isPrimitiveType(node) {
+			return validatePrimitive(node, "", data, path)
+		}
+		name := avro.FullName(node, "", namespace)
+		definition, ok := w.named[name]
+		if !ok {
+			return fmt.Errorf("%sunknown named type '%s'", pathPrefix(path), name)
+		}
+		return w.validateNamed(definition, name, data, path)
+	case []interface{}:
+		return w.validateUnion(node, namespace, data, path)
+	case map[string]interface{}:
+		typ, ok := node["type"].(string)
+		if !ok {
+			return w.validate(node["type"], namespace, data, path)
+		}
+		switch typ {
+		case string(avro.RecordType), "error", string(avro.EnumType), "fixed":
+			return w.validateNamed(node, namedTypeName(node, namespace), data, path)
+		case string(avro.ArrayType):
+			items, ok := data.([]interface{})
+			if !ok {
+				return fmt.Errorf("%sexpected array, got %T", pathPrefix(path), data)
+			}
+			for i, item := range items {
+				if err := w.validate(node["items"], namespace, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+			return nil
+		case string(avro.MapType):
+			values, ok := data.(map[string]interface{})
+			if !ok {
+				return fmt.Errorf("%sexpected map, got %T", pathPrefix(path), data)
+			}
+			for key, value := range values {
+				if err := w.validate(node["values"], namespace, value, fmt.Sprintf("%s[%q]", path, key)); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if !isPrimitiveType(typ) {
+			return w.validate(typ, namespace, data, path)
+		}
+		logicalType, _ := node["logicalType"].(string)
+		return validatePrimitive(typ, logicalType, data, path)
+	}
+	return fmt.Errorf("%sunexpected schema %v", pathPrefix(path), node)
+}
+
+// validateUnion returns an error if data is not null, for unions with a null member,
+// or a map from the name of a member to a value of the member.
+func (w *writerSchema) validateUnion(union []interface{}, namespace string, data interface{}, path string) error {
+	if data == nil {
+		for _, member := range union {
+			if w.branchName(member, namespace) == string(avro.NullType) {
+				return nil
+			}
+		}
+		return fmt.Errorf("%snull is not a member of union", pathPrefix(path))
+	}
+	value, ok := data.(map[string]interface{})
+	if !ok || len(value) != 1 {
+		return fmt.Errorf("%sexpected union encoded as map with a single key, got %T", pathPrefix(path), data)
+	}
+	for branch, value := range value {
+		for _, member := range union {
+			if w.branchName(member, namespace) == branch {
+				return w.validate(member, namespace, value, path)
+			}
+		}
+		return fmt.Errorf("%sunknown union branch '%s'", pathPrefix(path), branch)
+	}
+	return nil
+}
+
+// validateNamed returns an error if data is not the native form of the named type definition.
+func (w *writerSchema) validateNamed(
+	definition map[string]interface{},
+	name string,
+	data interface{},
+	path string,
+) error {
+	switch definition["type"] {
+	case string(avro.EnumType):
+		symbol, ok := data.(string)
+		if !ok {
+			return fmt.Errorf("%sexpected enum %s, got %T", pathPrefix(path), name, data)
+		}
+		symbols, _ := definition["symbols"].([]interface{})
+		for _, s := range symbols {
+			if s == symbol {
+				return nil
+			}
+		}
+		return fmt.Errorf("%sunknown symbol '%s' of enum %s", pathPrefix(path), symbol, name)
+	case "fixed":
+		b, ok := data.([]byte)
+		if !ok {
+			return fmt.Errorf("%sexpected fixed %s, got %T", pathPrefix(path), name, data)
+		}
+		if size, _ := definition["size"].(float64); len(b) != int(size) {
+			return fmt.Errorf("%sexpected %v bytes for fixed %s, got %d", pathPrefix(path), size, name, len(b))
+		}
+		return nil
+	}
+	record, ok := data.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%sexpected record %s, got %T", pathPrefix(path), name, data)
+	}
+	if path == "" {
+		path = name
+	}
+	fields, _ := definition["fields"].([]interface{})
+	for _, field := range fields {
+		field, _ := field.(map[string]interface{})
+		fieldName, _ := field["name"].(string)
+		fieldPath := path + "." + fieldName
+		value, ok := record[fieldName]
+		if !ok {
+			if _, hasDefault := field["default"]; hasDefault {
+				continue
+			}
+			return fmt.Errorf("%s: missing field without default", fieldPath)
+		}
+		if err := w.validate(field["type"], avro.NamespaceOf(name), value, fieldPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// branchName returns the name that identifies node as a union member.
+func (w *writerSchema) branchName(node interface{}, namespace string) string {
+	switch node := node.(type) {
+	case string:
+		if isPrimitiveType(node) {
+			return node
+		}
+		return avro.FullName(node, "", namespace)
+	case map[string]interface{}:
+		typ, ok := node["type"].(string)
+		if !ok {
+			return w.branchName(node["type"], namespace)
+		}
+		switch typ {
+		case string(avro.RecordType), "error", string(avro.EnumType), "fixed":
+			return namedTypeName(node, namespace)
+		case string(avro.ArrayType), string(avro.MapType):
+			return typ
+		}
+		if !isPrimitiveType(typ) {
+			return avro.FullName(typ, "", namespace)
+		}
+		logicalType, _ := node["logicalType"].(string)
+		if _, ok := goavroLogicalTypes[typ+"."+logicalType]; ok {
+			return typ + "." + logicalType
+		}
+		return typ
+	}
+	return ""
+}
+
+func isPrimitiveType(name string) bool {
+	switch avro.Type(name) {
+	case avro.NullType, avro.BooleanType, avro.IntType, avro.LongType,
+		avro.FloatType, avro.DoubleType, avro.BytesType, avro.StringType:
+		return true
+	}
+	return false
+}
+
+// validatePrimitive returns an error if data does not have the Go type that goavro decodes
+// the primitive type into. Values of logical types may also have the type of the logical value.
+func validatePrimitive(typ, logicalType string, data interface{}, path string) error {
+	var ok bool
+	switch avro.Type(typ) {
+	case avro.NullType:
+		ok = data == nil
+	case avro.BooleanType:
+		_, ok = data.(bool)
+	case avro.IntType:
+		_, ok = data.(int32)
+	case avro.LongType:
+		_, ok = data.(int64)
+	case avro.FloatType:
+		_, ok = data.(float32)
+	case avro.DoubleType:
+		_, ok = data.(float64)
+	case avro.BytesType:
+		_, ok = data.([]byte)
+	case avro.StringType:
+		_, ok = data.(string)
+	}
+	if !ok {
+		switch typ + "." + logicalType {
+		case "int.date", "long.timestamp-millis", "long.timestamp-micros":
+			_, ok = data.(time.Time)
+		case "int.time-millis", "long.time-micros":
+			_, ok = data.(time.Duration)
+		case "bytes.decimal":
+			_, ok = data.(*big.Rat)
+		}
+	}
+	if !ok {
+		return fmt.Errorf("%sexpected %s, got %T", pathPrefix(path), typ, data)
+	}
+	return nil
+}
+
+// pathPrefix returns the prefix of errors about the value at path.
+func pathPrefix(path string) string {
+	if path == "" {
+		return ""
+	}
+	return path + ": "
+}
@@ -68,6 +68,53 @@ func (x *ExampleAny) GetAny() *anypb.Any {
 	return nil
 }
 
+type ExampleAnyList struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Anys []*anypb.Any `protobuf:"bytes,1,rep,name=anys,proto3" json:"anys,omitempty"`
+}
+
+func (x *ExampleAnyList) Reset() {
+	*x = ExampleAnyList{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_any_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleAnyList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleAnyList) ProtoMessage() {}
+
+func (x *ExampleAnyList) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_any_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleAnyList.ProtoReflect.Descriptor instead.
+func (*ExampleAnyList) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_any_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ExampleAnyList) GetAnys() []*anypb.Any {
+	if x != nil {
+		return x.Anys
+	}
+	return nil
+}
+
 var File_einride_avro_example_v1_example_any_proto protoreflect.FileDescriptor
 
 var file_einride_avro_example_v1_example_any_proto_rawDesc = []byte{
@@ -80,13 +127,17 @@ var file_einride_avro_example_v1_example_any_proto_rawDesc = []byte{
 	0x34, 0x0a, 0x0a, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x41, 0x6e, 0x79, 0x12, 0x26, 0x0a,
 	0x03, 0x61, 0x6e, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f,
 	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79,
-	0x52, 0x03, 0x61, 0x6e, 0x79, 0x42, 0x5d, 0x5a, 0x5b, 0x67, 0x6f, 0x2e, 0x65, 0x69, 0x6e, 0x72,
-	0x69, 0x64, 0x65, 0x2e, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2d, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f,
-	0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67,
-	0x65, 0x6e, 0x2f, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f,
-	0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x78, 0x61, 0x6d, 0x70,
-	0x6c, 0x65, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x03, 0x61, 0x6e, 0x79, 0x22, 0x3a, 0x0a, 0x0e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x41, 0x6e, 0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x61, 0x6e, 0x79, 0x73, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52, 0x04, 0x61, 0x6e, 0x79,
+	0x73, 0x42, 0x5d, 0x5a, 0x5b, 0x67, 0x6f, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e,
+	0x74, 0x65, 0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2d, 0x61, 0x76,
+	0x72, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x65,
+	0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x76, 0x31,
+	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -101,18 +152,20 @@ func file_einride_avro_example_v1_example_any_proto_rawDescGZIP() []byte {
 	return file_einride_avro_example_v1_example_any_proto_rawDescData
 }
 
-var file_einride_avro_example_v1_example_any_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_einride_avro_example_v1_example_any_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_einride_avro_example_v1_example_any_proto_goTypes = []interface{}{
-	(*ExampleAny)(nil), // 0: einride.avro.example.v1.ExampleAny
-	(*anypb.Any)(nil),  // 1: google.protobuf.Any
+	(*ExampleAny)(nil),     // 0: einride.avro.example.v1.ExampleAny
+	(*ExampleAnyList)(nil), // 1: einride.avro.example.v1.ExampleAnyList
+	(*anypb.Any)(nil),      // 2: google.protobuf.Any
 }
 var file_einride_avro_example_v1_example_any_proto_depIdxs = []int32{
-	1, // 0: einride.avro.example.v1.ExampleAny.any:type_name -> google.protobuf.Any
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	2, // 0: einride.avro.example.v1.ExampleAny.any:type_name -> google.protobuf.Any
+	2, // 1: einride.avro.example.v1.ExampleAnyList.anys:type_name -> google.protobuf.Any
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_einride_avro_example_v1_example_any_proto_init() }
@@ -133,6 +186,18 @@ func file_einride_avro_example_v1_example_any_proto_init() {
 				return nil
 			}
 		}
+		file_einride_avro_example_v1_example_any_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleAnyList); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -140,7 +205,7 @@ func file_einride_avro_example_v1_example_any_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_einride_avro_example_v1_example_any_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,192 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: einride/avro/example/v1/example_extension.proto
+
+package examplev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExampleExtendable struct {
+	state           protoimpl.MessageState
+	sizeCache       protoimpl.SizeCache
+	unknownFields   protoimpl.UnknownFields
+	extensionFields protoimpl.ExtensionFields
+
+	Name *string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+}
+
+func (x *ExampleExtendable) Reset() {
+	*x = ExampleExtendable{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_extension_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleExtendable) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleExtendable) ProtoMessage() {}
+
+func (x *ExampleExtendable) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_extension_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleExtendable.ProtoReflect.Descriptor instead.
+func (*ExampleExtendable) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_extension_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExampleExtendable) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+var file_einride_avro_example_v1_example_extension_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*ExampleExtendable)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         100,
+		Name:          "einride.avro.example.v1.note",
+		Tag:           "bytes,100,opt,name=note",
+		Filename:      "einride/avro/example/v1/example_extension.proto",
+	},
+	{
+		ExtendedType:  (*ExampleExtendable)(nil),
+		ExtensionType: (*int64)(nil),
+		Field:         101,
+		Name:          "einride.avro.example.v1.priority",
+		Tag:           "varint,101,opt,name=priority",
+		Filename:      "einride/avro/example/v1/example_extension.proto",
+	},
+}
+
+// Extension fields to ExampleExtendable.
+var (
+	// optional string note = 100;
+	E_Note = &file_einride_avro_example_v1_example_extension_proto_extTypes[0]
+	// optional int64 priority = 101;
+	E_Priority = &file_einride_avro_example_v1_example_extension_proto_extTypes[1]
+)
+
+var File_einride_avro_example_v1_example_extension_proto protoreflect.FileDescriptor
+
+var file_einride_avro_example_v1_example_extension_proto_rawDesc = []byte{
+	0x0a, 0x2f, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x17, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e,
+	0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x22, 0x2e, 0x0a, 0x11, 0x45, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x2a, 0x05, 0x08, 0x64, 0x10, 0xc8, 0x01, 0x3a, 0x3e, 0x0a, 0x04, 0x6e, 0x6f,
+	0x74, 0x65, 0x12, 0x2a, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72,
+	0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x64,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x6f, 0x74, 0x65, 0x3a, 0x46, 0x0a, 0x08, 0x70, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x2a, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65,
+	0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31,
+	0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x64, 0x61, 0x62,
+	0x6c, 0x65, 0x18, 0x65, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x42, 0x5d, 0x5a, 0x5b, 0x67, 0x6f, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65,
+	0x2e, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2d, 0x61,
+	0x76, 0x72, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f,
+	0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x76,
+	0x31,
+}
+
+var (
+	file_einride_avro_example_v1_example_extension_proto_rawDescOnce sync.Once
+	file_einride_avro_example_v1_example_extension_proto_rawDescData = file_einride_avro_example_v1_example_extension_proto_rawDesc
+)
+
+func file_einride_avro_example_v1_example_extension_proto_rawDescGZIP() []byte {
+	file_einride_avro_example_v1_example_extension_proto_rawDescOnce.Do(func() {
+		file_einride_avro_example_v1_example_extension_proto_rawDescData = protoimpl.X.CompressGZIP(file_einride_avro_example_v1_example_extension_proto_rawDescData)
+	})
+	return file_einride_avro_example_v1_example_extension_proto_rawDescData
+}
+
+var file_einride_avro_example_v1_example_extension_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_einride_avro_example_v1_example_extension_proto_goTypes = []interface{}{
+	(*ExampleExtendable)(nil), // 0: einride.avro.example.v1.ExampleExtendable
+}
+var file_einride_avro_example_v1_example_extension_proto_depIdxs = []int32{
+	0, // 0: einride.avro.example.v1.note:extendee -> einride.avro.example.v1.ExampleExtendable
+	0, // 1: einride.avro.example.v1.priority:extendee -> einride.avro.example.v1.ExampleExtendable
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	0, // [0:2] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_einride_avro_example_v1_example_extension_proto_init() }
+func file_einride_avro_example_v1_example_extension_proto_init() {
+	if File_einride_avro_example_v1_example_extension_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_einride_avro_example_v1_example_extension_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleExtendable); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			case 3:
+				return &v.extensionFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_einride_avro_example_v1_example_extension_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 2,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_avro_example_v1_example_extension_proto_goTypes,
+		DependencyIndexes: file_einride_avro_example_v1_example_extension_proto_depIdxs,
+		MessageInfos:      file_einride_avro_example_v1_example_extension_proto_msgTypes,
+		ExtensionInfos:    file_einride_avro_example_v1_example_extension_proto_extTypes,
+	}.Build()
+	File_einride_avro_example_v1_example_extension_proto = out.File
+	file_einride_avro_example_v1_example_extension_proto_rawDesc = nil
+	file_einride_avro_example_v1_example_extension_proto_goTypes = nil
+	file_einride_avro_example_v1_example_extension_proto_depIdxs = nil
+}
@@ -0,0 +1,275 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: einride/avro/example/v1/example_field_behavior.proto
+
+package examplev1
+
+import (
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExampleFieldBehavior struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RequiredString   string                       `protobuf:"bytes,1,opt,name=required_string,json=requiredString,proto3" json:"required_string,omitempty"`
+	OptionalString   string                       `protobuf:"bytes,2,opt,name=optional_string,json=optionalString,proto3" json:"optional_string,omitempty"`
+	String_          string                       `protobuf:"bytes,3,opt,name=string,proto3" json:"string,omitempty"`
+	RequiredNested   *ExampleFieldBehavior_Nested `protobuf:"bytes,4,opt,name=required_nested,json=requiredNested,proto3" json:"required_nested,omitempty"`
+	RequiredList     []string                     `protobuf:"bytes,5,rep,name=required_list,json=requiredList,proto3" json:"required_list,omitempty"`
+	OutputOnlyString string                       `protobuf:"bytes,6,opt,name=output_only_string,json=outputOnlyString,proto3" json:"output_only_string,omitempty"`
+}
+
+func (x *ExampleFieldBehavior) Reset() {
+	*x = ExampleFieldBehavior{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_field_behavior_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleFieldBehavior) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleFieldBehavior) ProtoMessage() {}
+
+func (x *ExampleFieldBehavior) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_field_behavior_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleFieldBehavior.ProtoReflect.Descriptor instead.
+func (*ExampleFieldBehavior) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_field_behavior_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExampleFieldBehavior) GetRequiredString() string {
+	if x != nil {
+		return x.RequiredString
+	}
+	return ""
+}
+
+func (x *ExampleFieldBehavior) GetOptionalString() string {
+	if x != nil {
+		return x.OptionalString
+	}
+	return ""
+}
+
+func (x *ExampleFieldBehavior) GetString_() string {
+	if x != nil {
+		return x.String_
+	}
+	return ""
+}
+
+func (x *ExampleFieldBehavior) GetRequiredNested() *ExampleFieldBehavior_Nested {
+	if x != nil {
+		return x.RequiredNested
+	}
+	return nil
+}
+
+func (x *ExampleFieldBehavior) GetRequiredList() []string {
+	if x != nil {
+		return x.RequiredList
+	}
+	return nil
+}
+
+func (x *ExampleFieldBehavior) GetOutputOnlyString() string {
+	if x != nil {
+		return x.OutputOnlyString
+	}
+	return ""
+}
+
+type ExampleFieldBehavior_Nested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ExampleFieldBehavior_Nested) Reset() {
+	*x = ExampleFieldBehavior_Nested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_field_behavior_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleFieldBehavior_Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleFieldBehavior_Nested) ProtoMessage() {}
+
+func (x *ExampleFieldBehavior_Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_field_behavior_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleFieldBehavior_Nested.ProtoReflect.Descriptor instead.
+func (*ExampleFieldBehavior_Nested) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_field_behavior_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ExampleFieldBehavior_Nested) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_einride_avro_example_v1_example_field_behavior_proto protoreflect.FileDescriptor
+
+var file_einride_avro_example_v1_example_field_behavior_proto_rawDesc = []byte{
+	0x0a, 0x34, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e,
+	0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x1a,
+	0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x66, 0x69, 0x65, 0x6c,
+	0x64, 0x5f, 0x62, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0xf1, 0x02, 0x0a, 0x14, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x42, 0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x12, 0x2d, 0x0a, 0x0f, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x02, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x64, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x2d, 0x0a, 0x0f, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x04, 0xe2, 0x41, 0x01, 0x01, 0x52, 0x0e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e,
+	0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x12,
+	0x63, 0x0a, 0x0f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x6e, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x34, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69,
+	0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x42,
+	0x65, 0x68, 0x61, 0x76, 0x69, 0x6f, 0x72, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x42, 0x04,
+	0xe2, 0x41, 0x01, 0x02, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x4e, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x12, 0x2a, 0x0a, 0x0d, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
+	0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x42, 0x05, 0xe2, 0x41, 0x02,
+	0x02, 0x05, 0x52, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x4c, 0x69, 0x73, 0x74,
+	0x12, 0x32, 0x0a, 0x12, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x5f, 0x6f, 0x6e, 0x6c, 0x79, 0x5f,
+	0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x42, 0x04, 0xe2, 0x41,
+	0x01, 0x03, 0x52, 0x10, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4f, 0x6e, 0x6c, 0x79, 0x53, 0x74,
+	0x72, 0x69, 0x6e, 0x67, 0x1a, 0x1e, 0x0a, 0x06, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x42, 0x5d, 0x5a, 0x5b, 0x67, 0x6f, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69,
+	0x64, 0x65, 0x2e, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2d, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65,
+	0x6e, 0x2f, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_einride_avro_example_v1_example_field_behavior_proto_rawDescOnce sync.Once
+	file_einride_avro_example_v1_example_field_behavior_proto_rawDescData = file_einride_avro_example_v1_example_field_behavior_proto_rawDesc
+)
+
+func file_einride_avro_example_v1_example_field_behavior_proto_rawDescGZIP() []byte {
+	file_einride_avro_example_v1_example_field_behavior_proto_rawDescOnce.Do(func() {
+		file_einride_avro_example_v1_example_field_behavior_proto_rawDescData = protoimpl.X.CompressGZIP(file_einride_avro_example_v1_example_field_behavior_proto_rawDescData)
+	})
+	return file_einride_avro_example_v1_example_field_behavior_proto_rawDescData
+}
+
+var file_einride_avro_example_v1_example_field_behavior_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_einride_avro_example_v1_example_field_behavior_proto_goTypes = []interface{}{
+	(*ExampleFieldBehavior)(nil),        // 0: einride.avro.example.v1.ExampleFieldBehavior
+	(*ExampleFieldBehavior_Nested)(nil), // 1: einride.avro.example.v1.ExampleFieldBehavior.Nested
+}
+var file_einride_avro_example_v1_example_field_behavior_proto_depIdxs = []int32{
+	1, // 0: einride.avro.example.v1.ExampleFieldBehavior.required_nested:type_name -> einride.avro.example.v1.ExampleFieldBehavior.Nested
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_einride_avro_example_v1_example_field_behavior_proto_init() }
+func file_einride_avro_example_v1_example_field_behavior_proto_init() {
+	if File_einride_avro_example_v1_example_field_behavior_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_einride_avro_example_v1_example_field_behavior_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleFieldBehavior); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_einride_avro_example_v1_example_field_behavior_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleFieldBehavior_Nested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_einride_avro_example_v1_example_field_behavior_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_avro_example_v1_example_field_behavior_proto_goTypes,
+		DependencyIndexes: file_einride_avro_example_v1_example_field_behavior_proto_depIdxs,
+		MessageInfos:      file_einride_avro_example_v1_example_field_behavior_proto_msgTypes,
+	}.Build()
+	File_einride_avro_example_v1_example_field_behavior_proto = out.File
+	file_einride_avro_example_v1_example_field_behavior_proto_rawDesc = nil
+	file_einride_avro_example_v1_example_field_behavior_proto_goTypes = nil
+	file_einride_avro_example_v1_example_field_behavior_proto_depIdxs = nil
+}
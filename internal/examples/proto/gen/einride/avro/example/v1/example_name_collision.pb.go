@@ -0,0 +1,162 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: einride/avro/example/v1/example_name_collision.proto
+
+package examplev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExampleNameCollision struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The JSON name of this field is the text name of the next field.
+	DisplayName string `protobuf:"bytes,1,opt,name=display_name,json=title,proto3" json:"display_name,omitempty"`
+	Title       string `protobuf:"bytes,2,opt,name=title,json=heading,proto3" json:"title,omitempty"`
+}
+
+func (x *ExampleNameCollision) Reset() {
+	*x = ExampleNameCollision{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_name_collision_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleNameCollision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleNameCollision) ProtoMessage() {}
+
+func (x *ExampleNameCollision) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_name_collision_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleNameCollision.ProtoReflect.Descriptor instead.
+func (*ExampleNameCollision) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_name_collision_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExampleNameCollision) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *ExampleNameCollision) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+var File_einride_avro_example_v1_example_name_collision_proto protoreflect.FileDescriptor
+
+var file_einride_avro_example_v1_example_name_collision_proto_rawDesc = []byte{
+	0x0a, 0x34, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x63, 0x6f, 0x6c, 0x6c, 0x69, 0x73, 0x69, 0x6f, 0x6e,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e,
+	0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x22,
+	0x4b, 0x0a, 0x14, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x43, 0x6f,
+	0x6c, 0x6c, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1b, 0x0a, 0x0c, 0x64, 0x69, 0x73, 0x70, 0x6c,
+	0x61, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74,
+	0x69, 0x74, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x42, 0x5d, 0x5a, 0x5b,
+	0x67, 0x6f, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x74, 0x65, 0x63, 0x68, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2d, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64,
+	0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76,
+	0x31, 0x3b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_einride_avro_example_v1_example_name_collision_proto_rawDescOnce sync.Once
+	file_einride_avro_example_v1_example_name_collision_proto_rawDescData = file_einride_avro_example_v1_example_name_collision_proto_rawDesc
+)
+
+func file_einride_avro_example_v1_example_name_collision_proto_rawDescGZIP() []byte {
+	file_einride_avro_example_v1_example_name_collision_proto_rawDescOnce.Do(func() {
+		file_einride_avro_example_v1_example_name_collision_proto_rawDescData = protoimpl.X.CompressGZIP(file_einride_avro_example_v1_example_name_collision_proto_rawDescData)
+	})
+	return file_einride_avro_example_v1_example_name_collision_proto_rawDescData
+}
+
+var file_einride_avro_example_v1_example_name_collision_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_einride_avro_example_v1_example_name_collision_proto_goTypes = []interface{}{
+	(*ExampleNameCollision)(nil), // 0: einride.avro.example.v1.ExampleNameCollision
+}
+var file_einride_avro_example_v1_example_name_collision_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_einride_avro_example_v1_example_name_collision_proto_init() }
+func file_einride_avro_example_v1_example_name_collision_proto_init() {
+	if File_einride_avro_example_v1_example_name_collision_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_einride_avro_example_v1_example_name_collision_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleNameCollision); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_einride_avro_example_v1_example_name_collision_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_avro_example_v1_example_name_collision_proto_goTypes,
+		DependencyIndexes: file_einride_avro_example_v1_example_name_collision_proto_depIdxs,
+		MessageInfos:      file_einride_avro_example_v1_example_name_collision_proto_msgTypes,
+	}.Build()
+	File_einride_avro_example_v1_example_name_collision_proto = out.File
+	file_einride_avro_example_v1_example_name_collision_proto_rawDesc = nil
+	file_einride_avro_example_v1_example_name_collision_proto_goTypes = nil
+	file_einride_avro_example_v1_example_name_collision_proto_depIdxs = nil
+}
@@ -0,0 +1,395 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: einride/avro/example/v1/example_nested.proto
+
+package examplev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExampleNested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level1 *ExampleNested_Level1 `protobuf:"bytes,1,opt,name=level1,proto3" json:"level1,omitempty"`
+	Name   string                `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *ExampleNested) Reset() {
+	*x = ExampleNested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_nested_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleNested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleNested) ProtoMessage() {}
+
+func (x *ExampleNested) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_nested_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleNested.ProtoReflect.Descriptor instead.
+func (*ExampleNested) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_nested_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExampleNested) GetLevel1() *ExampleNested_Level1 {
+	if x != nil {
+		return x.Level1
+	}
+	return nil
+}
+
+func (x *ExampleNested) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ExampleNested_Level1 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level2     *ExampleNested_Level2   `protobuf:"bytes,1,opt,name=level2,proto3" json:"level2,omitempty"`
+	Level2List []*ExampleNested_Level2 `protobuf:"bytes,2,rep,name=level2_list,json=level2List,proto3" json:"level2_list,omitempty"`
+}
+
+func (x *ExampleNested_Level1) Reset() {
+	*x = ExampleNested_Level1{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_nested_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleNested_Level1) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleNested_Level1) ProtoMessage() {}
+
+func (x *ExampleNested_Level1) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_nested_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleNested_Level1.ProtoReflect.Descriptor instead.
+func (*ExampleNested_Level1) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_nested_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ExampleNested_Level1) GetLevel2() *ExampleNested_Level2 {
+	if x != nil {
+		return x.Level2
+	}
+	return nil
+}
+
+func (x *ExampleNested_Level1) GetLevel2List() []*ExampleNested_Level2 {
+	if x != nil {
+		return x.Level2List
+	}
+	return nil
+}
+
+type ExampleNested_Level2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Level3    *ExampleNested_Level3            `protobuf:"bytes,1,opt,name=level3,proto3" json:"level3,omitempty"`
+	Level3Map map[string]*ExampleNested_Level3 `protobuf:"bytes,2,rep,name=level3_map,json=level3Map,proto3" json:"level3_map,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ExampleNested_Level2) Reset() {
+	*x = ExampleNested_Level2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_nested_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleNested_Level2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleNested_Level2) ProtoMessage() {}
+
+func (x *ExampleNested_Level2) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_nested_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleNested_Level2.ProtoReflect.Descriptor instead.
+func (*ExampleNested_Level2) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_nested_proto_rawDescGZIP(), []int{0, 1}
+}
+
+func (x *ExampleNested_Level2) GetLevel3() *ExampleNested_Level3 {
+	if x != nil {
+		return x.Level3
+	}
+	return nil
+}
+
+func (x *ExampleNested_Level2) GetLevel3Map() map[string]*ExampleNested_Level3 {
+	if x != nil {
+		return x.Level3Map
+	}
+	return nil
+}
+
+type ExampleNested_Level3 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ExampleNested_Level3) Reset() {
+	*x = ExampleNested_Level3{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_nested_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleNested_Level3) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleNested_Level3) ProtoMessage() {}
+
+func (x *ExampleNested_Level3) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_nested_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleNested_Level3.ProtoReflect.Descriptor instead.
+func (*ExampleNested_Level3) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_nested_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *ExampleNested_Level3) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_einride_avro_example_v1_example_nested_proto protoreflect.FileDescriptor
+
+var file_einride_avro_example_v1_example_nested_proto_rawDesc = []byte{
+	0x0a, 0x2c, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x5f, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17,
+	0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x22, 0xc8, 0x04, 0x0a, 0x0d, 0x45, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x45, 0x0a, 0x06, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x31, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x65, 0x69, 0x6e, 0x72,
+	0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4e, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x31, 0x52, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x31,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x1a, 0x9f, 0x01, 0x0a, 0x06, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x31, 0x12,
+	0x45, 0x0a, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x32, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x2d, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x32, 0x52, 0x06,
+	0x6c, 0x65, 0x76, 0x65, 0x6c, 0x32, 0x12, 0x4e, 0x0a, 0x0b, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x32,
+	0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x65, 0x69,
+	0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4e, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x32, 0x52, 0x0a, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x32, 0x4c, 0x69, 0x73, 0x74, 0x1a, 0x99, 0x02, 0x0a, 0x06, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x32, 0x12, 0x45, 0x0a, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x33, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x2d, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f,
+	0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x33,
+	0x52, 0x06, 0x6c, 0x65, 0x76, 0x65, 0x6c, 0x33, 0x12, 0x5b, 0x0a, 0x0a, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x33, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x65,
+	0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4e, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x32, 0x2e, 0x4c, 0x65, 0x76, 0x65,
+	0x6c, 0x33, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6c, 0x65, 0x76, 0x65,
+	0x6c, 0x33, 0x4d, 0x61, 0x70, 0x1a, 0x6b, 0x0a, 0x0e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x33, 0x4d,
+	0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x43, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69,
+	0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64,
+	0x2e, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x33, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x1e, 0x0a, 0x06, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x33, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x42, 0x5d, 0x5a, 0x5b, 0x67, 0x6f, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65,
+	0x2e, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2d, 0x61,
+	0x76, 0x72, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f,
+	0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_einride_avro_example_v1_example_nested_proto_rawDescOnce sync.Once
+	file_einride_avro_example_v1_example_nested_proto_rawDescData = file_einride_avro_example_v1_example_nested_proto_rawDesc
+)
+
+func file_einride_avro_example_v1_example_nested_proto_rawDescGZIP() []byte {
+	file_einride_avro_example_v1_example_nested_proto_rawDescOnce.Do(func() {
+		file_einride_avro_example_v1_example_nested_proto_rawDescData = protoimpl.X.CompressGZIP(file_einride_avro_example_v1_example_nested_proto_rawDescData)
+	})
+	return file_einride_avro_example_v1_example_nested_proto_rawDescData
+}
+
+var file_einride_avro_example_v1_example_nested_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_einride_avro_example_v1_example_nested_proto_goTypes = []interface{}{
+	(*ExampleNested)(nil),        // 0: einride.avro.example.v1.ExampleNested
+	(*ExampleNested_Level1)(nil), // 1: einride.avro.example.v1.ExampleNested.Level1
+	(*ExampleNested_Level2)(nil), // 2: einride.avro.example.v1.ExampleNested.Level2
+	(*ExampleNested_Level3)(nil), // 3: einride.avro.example.v1.ExampleNested.Level3
+	nil,                          // 4: einride.avro.example.v1.ExampleNested.Level2.Level3MapEntry
+}
+var file_einride_avro_example_v1_example_nested_proto_depIdxs = []int32{
+	1, // 0: einride.avro.example.v1.ExampleNested.level1:type_name -> einride.avro.example.v1.ExampleNested.Level1
+	2, // 1: einride.avro.example.v1.ExampleNested.Level1.level2:type_name -> einride.avro.example.v1.ExampleNested.Level2
+	2, // 2: einride.avro.example.v1.ExampleNested.Level1.level2_list:type_name -> einride.avro.example.v1.ExampleNested.Level2
+	3, // 3: einride.avro.example.v1.ExampleNested.Level2.level3:type_name -> einride.avro.example.v1.ExampleNested.Level3
+	4, // 4: einride.avro.example.v1.ExampleNested.Level2.level3_map:type_name -> einride.avro.example.v1.ExampleNested.Level2.Level3MapEntry
+	3, // 5: einride.avro.example.v1.ExampleNested.Level2.Level3MapEntry.value:type_name -> einride.avro.example.v1.ExampleNested.Level3
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_einride_avro_example_v1_example_nested_proto_init() }
+func file_einride_avro_example_v1_example_nested_proto_init() {
+	if File_einride_avro_example_v1_example_nested_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_einride_avro_example_v1_example_nested_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleNested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_einride_avro_example_v1_example_nested_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleNested_Level1); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_einride_avro_example_v1_example_nested_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleNested_Level2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_einride_avro_example_v1_example_nested_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleNested_Level3); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_einride_avro_example_v1_example_nested_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_avro_example_v1_example_nested_proto_goTypes,
+		DependencyIndexes: file_einride_avro_example_v1_example_nested_proto_depIdxs,
+		MessageInfos:      file_einride_avro_example_v1_example_nested_proto_msgTypes,
+	}.Build()
+	File_einride_avro_example_v1_example_nested_proto = out.File
+	file_einride_avro_example_v1_example_nested_proto_rawDesc = nil
+	file_einride_avro_example_v1_example_nested_proto_goTypes = nil
+	file_einride_avro_example_v1_example_nested_proto_depIdxs = nil
+}
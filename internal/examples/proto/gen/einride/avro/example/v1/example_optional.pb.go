@@ -0,0 +1,343 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: einride/avro/example/v1/example_optional.proto
+
+package examplev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExampleOptional_Enum int32
+
+const (
+	ExampleOptional_ENUM_UNSPECIFIED ExampleOptional_Enum = 0
+	ExampleOptional_ENUM_VALUE1      ExampleOptional_Enum = 1
+	ExampleOptional_ENUM_VALUE2      ExampleOptional_Enum = 2
+)
+
+// Enum value maps for ExampleOptional_Enum.
+var (
+	ExampleOptional_Enum_name = map[int32]string{
+		0: "ENUM_UNSPECIFIED",
+		1: "ENUM_VALUE1",
+		2: "ENUM_VALUE2",
+	}
+	ExampleOptional_Enum_value = map[string]int32{
+		"ENUM_UNSPECIFIED": 0,
+		"ENUM_VALUE1":      1,
+		"ENUM_VALUE2":      2,
+	}
+)
+
+func (x ExampleOptional_Enum) Enum() *ExampleOptional_Enum {
+	p := new(ExampleOptional_Enum)
+	*p = x
+	return p
+}
+
+func (x ExampleOptional_Enum) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExampleOptional_Enum) Descriptor() protoreflect.EnumDescriptor {
+	return file_einride_avro_example_v1_example_optional_proto_enumTypes[0].Descriptor()
+}
+
+func (ExampleOptional_Enum) Type() protoreflect.EnumType {
+	return &file_einride_avro_example_v1_example_optional_proto_enumTypes[0]
+}
+
+func (x ExampleOptional_Enum) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExampleOptional_Enum.Descriptor instead.
+func (ExampleOptional_Enum) EnumDescriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_optional_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type ExampleOptional struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OptionalEnum   *ExampleOptional_Enum   `protobuf:"varint,1,opt,name=optional_enum,json=optionalEnum,proto3,enum=einride.avro.example.v1.ExampleOptional_Enum,oneof" json:"optional_enum,omitempty"`
+	EnumValue      ExampleOptional_Enum    `protobuf:"varint,2,opt,name=enum_value,json=enumValue,proto3,enum=einride.avro.example.v1.ExampleOptional_Enum" json:"enum_value,omitempty"`
+	OptionalString *string                 `protobuf:"bytes,3,opt,name=optional_string,json=optionalString,proto3,oneof" json:"optional_string,omitempty"`
+	OptionalInt64  *int64                  `protobuf:"varint,4,opt,name=optional_int64,json=optionalInt64,proto3,oneof" json:"optional_int64,omitempty"`
+	OptionalNested *ExampleOptional_Nested `protobuf:"bytes,5,opt,name=optional_nested,json=optionalNested,proto3,oneof" json:"optional_nested,omitempty"`
+	Nested         *ExampleOptional_Nested `protobuf:"bytes,6,opt,name=nested,proto3" json:"nested,omitempty"`
+}
+
+func (x *ExampleOptional) Reset() {
+	*x = ExampleOptional{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_optional_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleOptional) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleOptional) ProtoMessage() {}
+
+func (x *ExampleOptional) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_optional_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleOptional.ProtoReflect.Descriptor instead.
+func (*ExampleOptional) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_optional_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExampleOptional) GetOptionalEnum() ExampleOptional_Enum {
+	if x != nil && x.OptionalEnum != nil {
+		return *x.OptionalEnum
+	}
+	return ExampleOptional_ENUM_UNSPECIFIED
+}
+
+func (x *ExampleOptional) GetEnumValue() ExampleOptional_Enum {
+	if x != nil {
+		return x.EnumValue
+	}
+	return ExampleOptional_ENUM_UNSPECIFIED
+}
+
+func (x *ExampleOptional) GetOptionalString() string {
+	if x != nil && x.OptionalString != nil {
+		return *x.OptionalString
+	}
+	return ""
+}
+
+func (x *ExampleOptional) GetOptionalInt64() int64 {
+	if x != nil && x.OptionalInt64 != nil {
+		return *x.OptionalInt64
+	}
+	return 0
+}
+
+func (x *ExampleOptional) GetOptionalNested() *ExampleOptional_Nested {
+	if x != nil {
+		return x.OptionalNested
+	}
+	return nil
+}
+
+func (x *ExampleOptional) GetNested() *ExampleOptional_Nested {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+type ExampleOptional_Nested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ExampleOptional_Nested) Reset() {
+	*x = ExampleOptional_Nested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_optional_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleOptional_Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleOptional_Nested) ProtoMessage() {}
+
+func (x *ExampleOptional_Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_optional_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleOptional_Nested.ProtoReflect.Descriptor instead.
+func (*ExampleOptional_Nested) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_optional_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ExampleOptional_Nested) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var File_einride_avro_example_v1_example_optional_proto protoreflect.FileDescriptor
+
+var file_einride_avro_example_v1_example_optional_proto_rawDesc = []byte{
+	0x0a, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x17, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x22, 0xe7, 0x04, 0x0a, 0x0f, 0x45, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x12, 0x57, 0x0a,
+	0x0d, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x2d, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61,
+	0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x45,
+	0x6e, 0x75, 0x6d, 0x48, 0x00, 0x52, 0x0c, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x45,
+	0x6e, 0x75, 0x6d, 0x88, 0x01, 0x01, 0x12, 0x4c, 0x0a, 0x0a, 0x65, 0x6e, 0x75, 0x6d, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x2d, 0x2e, 0x65, 0x69, 0x6e,
+	0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x45, 0x6e, 0x75, 0x6d, 0x52, 0x09, 0x65, 0x6e, 0x75, 0x6d, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x12, 0x2c, 0x0a, 0x0f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c,
+	0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x48, 0x01, 0x52,
+	0x0e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x88,
+	0x01, 0x01, 0x12, 0x2a, 0x0a, 0x0e, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x69,
+	0x6e, 0x74, 0x36, 0x34, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x48, 0x02, 0x52, 0x0d, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x88, 0x01, 0x01, 0x12, 0x5d,
+	0x0a, 0x0f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x6e, 0x65, 0x73, 0x74, 0x65,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64,
+	0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61,
+	0x6c, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x48, 0x03, 0x52, 0x0e, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x88, 0x01, 0x01, 0x12, 0x47, 0x0a,
+	0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2f, 0x2e,
+	0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x06,
+	0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x1a, 0x1e, 0x0a, 0x06, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x3e, 0x0a, 0x04, 0x45, 0x6e, 0x75, 0x6d, 0x12, 0x14,
+	0x0a, 0x10, 0x45, 0x4e, 0x55, 0x4d, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x45, 0x4e, 0x55, 0x4d, 0x5f, 0x56, 0x41, 0x4c,
+	0x55, 0x45, 0x31, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x45, 0x4e, 0x55, 0x4d, 0x5f, 0x56, 0x41,
+	0x4c, 0x55, 0x45, 0x32, 0x10, 0x02, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x5f, 0x65, 0x6e, 0x75, 0x6d, 0x42, 0x12, 0x0a, 0x10, 0x5f, 0x6f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x42, 0x11, 0x0a, 0x0f,
+	0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x69, 0x6e, 0x74, 0x36, 0x34, 0x42,
+	0x12, 0x0a, 0x10, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x6e, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x42, 0x5d, 0x5a, 0x5b, 0x67, 0x6f, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64,
+	0x65, 0x2e, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2d,
+	0x61, 0x76, 0x72, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e,
+	0x2f, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_einride_avro_example_v1_example_optional_proto_rawDescOnce sync.Once
+	file_einride_avro_example_v1_example_optional_proto_rawDescData = file_einride_avro_example_v1_example_optional_proto_rawDesc
+)
+
+func file_einride_avro_example_v1_example_optional_proto_rawDescGZIP() []byte {
+	file_einride_avro_example_v1_example_optional_proto_rawDescOnce.Do(func() {
+		file_einride_avro_example_v1_example_optional_proto_rawDescData = protoimpl.X.CompressGZIP(file_einride_avro_example_v1_example_optional_proto_rawDescData)
+	})
+	return file_einride_avro_example_v1_example_optional_proto_rawDescData
+}
+
+var file_einride_avro_example_v1_example_optional_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_einride_avro_example_v1_example_optional_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_einride_avro_example_v1_example_optional_proto_goTypes = []interface{}{
+	(ExampleOptional_Enum)(0),      // 0: einride.avro.example.v1.ExampleOptional.Enum
+	(*ExampleOptional)(nil),        // 1: einride.avro.example.v1.ExampleOptional
+	(*ExampleOptional_Nested)(nil), // 2: einride.avro.example.v1.ExampleOptional.Nested
+}
+var file_einride_avro_example_v1_example_optional_proto_depIdxs = []int32{
+	0, // 0: einride.avro.example.v1.ExampleOptional.optional_enum:type_name -> einride.avro.example.v1.ExampleOptional.Enum
+	0, // 1: einride.avro.example.v1.ExampleOptional.enum_value:type_name -> einride.avro.example.v1.ExampleOptional.Enum
+	2, // 2: einride.avro.example.v1.ExampleOptional.optional_nested:type_name -> einride.avro.example.v1.ExampleOptional.Nested
+	2, // 3: einride.avro.example.v1.ExampleOptional.nested:type_name -> einride.avro.example.v1.ExampleOptional.Nested
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_einride_avro_example_v1_example_optional_proto_init() }
+func file_einride_avro_example_v1_example_optional_proto_init() {
+	if File_einride_avro_example_v1_example_optional_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_einride_avro_example_v1_example_optional_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleOptional); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_einride_avro_example_v1_example_optional_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleOptional_Nested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_einride_avro_example_v1_example_optional_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_einride_avro_example_v1_example_optional_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_avro_example_v1_example_optional_proto_goTypes,
+		DependencyIndexes: file_einride_avro_example_v1_example_optional_proto_depIdxs,
+		EnumInfos:         file_einride_avro_example_v1_example_optional_proto_enumTypes,
+		MessageInfos:      file_einride_avro_example_v1_example_optional_proto_msgTypes,
+	}.Build()
+	File_einride_avro_example_v1_example_optional_proto = out.File
+	file_einride_avro_example_v1_example_optional_proto_rawDesc = nil
+	file_einride_avro_example_v1_example_optional_proto_goTypes = nil
+	file_einride_avro_example_v1_example_optional_proto_depIdxs = nil
+}
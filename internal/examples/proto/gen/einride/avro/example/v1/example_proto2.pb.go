@@ -0,0 +1,259 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: einride/avro/example/v1/example_proto2.proto
+
+package examplev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExampleProto2 struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name           *string               `protobuf:"bytes,1,req,name=name" json:"name,omitempty"`
+	Description    *string               `protobuf:"bytes,2,opt,name=description" json:"description,omitempty"`
+	Count          *int64                `protobuf:"varint,3,req,name=count" json:"count,omitempty"`
+	Nested         *ExampleProto2_Nested `protobuf:"bytes,4,opt,name=nested" json:"nested,omitempty"`
+	RequiredNested *ExampleProto2_Nested `protobuf:"bytes,5,req,name=required_nested,json=requiredNested" json:"required_nested,omitempty"`
+}
+
+func (x *ExampleProto2) Reset() {
+	*x = ExampleProto2{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_proto2_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleProto2) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleProto2) ProtoMessage() {}
+
+func (x *ExampleProto2) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_proto2_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleProto2.ProtoReflect.Descriptor instead.
+func (*ExampleProto2) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_proto2_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExampleProto2) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *ExampleProto2) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *ExampleProto2) GetCount() int64 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return 0
+}
+
+func (x *ExampleProto2) GetNested() *ExampleProto2_Nested {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *ExampleProto2) GetRequiredNested() *ExampleProto2_Nested {
+	if x != nil {
+		return x.RequiredNested
+	}
+	return nil
+}
+
+type ExampleProto2_Nested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value *string `protobuf:"bytes,1,opt,name=value" json:"value,omitempty"`
+}
+
+func (x *ExampleProto2_Nested) Reset() {
+	*x = ExampleProto2_Nested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_proto2_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleProto2_Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleProto2_Nested) ProtoMessage() {}
+
+func (x *ExampleProto2_Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_proto2_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleProto2_Nested.ProtoReflect.Descriptor instead.
+func (*ExampleProto2_Nested) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_proto2_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ExampleProto2_Nested) GetValue() string {
+	if x != nil && x.Value != nil {
+		return *x.Value
+	}
+	return ""
+}
+
+var File_einride_avro_example_v1_example_proto2_proto protoreflect.FileDescriptor
+
+var file_einride_avro_example_v1_example_proto2_proto_rawDesc = []byte{
+	0x0a, 0x2c, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17,
+	0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x22, 0x9a, 0x02, 0x0a, 0x0d, 0x45, 0x78, 0x61, 0x6d,
+	0x70, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x18, 0x01, 0x20, 0x02, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x02, 0x28, 0x03, 0x52, 0x05,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x45, 0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e,
+	0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x4e, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x52, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x56, 0x0a, 0x0f,
+	0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18,
+	0x05, 0x20, 0x02, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e,
+	0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x32, 0x2e, 0x4e, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x52, 0x0e, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x4e, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x1a, 0x1e, 0x0a, 0x06, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x42, 0x5d, 0x5a, 0x5b, 0x67, 0x6f, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69,
+	0x64, 0x65, 0x2e, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2d, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65,
+	0x6e, 0x2f, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x76, 0x31,
+}
+
+var (
+	file_einride_avro_example_v1_example_proto2_proto_rawDescOnce sync.Once
+	file_einride_avro_example_v1_example_proto2_proto_rawDescData = file_einride_avro_example_v1_example_proto2_proto_rawDesc
+)
+
+func file_einride_avro_example_v1_example_proto2_proto_rawDescGZIP() []byte {
+	file_einride_avro_example_v1_example_proto2_proto_rawDescOnce.Do(func() {
+		file_einride_avro_example_v1_example_proto2_proto_rawDescData = protoimpl.X.CompressGZIP(file_einride_avro_example_v1_example_proto2_proto_rawDescData)
+	})
+	return file_einride_avro_example_v1_example_proto2_proto_rawDescData
+}
+
+var file_einride_avro_example_v1_example_proto2_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_einride_avro_example_v1_example_proto2_proto_goTypes = []interface{}{
+	(*ExampleProto2)(nil),        // 0: einride.avro.example.v1.ExampleProto2
+	(*ExampleProto2_Nested)(nil), // 1: einride.avro.example.v1.ExampleProto2.Nested
+}
+var file_einride_avro_example_v1_example_proto2_proto_depIdxs = []int32{
+	1, // 0: einride.avro.example.v1.ExampleProto2.nested:type_name -> einride.avro.example.v1.ExampleProto2.Nested
+	1, // 1: einride.avro.example.v1.ExampleProto2.required_nested:type_name -> einride.avro.example.v1.ExampleProto2.Nested
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_einride_avro_example_v1_example_proto2_proto_init() }
+func file_einride_avro_example_v1_example_proto2_proto_init() {
+	if File_einride_avro_example_v1_example_proto2_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_einride_avro_example_v1_example_proto2_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleProto2); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_einride_avro_example_v1_example_proto2_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleProto2_Nested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_einride_avro_example_v1_example_proto2_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_avro_example_v1_example_proto2_proto_goTypes,
+		DependencyIndexes: file_einride_avro_example_v1_example_proto2_proto_depIdxs,
+		MessageInfos:      file_einride_avro_example_v1_example_proto2_proto_msgTypes,
+	}.Build()
+	File_einride_avro_example_v1_example_proto2_proto = out.File
+	file_einride_avro_example_v1_example_proto2_proto_rawDesc = nil
+	file_einride_avro_example_v1_example_proto2_proto_goTypes = nil
+	file_einride_avro_example_v1_example_proto2_proto_depIdxs = nil
+}
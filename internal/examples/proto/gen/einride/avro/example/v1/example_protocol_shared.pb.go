@@ -0,0 +1,216 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: einride/avro/example/v1/example_protocol_shared.proto
+
+package examplev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExampleProtocolShared_Kind int32
+
+const (
+	ExampleProtocolShared_KIND_UNSPECIFIED ExampleProtocolShared_Kind = 0
+	ExampleProtocolShared_KIND_A           ExampleProtocolShared_Kind = 1
+)
+
+// Enum value maps for ExampleProtocolShared_Kind.
+var (
+	ExampleProtocolShared_Kind_name = map[int32]string{
+		0: "KIND_UNSPECIFIED",
+		1: "KIND_A",
+	}
+	ExampleProtocolShared_Kind_value = map[string]int32{
+		"KIND_UNSPECIFIED": 0,
+		"KIND_A":           1,
+	}
+)
+
+func (x ExampleProtocolShared_Kind) Enum() *ExampleProtocolShared_Kind {
+	p := new(ExampleProtocolShared_Kind)
+	*p = x
+	return p
+}
+
+func (x ExampleProtocolShared_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ExampleProtocolShared_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_einride_avro_example_v1_example_protocol_shared_proto_enumTypes[0].Descriptor()
+}
+
+func (ExampleProtocolShared_Kind) Type() protoreflect.EnumType {
+	return &file_einride_avro_example_v1_example_protocol_shared_proto_enumTypes[0]
+}
+
+func (x ExampleProtocolShared_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ExampleProtocolShared_Kind.Descriptor instead.
+func (ExampleProtocolShared_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_protocol_shared_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type ExampleProtocolShared struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value string                     `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Kind  ExampleProtocolShared_Kind `protobuf:"varint,2,opt,name=kind,proto3,enum=einride.avro.example.v1.ExampleProtocolShared_Kind" json:"kind,omitempty"`
+}
+
+func (x *ExampleProtocolShared) Reset() {
+	*x = ExampleProtocolShared{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_protocol_shared_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleProtocolShared) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleProtocolShared) ProtoMessage() {}
+
+func (x *ExampleProtocolShared) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_protocol_shared_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleProtocolShared.ProtoReflect.Descriptor instead.
+func (*ExampleProtocolShared) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_protocol_shared_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExampleProtocolShared) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *ExampleProtocolShared) GetKind() ExampleProtocolShared_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return ExampleProtocolShared_KIND_UNSPECIFIED
+}
+
+var File_einride_avro_example_v1_example_protocol_shared_proto protoreflect.FileDescriptor
+
+var file_einride_avro_example_v1_example_protocol_shared_proto_rawDesc = []byte{
+	0x0a, 0x35, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x5f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65,
+	0x64, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65,
+	0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31,
+	0x22, 0xa0, 0x01, 0x0a, 0x15, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x50, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x47, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x33,
+	0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2e, 0x4b,
+	0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x22, 0x28, 0x0a, 0x04, 0x4b, 0x69, 0x6e,
+	0x64, 0x12, 0x14, 0x0a, 0x10, 0x4b, 0x49, 0x4e, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0a, 0x0a, 0x06, 0x4b, 0x49, 0x4e, 0x44, 0x5f,
+	0x41, 0x10, 0x01, 0x42, 0x5d, 0x5a, 0x5b, 0x67, 0x6f, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64,
+	0x65, 0x2e, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2d,
+	0x61, 0x76, 0x72, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e,
+	0x2f, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+	0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_einride_avro_example_v1_example_protocol_shared_proto_rawDescOnce sync.Once
+	file_einride_avro_example_v1_example_protocol_shared_proto_rawDescData = file_einride_avro_example_v1_example_protocol_shared_proto_rawDesc
+)
+
+func file_einride_avro_example_v1_example_protocol_shared_proto_rawDescGZIP() []byte {
+	file_einride_avro_example_v1_example_protocol_shared_proto_rawDescOnce.Do(func() {
+		file_einride_avro_example_v1_example_protocol_shared_proto_rawDescData = protoimpl.X.CompressGZIP(file_einride_avro_example_v1_example_protocol_shared_proto_rawDescData)
+	})
+	return file_einride_avro_example_v1_example_protocol_shared_proto_rawDescData
+}
+
+var file_einride_avro_example_v1_example_protocol_shared_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_einride_avro_example_v1_example_protocol_shared_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_einride_avro_example_v1_example_protocol_shared_proto_goTypes = []interface{}{
+	(ExampleProtocolShared_Kind)(0), // 0: einride.avro.example.v1.ExampleProtocolShared.Kind
+	(*ExampleProtocolShared)(nil),   // 1: einride.avro.example.v1.ExampleProtocolShared
+}
+var file_einride_avro_example_v1_example_protocol_shared_proto_depIdxs = []int32{
+	0, // 0: einride.avro.example.v1.ExampleProtocolShared.kind:type_name -> einride.avro.example.v1.ExampleProtocolShared.Kind
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_einride_avro_example_v1_example_protocol_shared_proto_init() }
+func file_einride_avro_example_v1_example_protocol_shared_proto_init() {
+	if File_einride_avro_example_v1_example_protocol_shared_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_einride_avro_example_v1_example_protocol_shared_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleProtocolShared); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_einride_avro_example_v1_example_protocol_shared_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_avro_example_v1_example_protocol_shared_proto_goTypes,
+		DependencyIndexes: file_einride_avro_example_v1_example_protocol_shared_proto_depIdxs,
+		EnumInfos:         file_einride_avro_example_v1_example_protocol_shared_proto_enumTypes,
+		MessageInfos:      file_einride_avro_example_v1_example_protocol_shared_proto_msgTypes,
+	}.Build()
+	File_einride_avro_example_v1_example_protocol_shared_proto = out.File
+	file_einride_avro_example_v1_example_protocol_shared_proto_rawDesc = nil
+	file_einride_avro_example_v1_example_protocol_shared_proto_goTypes = nil
+	file_einride_avro_example_v1_example_protocol_shared_proto_depIdxs = nil
+}
@@ -0,0 +1,284 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        (unknown)
+// source: einride/avro/example/v1/example_redact.proto
+
+package examplev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ExampleRedact struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string                `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Secret     string                `protobuf:"bytes,2,opt,name=secret,proto3" json:"secret,omitempty"`
+	Pin        int64                 `protobuf:"varint,3,opt,name=pin,proto3" json:"pin,omitempty"`
+	Nested     *ExampleRedact_Nested `protobuf:"bytes,4,opt,name=nested,proto3" json:"nested,omitempty"`
+	SecretList []string              `protobuf:"bytes,5,rep,name=secret_list,json=secretList,proto3" json:"secret_list,omitempty"`
+}
+
+func (x *ExampleRedact) Reset() {
+	*x = ExampleRedact{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_redact_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleRedact) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleRedact) ProtoMessage() {}
+
+func (x *ExampleRedact) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_redact_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleRedact.ProtoReflect.Descriptor instead.
+func (*ExampleRedact) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_redact_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ExampleRedact) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ExampleRedact) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *ExampleRedact) GetPin() int64 {
+	if x != nil {
+		return x.Pin
+	}
+	return 0
+}
+
+func (x *ExampleRedact) GetNested() *ExampleRedact_Nested {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *ExampleRedact) GetSecretList() []string {
+	if x != nil {
+		return x.SecretList
+	}
+	return nil
+}
+
+type ExampleRedact_Nested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *ExampleRedact_Nested) Reset() {
+	*x = ExampleRedact_Nested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_einride_avro_example_v1_example_redact_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExampleRedact_Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExampleRedact_Nested) ProtoMessage() {}
+
+func (x *ExampleRedact_Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_einride_avro_example_v1_example_redact_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExampleRedact_Nested.ProtoReflect.Descriptor instead.
+func (*ExampleRedact_Nested) Descriptor() ([]byte, []int) {
+	return file_einride_avro_example_v1_example_redact_proto_rawDescGZIP(), []int{0, 0}
+}
+
+func (x *ExampleRedact_Nested) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+var file_einride_avro_example_v1_example_redact_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.FieldOptions)(nil),
+		ExtensionType: (*bool)(nil),
+		Field:         50000,
+		Name:          "einride.avro.example.v1.redact",
+		Tag:           "varint,50000,opt,name=redact",
+		Filename:      "einride/avro/example/v1/example_redact.proto",
+	},
+}
+
+// Extension fields to descriptorpb.FieldOptions.
+var (
+	// Fields with redact set to true contain sensitive values.
+	//
+	// optional bool redact = 50000;
+	E_Redact = &file_einride_avro_example_v1_example_redact_proto_extTypes[0]
+)
+
+var File_einride_avro_example_v1_example_redact_proto protoreflect.FileDescriptor
+
+var file_einride_avro_example_v1_example_redact_proto_rawDesc = []byte{
+	0x0a, 0x2c, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65,
+	0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c,
+	0x65, 0x5f, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x17,
+	0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e, 0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xed, 0x01, 0x0a, 0x0d, 0x45, 0x78,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x1c, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x42,
+	0x04, 0x80, 0xb5, 0x18, 0x01, 0x52, 0x06, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x16, 0x0a,
+	0x03, 0x70, 0x69, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x42, 0x04, 0x80, 0xb5, 0x18, 0x01,
+	0x52, 0x03, 0x70, 0x69, 0x6e, 0x12, 0x4b, 0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x2d, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2e,
+	0x61, 0x76, 0x72, 0x6f, 0x2e, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x2e, 0x4e, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x42, 0x04, 0x80, 0xb5, 0x18, 0x01, 0x52, 0x06, 0x6e, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x12, 0x25, 0x0a, 0x0b, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x5f, 0x6c, 0x69, 0x73,
+	0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x42, 0x04, 0x80, 0xb5, 0x18, 0x01, 0x52, 0x0a, 0x73,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x4c, 0x69, 0x73, 0x74, 0x1a, 0x1e, 0x0a, 0x06, 0x4e, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x37, 0x0a, 0x06, 0x72, 0x65, 0x64,
+	0x61, 0x63, 0x74, 0x12, 0x1d, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0xd0, 0x86, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x65, 0x64, 0x61,
+	0x63, 0x74, 0x42, 0x5d, 0x5a, 0x5b, 0x67, 0x6f, 0x2e, 0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65,
+	0x2e, 0x74, 0x65, 0x63, 0x68, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2d, 0x61,
+	0x76, 0x72, 0x6f, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x73, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f,
+	0x65, 0x69, 0x6e, 0x72, 0x69, 0x64, 0x65, 0x2f, 0x61, 0x76, 0x72, 0x6f, 0x2f, 0x65, 0x78, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x2f, 0x76, 0x31, 0x3b, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_einride_avro_example_v1_example_redact_proto_rawDescOnce sync.Once
+	file_einride_avro_example_v1_example_redact_proto_rawDescData = file_einride_avro_example_v1_example_redact_proto_rawDesc
+)
+
+func file_einride_avro_example_v1_example_redact_proto_rawDescGZIP() []byte {
+	file_einride_avro_example_v1_example_redact_proto_rawDescOnce.Do(func() {
+		file_einride_avro_example_v1_example_redact_proto_rawDescData = protoimpl.X.CompressGZIP(file_einride_avro_example_v1_example_redact_proto_rawDescData)
+	})
+	return file_einride_avro_example_v1_example_redact_proto_rawDescData
+}
+
+var file_einride_avro_example_v1_example_redact_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_einride_avro_example_v1_example_redact_proto_goTypes = []interface{}{
+	(*ExampleRedact)(nil),             // 0: einride.avro.example.v1.ExampleRedact
+	(*ExampleRedact_Nested)(nil),      // 1: einride.avro.example.v1.ExampleRedact.Nested
+	(*descriptorpb.FieldOptions)(nil), // 2: google.protobuf.FieldOptions
+}
+var file_einride_avro_example_v1_example_redact_proto_depIdxs = []int32{
+	1, // 0: einride.avro.example.v1.ExampleRedact.nested:type_name -> einride.avro.example.v1.ExampleRedact.Nested
+	2, // 1: einride.avro.example.v1.redact:extendee -> google.protobuf.FieldOptions
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	1, // [1:2] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_einride_avro_example_v1_example_redact_proto_init() }
+func file_einride_avro_example_v1_example_redact_proto_init() {
+	if File_einride_avro_example_v1_example_redact_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_einride_avro_example_v1_example_redact_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleRedact); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_einride_avro_example_v1_example_redact_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ExampleRedact_Nested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_einride_avro_example_v1_example_redact_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_einride_avro_example_v1_example_redact_proto_goTypes,
+		DependencyIndexes: file_einride_avro_example_v1_example_redact_proto_depIdxs,
+		MessageInfos:      file_einride_avro_example_v1_example_redact_proto_msgTypes,
+		ExtensionInfos:    file_einride_avro_example_v1_example_redact_proto_extTypes,
+	}.Build()
+	File_einride_avro_example_v1_example_redact_proto = out.File
+	file_einride_avro_example_v1_example_redact_proto_rawDesc = nil
+	file_einride_avro_example_v1_example_redact_proto_goTypes = nil
+	file_einride_avro_example_v1_example_redact_proto_depIdxs = nil
+}